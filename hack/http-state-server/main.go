@@ -0,0 +1,43 @@
+// http-state-server runs the reference http state service locally for testing the http state backend.
+//
+// Usage:
+//
+//	go run ./hack/http-state-server -addr :8080 -token kusion
+//
+// Then configure the http backend with the default url formats, for example:
+//
+//	kusion apply --backend-type http -C urlPrefix=http://127.0.0.1:8080 -C token=kusion \
+//		-C applyURLFormat=/apis/v1/tenants/%s/projects/%s/stacks/%s/clusters/%s/states/ \
+//		-C getLatestURLFormat=/apis/v1/tenants/%s/projects/%s/stacks/%s/clusters/%s/states/
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"kusionstack.io/kusion/pkg/engine/states/remote/http/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "the address to listen on")
+	token := flag.String("token", "", "the required bearer token, no auth if empty")
+	certFile := flag.String("tls-cert-file", "", "the TLS certificate file, serve https if set with tls-key-file")
+	keyFile := flag.String("tls-key-file", "", "the TLS key file")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.NewServer(*token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("http state server listening on %s", *addr)
+	var err error
+	if *certFile != "" && *keyFile != "" {
+		err = srv.ListenAndServeTLS(*certFile, *keyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	log.Fatal(err)
+}
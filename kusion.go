@@ -7,6 +7,9 @@ import (
 
 	"kusionstack.io/kusion/pkg/cmd"
 	"kusionstack.io/kusion/pkg/util/pretty"
+
+	// register the secret store providers
+	_ "kusionstack.io/kusion/pkg/secrets/providers/register"
 )
 
 func main() {
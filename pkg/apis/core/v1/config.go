@@ -24,11 +24,20 @@ const (
 	BackendGenericOssBucket   = "bucket"
 	BackendGenericOssPrefix   = "prefix"
 	BackendS3Region           = "region"
+	BackendPostgresSSLMode    = "sslMode"
+	BackendHttpURLPrefix      = "urlPrefix"
+	BackendHttpToken          = "token"
+
+	BackendEncryptionKeyFile         = "encryptionKeyFile"
+	BackendEncryptionKeyEnv          = "encryptionKeyEnv"
+	BackendEncryptionAgeRecipients   = "encryptionAgeRecipients"
+	BackendEncryptionAgeIdentityFile = "encryptionAgeIdentityFile"
 
 	BackendTypeLocal = "local"
 	BackendTypeMysql = "mysql"
 	BackendTypeOss   = "oss"
 	BackendTypeS3    = "s3"
+	BackendTypeHttp  = "http"
 )
 
 // BackendConfigs contains the configuration of multiple backends and the current backend.
@@ -42,7 +51,8 @@ type BackendConfigs struct {
 
 // BackendConfig contains the type and configs of a backend, which is used to store Spec, State and Workspace.
 type BackendConfig struct {
-	// Type is the backend type, supports BackendTypeLocal, BackendTypeMysql, BackendTypeOss, BackendTypeS3.
+	// Type is the backend type, supports BackendTypeLocal, BackendTypeMysql, BackendTypeOss, BackendTypeS3,
+	// BackendTypeHttp.
 	Type string `yaml:"type,omitempty"`
 
 	// Configs contains config items of the backend, whose keys differ from different backend types.
@@ -90,6 +100,16 @@ type BackendS3Config struct {
 	Region string `yaml:"region,omitempty" json:"region,omitempty"`
 }
 
+// BackendHttpConfig contains the config of using a http service as backend, which can be converted from
+// BackendConfig if Type is BackendTypeHttp.
+type BackendHttpConfig struct {
+	// URLPrefix is the address of the http service, such as "https://kusion.example.com".
+	URLPrefix string `yaml:"urlPrefix" json:"urlPrefix"`
+
+	// Token is the bearer token used to access the http service.
+	Token string `yaml:"token,omitempty" json:"token,omitempty"`
+}
+
 // GenericBackendObjectStorageConfig contains generic configs which can be reused by BackendOssConfig and
 // BackendS3Config.
 type GenericBackendObjectStorageConfig struct {
@@ -186,3 +206,17 @@ func (b *BackendConfig) ToS3Backend() *BackendS3Config {
 		Region: region,
 	}
 }
+
+// ToHttpBackend converts BackendConfig to structured BackendHttpConfig, works only when the Type is
+// BackendTypeHttp, and the Configs are with correct type, or return nil.
+func (b *BackendConfig) ToHttpBackend() *BackendHttpConfig {
+	if b.Type != BackendTypeHttp {
+		return nil
+	}
+	urlPrefix, _ := b.Configs[BackendHttpURLPrefix].(string)
+	token, _ := b.Configs[BackendHttpToken].(string)
+	return &BackendHttpConfig{
+		URLPrefix: urlPrefix,
+		Token:     token,
+	}
+}
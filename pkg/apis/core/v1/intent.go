@@ -14,6 +14,10 @@ const (
 	// ResourceExtensionKubeConfig is the key for resource extension, which is used
 	// to indicate the path of kubeConfig for Kubernetes type resource.
 	ResourceExtensionKubeConfig = "kubeConfig"
+	// ResourceExtensionGeneratedKeys is the key for resource extension, which is used
+	// to record the keys of the data generated by Kusion, such as the random token of
+	// a Secret, whose values are reused from the prior state until rotated.
+	ResourceExtensionGeneratedKeys = "generatedKeys"
 )
 
 // Intent describes the desired state how the infrastructure should look like: which workload to run,
@@ -0,0 +1,35 @@
+package workload
+
+const (
+	FieldAutoscaling = "autoscaling"
+
+	AutoscalingMetricPods     AutoscalingMetricType = "Pods"
+	AutoscalingMetricExternal AutoscalingMetricType = "External"
+)
+
+// Autoscaling describes the horizontal autoscaling of the workload, which is configured by the workspace module
+// config. The replicas of the workload are managed by the HorizontalPodAutoscaler if it is set.
+type Autoscaling struct {
+	// MinReplicas is the lower limit of the replicas, defaults to 1.
+	MinReplicas *int32 `yaml:"minReplicas,omitempty" json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit of the replicas.
+	MaxReplicas int32 `yaml:"maxReplicas" json:"maxReplicas"`
+	// CPUUtilization is the target average CPU utilization in percentage of the requested CPU.
+	CPUUtilization *int32 `yaml:"cpuUtilization,omitempty" json:"cpuUtilization,omitempty"`
+	// MemoryUtilization is the target average memory utilization in percentage of the requested memory.
+	MemoryUtilization *int32 `yaml:"memoryUtilization,omitempty" json:"memoryUtilization,omitempty"`
+	// Metrics are the custom metrics to scale on.
+	Metrics []AutoscalingMetric `yaml:"metrics,omitempty" json:"metrics,omitempty"`
+}
+
+type AutoscalingMetricType string
+
+// AutoscalingMetric describes a custom metric and its target average value.
+type AutoscalingMetric struct {
+	// Type is the type of the metric, support Pods and External, defaults to Pods.
+	Type AutoscalingMetricType `yaml:"type,omitempty" json:"type,omitempty"`
+	// Name is the name of the metric.
+	Name string `yaml:"name" json:"name"`
+	// AverageValue is the target average value of the metric per pod, e.g. 100 or 500m.
+	AverageValue string `yaml:"averageValue" json:"averageValue"`
+}
@@ -5,6 +5,31 @@ import "kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
 const (
 	FieldLabels      = "labels"
 	FieldAnnotations = "annotations"
+
+	// The fields of the workspace module config to schedule the pods, whose values are in the format of the
+	// corresponding fields of the Kubernetes PodSpec.
+	FieldNodeSelector              = "nodeSelector"
+	FieldTolerations               = "tolerations"
+	FieldAffinity                  = "affinity"
+	FieldTopologySpreadConstraints = "topologySpreadConstraints"
+	FieldPriorityClassName         = "priorityClassName"
+
+	// The fields of the workspace module config about the security. FieldSecurityContext is the pod-level security
+	// context, FieldContainerSecurityContext is the default security context of the containers, and
+	// FieldPodSecurityLevel is the Pod Security Standards level the generated pods must satisfy, which is one of
+	// privileged, baseline and restricted.
+	FieldSecurityContext          = "securityContext"
+	FieldContainerSecurityContext = "containerSecurityContext"
+	FieldPodSecurityLevel         = "podSecurityLevel"
+
+	// FieldNativeSidecar is the field of the workspace module config indicating whether the cluster supports the
+	// native sidecar containers, i.e. the init containers with restartPolicy Always, which is available since
+	// Kubernetes v1.29 by default. If not, the sidecars are generated as regular containers.
+	FieldNativeSidecar = "nativeSidecar"
+
+	// FieldPodDisruptionBudget is the field of the workspace module config to generate a PodDisruptionBudget,
+	// whose value has either minAvailable or maxUnavailable.
+	FieldPodDisruptionBudget = "podDisruptionBudget"
 )
 
 // Base defines set of attributes shared by different workload profile, e.g. Service and Job. You can inherit this Schema to reuse these
@@ -12,6 +37,12 @@ const (
 type Base struct {
 	// The templates of containers to be run.
 	Containers map[string]container.Container `yaml:"containers,omitempty" json:"containers,omitempty"`
+	// The templates of init containers to be run to completion before the containers are started, in the
+	// alphabetical order of their names.
+	InitContainers map[string]container.Container `yaml:"initContainers,omitempty" json:"initContainers,omitempty"`
+	// The templates of sidecar containers to be run alongside the containers, e.g. the proxies or log
+	// collectors, which are started after the init containers.
+	Sidecars map[string]container.Container `yaml:"sidecars,omitempty" json:"sidecars,omitempty"`
 	// The number of containers that should be run.
 	Replicas *int32 `yaml:"replicas,omitempty" json:"replicas,omitempty"`
 	// Secret
@@ -7,6 +7,10 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// ErrGrpcLifecycleHandler is returned if the Grpc action is used as a lifecycle handler, which is only supported
+// by the probes.
+var ErrGrpcLifecycleHandler = errors.New("grpc action is not supported by the lifecycle handler")
+
 // Container describes how the App's tasks are expected to be run.
 type Container struct {
 	// Image to run for this container
@@ -36,6 +40,8 @@ type Container struct {
 	StartupProbe *Probe `yaml:"startupProbe,omitempty" json:"startupProbe,omitempty"`
 	// Actions that the management system should take in response to container lifecycle events.
 	Lifecycle *Lifecycle `yaml:"lifecycle,omitempty" json:"lifecycle,omitempty"`
+	// Security options the container should be run with, which override the defaults of the workspace.
+	SecurityContext *SecurityContext `yaml:"securityContext,omitempty" json:"securityContext,omitempty"`
 }
 
 // FileSpec defines the target file in a Container
@@ -85,6 +91,9 @@ type ProbeHandler struct {
 	// TCPSocket specifies an action involving a TCP port.
 	// +optional
 	*TCPSocketAction `yaml:",inline" json:",inline"`
+	// GRPC specifies an action involving a gRPC port.
+	// +optional
+	*GRPCAction `yaml:",inline" json:",inline"`
 }
 
 // ExecAction describes a "run in container" action.
@@ -109,6 +118,15 @@ type TCPSocketAction struct {
 	URL string `yaml:"url,omitempty" json:"url,omitempty"`
 }
 
+// GRPCAction describes an action involving a gRPC service by the gRPC health checking protocol.
+type GRPCAction struct {
+	// Port number of the gRPC service.
+	Port int32 `yaml:"port" json:"port"`
+	// Service is the name of the service to place in the gRPC HealthCheckRequest.
+	// If this is not specified, the default behavior is defined by gRPC.
+	Service string `yaml:"service,omitempty" json:"service,omitempty"`
+}
+
 // Lifecycle describes actions that the management system should take in response
 // to container lifecycle events.
 type Lifecycle struct {
@@ -133,6 +151,42 @@ type LifecycleHandler struct {
 	*HTTPGetAction `yaml:",inline" json:",inline"`
 }
 
+// SecurityContext holds the security options the container should be run with.
+type SecurityContext struct {
+	// The UID to run the entrypoint of the container process.
+	RunAsUser *int64 `yaml:"runAsUser,omitempty" json:"runAsUser,omitempty"`
+	// The GID to run the entrypoint of the container process.
+	RunAsGroup *int64 `yaml:"runAsGroup,omitempty" json:"runAsGroup,omitempty"`
+	// Indicates that the container must run as a non-root user.
+	RunAsNonRoot *bool `yaml:"runAsNonRoot,omitempty" json:"runAsNonRoot,omitempty"`
+	// Run container in privileged mode.
+	Privileged *bool `yaml:"privileged,omitempty" json:"privileged,omitempty"`
+	// Whether a process can gain more privileges than its parent process.
+	AllowPrivilegeEscalation *bool `yaml:"allowPrivilegeEscalation,omitempty" json:"allowPrivilegeEscalation,omitempty"`
+	// Whether the container has a read-only root filesystem.
+	ReadOnlyRootFilesystem *bool `yaml:"readOnlyRootFilesystem,omitempty" json:"readOnlyRootFilesystem,omitempty"`
+	// The capabilities to add or drop when running the container.
+	Capabilities *Capabilities `yaml:"capabilities,omitempty" json:"capabilities,omitempty"`
+	// The seccomp options used by the container.
+	SeccompProfile *SeccompProfile `yaml:"seccompProfile,omitempty" json:"seccompProfile,omitempty"`
+}
+
+// Capabilities describes the POSIX capabilities added or dropped from the container, e.g. NET_BIND_SERVICE or ALL.
+type Capabilities struct {
+	// Added capabilities.
+	Add []string `yaml:"add,omitempty" json:"add,omitempty"`
+	// Dropped capabilities.
+	Drop []string `yaml:"drop,omitempty" json:"drop,omitempty"`
+}
+
+// SeccompProfile defines the seccomp profile settings.
+type SeccompProfile struct {
+	// Type of the seccomp profile, which is one of RuntimeDefault, Localhost and Unconfined.
+	Type string `yaml:"type" json:"type"`
+	// The path of the profile on the node, only set if the type is Localhost.
+	LocalhostProfile string `yaml:"localhostProfile,omitempty" json:"localhostProfile,omitempty"`
+}
+
 // MarshalJSON implements the json.Marshaler interface for ProbeHandler.
 func (p *ProbeHandler) MarshalJSON() ([]byte, error) {
 	switch p.Type {
@@ -160,6 +214,14 @@ func (p *ProbeHandler) MarshalJSON() ([]byte, error) {
 			TypeWrapper:     TypeWrapper{p.Type},
 			TCPSocketAction: p.TCPSocketAction,
 		})
+	case "Grpc":
+		return json.Marshal(struct {
+			TypeWrapper `json:",inline"`
+			*GRPCAction `json:",inline"`
+		}{
+			TypeWrapper: TypeWrapper{p.Type},
+			GRPCAction:  p.GRPCAction,
+		})
 	default:
 		return nil, errors.New("unrecognized probe handler type")
 	}
@@ -187,6 +249,10 @@ func (p *ProbeHandler) UnmarshalJSON(data []byte) error {
 		handler := &TCPSocketAction{}
 		err = json.Unmarshal(data, handler)
 		p.TCPSocketAction = handler
+	case "Grpc":
+		handler := &GRPCAction{}
+		err = json.Unmarshal(data, handler)
+		p.GRPCAction = handler
 	default:
 		return errors.New("unrecognized probe handler type")
 	}
@@ -221,6 +287,14 @@ func (p *ProbeHandler) MarshalYAML() (interface{}, error) {
 			TypeWrapper:     TypeWrapper{Type: p.Type},
 			TCPSocketAction: *p.TCPSocketAction,
 		}, nil
+	case "Grpc":
+		return struct {
+			TypeWrapper `yaml:",inline" json:",inline"`
+			GRPCAction  `yaml:",inline" json:",inline"`
+		}{
+			TypeWrapper: TypeWrapper{Type: p.Type},
+			GRPCAction:  *p.GRPCAction,
+		}, nil
 	}
 
 	return nil, nil
@@ -248,6 +322,10 @@ func (p *ProbeHandler) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		handler := &TCPSocketAction{}
 		err = unmarshal(handler)
 		p.TCPSocketAction = handler
+	case "Grpc":
+		handler := &GRPCAction{}
+		err = unmarshal(handler)
+		p.GRPCAction = handler
 	default:
 		return errors.New("unrecognized probe handler type")
 	}
@@ -274,6 +352,8 @@ func (l *LifecycleHandler) MarshalJSON() ([]byte, error) {
 			TypeWrapper: TypeWrapper{l.Type},
 			ExecAction:  l.ExecAction,
 		})
+	case "Grpc":
+		return nil, ErrGrpcLifecycleHandler
 	default:
 		return nil, errors.New("unrecognized lifecycle handler type")
 	}
@@ -297,6 +377,8 @@ func (l *LifecycleHandler) UnmarshalJSON(data []byte) error {
 		handler := &ExecAction{}
 		err = json.Unmarshal(data, handler)
 		l.ExecAction = handler
+	case "Grpc":
+		return ErrGrpcLifecycleHandler
 	default:
 		return errors.New("unrecognized lifecycle handler type")
 	}
@@ -323,6 +405,8 @@ func (l *LifecycleHandler) MarshalYAML() (interface{}, error) {
 			TypeWrapper: TypeWrapper{Type: l.Type},
 			ExecAction:  *l.ExecAction,
 		}, nil
+	case "Grpc":
+		return nil, ErrGrpcLifecycleHandler
 	default:
 		return nil, errors.New("unrecognized lifecycle handler type")
 	}
@@ -346,6 +430,8 @@ func (l *LifecycleHandler) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		handler := &ExecAction{}
 		err = unmarshal(handler)
 		l.ExecAction = handler
+	case "Grpc":
+		return ErrGrpcLifecycleHandler
 	default:
 		return errors.New("unrecognized lifecycle handler type")
 	}
@@ -2,6 +2,7 @@ package container
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -789,3 +790,64 @@ lifecycle:
 		}
 	}
 }
+
+func TestGrpcProbeHandler(t *testing.T) {
+	c := Container{
+		Image: "grpc-server:v1",
+		LivenessProbe: &Probe{
+			ProbeHandler: &ProbeHandler{
+				TypeWrapper: TypeWrapper{Type: "Grpc"},
+				GRPCAction: &GRPCAction{
+					Port:    9090,
+					Service: "health",
+				},
+			},
+			PeriodSeconds: 5,
+		},
+	}
+	jsonResult := `{"image":"grpc-server:v1","livenessProbe":{"probeHandler":{"_type":"Grpc","port":9090,"service":"health"},"periodSeconds":5}}`
+	yamlResult := `image: grpc-server:v1
+livenessProbe:
+  probeHandler:
+    _type: Grpc
+    port: 9090
+    service: health
+  periodSeconds: 5
+`
+
+	jsonData, err := json.Marshal(&c)
+	if err != nil || string(jsonData) != jsonResult {
+		t.Errorf("Failed to marshal grpc probe to json: expected %q, got %q, err %v", jsonResult, string(jsonData), err)
+	}
+	var jsonContainer Container
+	if err = json.Unmarshal([]byte(jsonResult), &jsonContainer); err != nil || !reflect.DeepEqual(jsonContainer, c) {
+		t.Errorf("Failed to unmarshal grpc probe from json: expected %+v, got %+v, err %v", c, jsonContainer, err)
+	}
+
+	yamlData, err := yaml.Marshal(&c)
+	if err != nil || string(yamlData) != yamlResult {
+		t.Errorf("Failed to marshal grpc probe to yaml: expected %q, got %q, err %v", yamlResult, string(yamlData), err)
+	}
+	var yamlContainer Container
+	if err = yaml.Unmarshal([]byte(yamlResult), &yamlContainer); err != nil || !reflect.DeepEqual(yamlContainer, c) {
+		t.Errorf("Failed to unmarshal grpc probe from yaml: expected %+v, got %+v, err %v", c, yamlContainer, err)
+	}
+}
+
+func TestGrpcLifecycleHandler(t *testing.T) {
+	handler := &LifecycleHandler{TypeWrapper: TypeWrapper{Type: "Grpc"}}
+	if _, err := json.Marshal(&Container{Image: "nginx:v1", Lifecycle: &Lifecycle{PreStop: handler}}); !errors.Is(err, ErrGrpcLifecycleHandler) {
+		t.Errorf("Expected error %v when marshaling grpc lifecycle handler to json, got %v", ErrGrpcLifecycleHandler, err)
+	}
+	if _, err := yaml.Marshal(&Container{Image: "nginx:v1", Lifecycle: &Lifecycle{PreStop: handler}}); err == nil {
+		t.Errorf("Expected error when marshaling grpc lifecycle handler to yaml")
+	}
+
+	var c Container
+	if err := json.Unmarshal([]byte(`{"image":"nginx:v1","lifecycle":{"preStop":{"_type":"Grpc","port":9090}}}`), &c); !errors.Is(err, ErrGrpcLifecycleHandler) {
+		t.Errorf("Expected error %v when unmarshaling grpc lifecycle handler from json, got %v", ErrGrpcLifecycleHandler, err)
+	}
+	if err := yaml.Unmarshal([]byte("image: nginx:v1\nlifecycle:\n  preStop:\n    _type: Grpc\n    port: 9090\n"), &c); !errors.Is(err, ErrGrpcLifecycleHandler) {
+		t.Errorf("Expected error %v when unmarshaling grpc lifecycle handler from yaml, got %v", ErrGrpcLifecycleHandler, err)
+	}
+}
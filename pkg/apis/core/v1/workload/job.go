@@ -2,10 +2,62 @@ package workload
 
 const ModuleJob = "job"
 
+// The fields of the workspace job module config used as the defaults of the job options unset by the application.
+const (
+	FieldBackoffLimit               = "backoffLimit"
+	FieldActiveDeadlineSeconds      = "activeDeadlineSeconds"
+	FieldTTLSecondsAfterFinished    = "ttlSecondsAfterFinished"
+	FieldCompletions                = "completions"
+	FieldParallelism                = "parallelism"
+	FieldRestartPolicy              = "restartPolicy"
+	FieldConcurrencyPolicy          = "concurrencyPolicy"
+	FieldStartingDeadlineSeconds    = "startingDeadlineSeconds"
+	FieldSuspend                    = "suspend"
+	FieldSuccessfulJobsHistoryLimit = "successfulJobsHistoryLimit"
+	FieldFailedJobsHistoryLimit     = "failedJobsHistoryLimit"
+)
+
+type (
+	JobRestartPolicy     string
+	JobConcurrencyPolicy string
+)
+
+const (
+	JobRestartPolicyNever     JobRestartPolicy = "Never"
+	JobRestartPolicyOnFailure JobRestartPolicy = "OnFailure"
+
+	JobConcurrencyPolicyAllow   JobConcurrencyPolicy = "Allow"
+	JobConcurrencyPolicyForbid  JobConcurrencyPolicy = "Forbid"
+	JobConcurrencyPolicyReplace JobConcurrencyPolicy = "Replace"
+)
+
 // Job is a kind of workload profile that describes how to run your application code. This is typically used for tasks that take from
 // a few seconds to a few days to complete.
 type Job struct {
 	Base `yaml:",inline" json:",inline"`
 	// The scheduling strategy in Cron format: https://en.wikipedia.org/wiki/Cron.
 	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	// The number of retries before marking the job failed.
+	BackoffLimit *int32 `yaml:"backoffLimit,omitempty" json:"backoffLimit,omitempty"`
+	// The duration in seconds relative to the start time that the job may be continuously active before it is
+	// terminated.
+	ActiveDeadlineSeconds *int64 `yaml:"activeDeadlineSeconds,omitempty" json:"activeDeadlineSeconds,omitempty"`
+	// The duration in seconds after the job finishes before it is deleted automatically.
+	TTLSecondsAfterFinished *int32 `yaml:"ttlSecondsAfterFinished,omitempty" json:"ttlSecondsAfterFinished,omitempty"`
+	// The desired number of successfully finished pods the job should be run with.
+	Completions *int32 `yaml:"completions,omitempty" json:"completions,omitempty"`
+	// The maximum desired number of pods the job should run at any given time.
+	Parallelism *int32 `yaml:"parallelism,omitempty" json:"parallelism,omitempty"`
+	// The restart policy of the containers of the job, which is one of Never and OnFailure, defaults to Never.
+	RestartPolicy JobRestartPolicy `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
+	// How to treat the concurrent executions of the scheduled job, which is one of Allow, Forbid and Replace.
+	ConcurrencyPolicy JobConcurrencyPolicy `yaml:"concurrencyPolicy,omitempty" json:"concurrencyPolicy,omitempty"`
+	// The deadline in seconds for starting the scheduled job if it misses the scheduled time.
+	StartingDeadlineSeconds *int64 `yaml:"startingDeadlineSeconds,omitempty" json:"startingDeadlineSeconds,omitempty"`
+	// Whether to suspend the job, or the subsequent executions of the scheduled job.
+	Suspend *bool `yaml:"suspend,omitempty" json:"suspend,omitempty"`
+	// The number of the successful finished jobs of the scheduled job to retain.
+	SuccessfulJobsHistoryLimit *int32 `yaml:"successfulJobsHistoryLimit,omitempty" json:"successfulJobsHistoryLimit,omitempty"`
+	// The number of the failed finished jobs of the scheduled job to retain.
+	FailedJobsHistoryLimit *int32 `yaml:"failedJobsHistoryLimit,omitempty" json:"failedJobsHistoryLimit,omitempty"`
 }
@@ -11,6 +11,8 @@ const (
 	ModuleServiceType             = "type"
 	Deployment        ServiceType = "Deployment"
 	Collaset          ServiceType = "CollaSet"
+	StatefulSet       ServiceType = "StatefulSet"
+	DaemonSet         ServiceType = "DaemonSet"
 )
 
 // Service is a kind of workload profile that describes how to run your application code.
@@ -18,8 +20,29 @@ const (
 // web requests, or events.
 type Service struct {
 	Base `yaml:",inline" json:",inline"`
-	// Type represents the type of workload.Service, support Deployment and CollaSet.
+	// Type represents the type of workload.Service, support Deployment, CollaSet, StatefulSet and DaemonSet.
+	// The replicas are ignored by DaemonSet, which runs a pod on each node.
 	Type ServiceType `yaml:"type" json:"type"`
 	// Ports describe the list of ports need getting exposed.
 	Ports []network.Port `yaml:"ports,omitempty" json:"ports,omitempty"`
+	// PodManagementPolicy controls how pods are created during initial scale up, when replacing pods on nodes,
+	// or when scaling down, support OrderedReady and Parallel. Only valid for StatefulSet.
+	PodManagementPolicy string `yaml:"podManagementPolicy,omitempty" json:"podManagementPolicy,omitempty"`
+	// VolumeClaimTemplates are the persistent volume claims created for each pod, which are mounted to all the
+	// containers, including the initContainers and sidecars. Only valid for StatefulSet.
+	VolumeClaimTemplates []VolumeClaimTemplate `yaml:"volumeClaimTemplates,omitempty" json:"volumeClaimTemplates,omitempty"`
+}
+
+// VolumeClaimTemplate describes a persistent volume claim created for each pod of the StatefulSet.
+type VolumeClaimTemplate struct {
+	// Name of the persistent volume claim, which is also the name of the volume in the pod.
+	Name string `yaml:"name" json:"name"`
+	// MountPath is the path in the containers where the volume is mounted.
+	MountPath string `yaml:"mountPath" json:"mountPath"`
+	// Size is the requested storage size, e.g. 10Gi.
+	Size string `yaml:"size" json:"size"`
+	// StorageClassName is the name of the StorageClass, and the default StorageClass is used if not set.
+	StorageClassName string `yaml:"storageClassName,omitempty" json:"storageClassName,omitempty"`
+	// AccessModes of the persistent volume claim, defaults to ReadWriteOnce.
+	AccessModes []string `yaml:"accessModes,omitempty" json:"accessModes,omitempty"`
 }
@@ -4,18 +4,22 @@ const (
 	DefaultBlock         = "default"
 	ProjectSelectorField = "projectSelector"
 
-	DeprecatedBackendLocal  = "local"
-	DeprecatedBackendMysql  = "mysql"
-	DeprecatedBackendOss    = "oss"
-	DeprecatedBackendS3     = "s3"
-	EnvBackendMysqlPassword = "KUSION_BACKEND_MYSQL_PASSWORD"
-	EnvAwsAccessKeyID       = "AWS_ACCESS_KEY_ID"
-	EnvAwsSecretAccessKey   = "AWS_SECRET_ACCESS_KEY"
-	EnvAwsDefaultRegion     = "AWS_DEFAULT_REGION"
-	EnvAwsRegion            = "AWS_REGION"
-	EnvOssAccessKeyID       = "OSS_ACCESS_KEY_ID"
-	EnvOssAccessKeySecret   = "OSS_ACCESS_KEY_SECRET"
-	DefaultMysqlPort        = 3306
+	DeprecatedBackendLocal     = "local"
+	DeprecatedBackendMysql     = "mysql"
+	DeprecatedBackendOss       = "oss"
+	DeprecatedBackendS3        = "s3"
+	DeprecatedBackendPostgres  = "postgres"
+	DeprecatedBackendSqlite    = "sqlite"
+	EnvBackendMysqlPassword    = "KUSION_BACKEND_MYSQL_PASSWORD"
+	EnvBackendPostgresPassword = "KUSION_BACKEND_POSTGRES_PASSWORD"
+	EnvAwsAccessKeyID          = "AWS_ACCESS_KEY_ID"
+	EnvAwsSecretAccessKey      = "AWS_SECRET_ACCESS_KEY"
+	EnvAwsDefaultRegion        = "AWS_DEFAULT_REGION"
+	EnvAwsRegion               = "AWS_REGION"
+	EnvOssAccessKeyID          = "OSS_ACCESS_KEY_ID"
+	EnvOssAccessKeySecret      = "OSS_ACCESS_KEY_SECRET"
+	DefaultMysqlPort           = 3306
+	DefaultPostgresPort        = 5432
 )
 
 // Workspace is a logical concept representing a target that stacks will be deployed to.
@@ -27,6 +31,10 @@ type Workspace struct {
 	// Name identifies a Workspace uniquely.
 	Name string `yaml:"-" json:"-"`
 
+	// Extends are the names of the workspaces which this workspace inherits from. The configs of the extended
+	// workspaces are deep merged in order, and then overridden by the configs of this workspace.
+	Extends []string `yaml:"extends,omitempty" json:"extends,omitempty"`
+
 	// Modules are the configs of a set of modules.
 	Modules ModuleConfigs `yaml:"modules,omitempty" json:"modules,omitempty"`
 
@@ -137,6 +145,12 @@ type DeprecatedBackendConfigs struct {
 
 	// S3 is the backend using S3.
 	S3 *DeprecatedS3Config `yaml:"s3,omitempty" json:"s3,omitempty"`
+
+	// Postgres is the backend using postgresql database.
+	Postgres *DeprecatedPostgresConfig `yaml:"postgres,omitempty" json:"postgres,omitempty"`
+
+	// Sqlite is the backend using sqlite database file.
+	Sqlite *DeprecatedSqliteConfig `yaml:"sqlite,omitempty" json:"sqlite,omitempty"`
 }
 
 // DeprecatedLocalFileConfig contains the config of using local file system as backend. Now there is no configuration
@@ -163,6 +177,35 @@ type DeprecatedMysqlConfig struct {
 	Port *int `yaml:"port,omitempty" json:"port,omitempty"`
 }
 
+// DeprecatedPostgresConfig contains the config of using postgresql database as backend.
+// Deprecated: do not support backend configs in workspace anymore
+type DeprecatedPostgresConfig struct {
+	// DBName is the database name.
+	DBName string `yaml:"dbName" json:"dbName"`
+
+	// User of the database.
+	User string `yaml:"user" json:"user"`
+
+	// Password of the database.
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+
+	// Host of the database.
+	Host string `yaml:"host" json:"host"`
+
+	// Port of the database. If not set, then it will be set to DefaultPostgresPort.
+	Port *int `yaml:"port,omitempty" json:"port,omitempty"`
+
+	// SSLMode of the connection, such as disable, require, verify-full.
+	SSLMode string `yaml:"sslMode,omitempty" json:"sslMode,omitempty"`
+}
+
+// DeprecatedSqliteConfig contains the config of using sqlite database file as backend.
+// Deprecated: do not support backend configs in workspace anymore
+type DeprecatedSqliteConfig struct {
+	// Path of the database file. If not set, then it will be the file kusion_state.db in the stack directory.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+}
+
 // DeprecatedOssConfig contains the config of using OSS as backend.
 // Deprecated: do not support backend configs in workspace anymore
 type DeprecatedOssConfig struct {
@@ -219,6 +262,32 @@ type ExternalSecretRef struct {
 // SecretStoreSpec contains configuration to describe target secret store.
 type SecretStoreSpec struct {
 	Provider *ProviderSpec `yaml:"provider" json:"provider"`
+
+	// ExternalSecrets configures to sync the external secrets by the external-secrets operator in the cluster. If set,
+	// the ExternalSecret and SecretStore or ClusterSecretStore resources are generated, rather than the Secret with
+	// the data resolved at build time.
+	ExternalSecrets *ExternalSecretsConfig `yaml:"externalSecrets,omitempty" json:"externalSecrets,omitempty"`
+}
+
+// ExternalSecretsConfig contains the configuration of the resources synced by the external-secrets operator.
+type ExternalSecretsConfig struct {
+	// ClusterScoped generates a ClusterSecretStore rather than a SecretStore in the namespace of the application.
+	// The ClusterSecretStore is generated once and shared by all the applications of the project, which is labeled
+	// with the project as the owner.
+	ClusterScoped bool `yaml:"clusterScoped,omitempty" json:"clusterScoped,omitempty"`
+
+	// StoreName is the name of the generated store, defaults to "kusion-secret-store" for the SecretStore and
+	// "<project>-kusion-secret-store" for the ClusterSecretStore, so that the projects own their stores.
+	StoreName string `yaml:"storeName,omitempty" json:"storeName,omitempty"`
+
+	// RefreshInterval is the amount of time before the secrets are read again from the provider, such as "1h",
+	// defaults to the default of the external-secrets operator.
+	RefreshInterval string `yaml:"refreshInterval,omitempty" json:"refreshInterval,omitempty"`
+
+	// ProviderConfig contains the additional fields of the provider in the generated store, which are merged into
+	// the provider config mapped from the secret store spec, such as the auth referring to the credentials in the
+	// cluster.
+	ProviderConfig GenericConfig `yaml:"providerConfig,omitempty" json:"providerConfig,omitempty"`
 }
 
 // ProviderSpec contains provider-specific configuration.
@@ -237,6 +306,12 @@ type ProviderSpec struct {
 
 	// Fake configures a store with static key/value pairs
 	Fake *FakeProvider `yaml:"fake,omitempty" json:"fake,omitempty"`
+
+	// File configures a store to retrieve secrets from a local file encrypted by age or PGP.
+	File *FileProvider `yaml:"file,omitempty" json:"file,omitempty"`
+
+	// Kubernetes configures a store to retrieve secrets from the existing Secrets of a Kubernetes cluster.
+	Kubernetes *KubernetesProvider `yaml:"kubernetes,omitempty" json:"kubernetes,omitempty"`
 }
 
 // AlicloudProvider configures a store to retrieve secrets from Alicloud Secrets Manager.
@@ -268,6 +343,97 @@ type VaultProvider struct {
 	// Version is the Vault KV secret engine version. Version can be either "v1" or
 	// "v2", defaults to "v2".
 	Version VaultKVStoreVersion `yaml:"version" json:"version"`
+
+	// Namespace is the Vault Enterprise namespace to interact with, e.g: "ns1/ns2".
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Auth configures how to authenticate with Vault. If not set, the token read from the environment variable
+	// VAULT_SERVER_TOKEN or VAULT_TOKEN is used.
+	Auth *VaultAuth `yaml:"auth,omitempty" json:"auth,omitempty"`
+
+	// TLS configures the TLS connection to Vault, such as the custom CA certificate.
+	TLS *VaultTLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
+}
+
+// VaultAuth configures the method to authenticate with Vault, and only one method can be specified. It logs in
+// again once the token obtained by login is about to expire.
+type VaultAuth struct {
+	// Token authenticates with a static token.
+	Token *VaultTokenAuth `yaml:"token,omitempty" json:"token,omitempty"`
+
+	// AppRole authenticates with the AppRole auth method, which is usually used in CI.
+	AppRole *VaultAppRoleAuth `yaml:"appRole,omitempty" json:"appRole,omitempty"`
+
+	// Kubernetes authenticates with the Kubernetes auth method by the service account token, which is usually
+	// used in cluster.
+	Kubernetes *VaultKubernetesAuth `yaml:"kubernetes,omitempty" json:"kubernetes,omitempty"`
+
+	// JWT authenticates with the JWT/OIDC auth method by a JWT, such as the OIDC token of the CI job.
+	JWT *VaultJWTAuth `yaml:"jwt,omitempty" json:"jwt,omitempty"`
+}
+
+// VaultTokenAuth authenticates with a static token.
+type VaultTokenAuth struct {
+	// TokenFile is the path of the file containing the token. If not set, the token is read from the
+	// environment variable VAULT_SERVER_TOKEN or VAULT_TOKEN.
+	TokenFile string `yaml:"tokenFile,omitempty" json:"tokenFile,omitempty"`
+}
+
+// VaultAppRoleAuth authenticates with the AppRole auth method.
+type VaultAppRoleAuth struct {
+	// MountPath is the mount path of the AppRole auth method, defaults to "approle".
+	MountPath string `yaml:"mountPath,omitempty" json:"mountPath,omitempty"`
+
+	// RoleID is the role id of the AppRole. If not set, the environment variable VAULT_ROLE_ID is used.
+	RoleID string `yaml:"roleId,omitempty" json:"roleId,omitempty"`
+
+	// SecretIDFile is the path of the file containing the secret id of the AppRole. If not set, the environment
+	// variable VAULT_SECRET_ID is used.
+	SecretIDFile string `yaml:"secretIdFile,omitempty" json:"secretIdFile,omitempty"`
+}
+
+// VaultKubernetesAuth authenticates with the Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	// MountPath is the mount path of the Kubernetes auth method, defaults to "kubernetes".
+	MountPath string `yaml:"mountPath,omitempty" json:"mountPath,omitempty"`
+
+	// Role is the Vault role bound to the service account, mandatory.
+	Role string `yaml:"role" json:"role"`
+
+	// ServiceAccountTokenFile is the path of the service account token, defaults to
+	// "/var/run/secrets/kubernetes.io/serviceaccount/token".
+	ServiceAccountTokenFile string `yaml:"serviceAccountTokenFile,omitempty" json:"serviceAccountTokenFile,omitempty"`
+}
+
+// VaultJWTAuth authenticates with the JWT/OIDC auth method.
+type VaultJWTAuth struct {
+	// MountPath is the mount path of the JWT auth method, defaults to "jwt".
+	MountPath string `yaml:"mountPath,omitempty" json:"mountPath,omitempty"`
+
+	// Role is the Vault role to login, and the default role of the auth method is used if not set.
+	Role string `yaml:"role,omitempty" json:"role,omitempty"`
+
+	// TokenFile is the path of the file containing the JWT. If not set, the environment variable VAULT_JWT
+	// is used.
+	TokenFile string `yaml:"tokenFile,omitempty" json:"tokenFile,omitempty"`
+}
+
+// VaultTLSConfig configures the TLS connection to Vault.
+type VaultTLSConfig struct {
+	// CACert is the path of the PEM-encoded CA certificate to verify the Vault server certificate.
+	CACert string `yaml:"caCert,omitempty" json:"caCert,omitempty"`
+
+	// ClientCert is the path of the PEM-encoded client certificate for the TLS authentication.
+	ClientCert string `yaml:"clientCert,omitempty" json:"clientCert,omitempty"`
+
+	// ClientKey is the path of the PEM-encoded private key of the client certificate.
+	ClientKey string `yaml:"clientKey,omitempty" json:"clientKey,omitempty"`
+
+	// ServerName is used to set the SNI host when connecting to Vault.
+	ServerName string `yaml:"serverName,omitempty" json:"serverName,omitempty"`
+
+	// Insecure skips the verification of the Vault server certificate, which should only be used for testing.
+	Insecure bool `yaml:"insecure,omitempty" json:"insecure,omitempty"`
 }
 
 // AzureEnvironmentType specifies the Azure cloud environment endpoints to use for connecting and authenticating with Azure.
@@ -295,6 +461,33 @@ type AzureKVProvider struct {
 	EnvironmentType AzureEnvironmentType `yaml:"environmentType,omitempty" json:"environmentType,omitempty"`
 }
 
+// FileProvider configures a store to retrieve secrets from a local YAML or JSON file encrypted by age or PGP,
+// which is usually committed in the repository along with the configurations.
+type FileProvider struct {
+	// Path is the path of the encrypted file, and the relative path is relative to the current working directory.
+	Path string `yaml:"path" json:"path"`
+
+	// AgeIdentityFile is the path of the age identity file to decrypt the file encrypted by age. If not set,
+	// the environment variable KUSION_SECRET_AGE_IDENTITY_FILE is used.
+	AgeIdentityFile string `yaml:"ageIdentityFile,omitempty" json:"ageIdentityFile,omitempty"`
+
+	// PGPPrivateKeyFile is the path of the armored PGP private key to decrypt the file encrypted by PGP. If not
+	// set, the environment variable KUSION_SECRET_PGP_PRIVATE_KEY_FILE is used, and the passphrase of the key is
+	// read from the environment variable KUSION_SECRET_PGP_PASSPHRASE.
+	PGPPrivateKeyFile string `yaml:"pgpPrivateKeyFile,omitempty" json:"pgpPrivateKeyFile,omitempty"`
+}
+
+// KubernetesProvider configures a store to retrieve secrets from the existing Secrets of a Kubernetes cluster.
+// The name of ExternalSecretRef is the Secret name, and the property is the key of the Secret data.
+type KubernetesProvider struct {
+	// Namespace is the namespace of the Secrets to retrieve, e.g. "shared-secrets".
+	Namespace string `yaml:"namespace" json:"namespace"`
+
+	// KubeConfig is the path of the kubeconfig file to access the cluster. If not set, the kubeconfig of the
+	// Kubernetes runtime of the workspace is used, and then the default kubeconfig.
+	KubeConfig string `yaml:"kubeConfig,omitempty" json:"kubeConfig,omitempty"`
+}
+
 // FakeProvider configures a fake provider that returns static values.
 type FakeProvider struct {
 	Data []FakeProviderData `json:"data"`
@@ -34,6 +34,9 @@ func NewCmdApply() *cobra.Command {
 	
 		# Skip interactive approval of preview details before applying
 		kusion apply --yes
+
+		# Apply with the generated data of the secret rotated
+		kusion apply --rotate-secret secret-token
 		
 		# Apply without output style and color
 		kusion apply --no-style=true`)
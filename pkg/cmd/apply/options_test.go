@@ -26,6 +26,8 @@ import (
 )
 
 func TestApplyOptions_Run(t *testing.T) {
+	defer os.Remove(local.KusionStateFileFile)
+
 	mockey.PatchConvey("Detail is true", t, func() {
 		mockPatchDetectProjectAndStack()
 		mockPatchBuildIntent()
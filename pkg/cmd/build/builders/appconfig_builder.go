@@ -4,6 +4,8 @@ import (
 	"kusionstack.io/kusion/pkg/apis/core/v1"
 	"kusionstack.io/kusion/pkg/modules"
 	"kusionstack.io/kusion/pkg/modules/generators"
+	"kusionstack.io/kusion/pkg/modules/generators/workload/secret"
+	"kusionstack.io/kusion/pkg/workspace"
 )
 
 type AppsConfigBuilder struct {
@@ -20,9 +22,14 @@ func (acg *AppsConfigBuilder) Build(
 		Resources: []v1.Resource{},
 	}
 
+	// The external secrets are retrieved once and shared by all the apps
+	var secretResolver *secret.ExternalSecretResolver
+	if acg.Workspace != nil {
+		secretResolver = secret.NewExternalSecretResolver(workspace.GetSecretStoreSpec(acg.Workspace))
+	}
 	var gfs []modules.NewGeneratorFunc
 	err := modules.ForeachOrdered(acg.Apps, func(appName string, app v1.AppConfiguration) error {
-		gfs = append(gfs, generators.NewAppConfigurationGeneratorFunc(project, stack, appName, &app, acg.Workspace))
+		gfs = append(gfs, generators.NewAppConfigurationGeneratorFunc(project, stack, appName, &app, acg.Workspace, secretResolver))
 		return nil
 	})
 	if err != nil {
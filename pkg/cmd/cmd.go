@@ -11,11 +11,15 @@ import (
 
 	"kusionstack.io/kusion/pkg/cmd/apply"
 	"kusionstack.io/kusion/pkg/cmd/build"
+	"kusionstack.io/kusion/pkg/cmd/config"
 	cmdinit "kusionstack.io/kusion/pkg/cmd/init"
 	"kusionstack.io/kusion/pkg/cmd/workspace"
 
 	"kusionstack.io/kusion/pkg/cmd/destroy"
 	"kusionstack.io/kusion/pkg/cmd/preview"
+	"kusionstack.io/kusion/pkg/cmd/promote"
+	"kusionstack.io/kusion/pkg/cmd/secret"
+	"kusionstack.io/kusion/pkg/cmd/state"
 	"kusionstack.io/kusion/pkg/cmd/version"
 	"kusionstack.io/kusion/pkg/util/i18n"
 )
@@ -91,6 +95,8 @@ func NewKusionctlCmd(o KusionctlOptions) *cobra.Command {
 			Message: "Configuration Commands:",
 			Commands: []*cobra.Command{
 				workspace.NewCmd(),
+				config.NewCmd(),
+				secret.NewCmd(),
 				cmdinit.NewCmdInit(),
 				build.NewCmdBuild(),
 			},
@@ -99,8 +105,10 @@ func NewKusionctlCmd(o KusionctlOptions) *cobra.Command {
 			Message: "Runtime Commands:",
 			Commands: []*cobra.Command{
 				preview.NewCmdPreview(),
+				promote.NewCmdPromote(),
 				apply.NewCmdApply(),
 				destroy.NewCmdDestroy(),
+				state.NewCmd(),
 			},
 		},
 	}
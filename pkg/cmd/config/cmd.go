@@ -0,0 +1,41 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/config/get"
+	"kusionstack.io/kusion/pkg/cmd/config/list"
+	"kusionstack.io/kusion/pkg/cmd/config/set"
+	"kusionstack.io/kusion/pkg/cmd/config/unset"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Interact with the kusion config`)
+
+		long = i18n.T(`
+		Config contains the operation of kusion configurations, which are stored in the file "config.yaml" under the kusion data folder.
+		
+		The config item is specified by dotted key, such as "backends.current" and "backends.prod.configs.bucket".`)
+	)
+
+	cmd := &cobra.Command{
+		Use:           "config",
+		Short:         short,
+		Long:          templates.LongDesc(long),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	getCmd := get.NewCmd()
+	setCmd := set.NewCmd()
+	unsetCmd := unset.NewCmd()
+	listCmd := list.NewCmd()
+	cmd.AddCommand(getCmd, setCmd, unsetCmd, listCmd)
+
+	return cmd
+}
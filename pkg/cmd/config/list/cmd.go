@@ -0,0 +1,59 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/config"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+var ErrNotEmptyArgs = errors.New("no args accepted")
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`List all config items`)
+
+		long = i18n.T(`
+		This command lists all the kusion config items and their values.`)
+
+		example = i18n.T(`
+		# List all config items
+		kusion config list`)
+	)
+
+	cmd := &cobra.Command{
+		Use:                   "list",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(Validate(args))
+			util.CheckErr(Run())
+			return
+		},
+	}
+	return cmd
+}
+
+func Validate(args []string) error {
+	if len(args) != 0 {
+		return ErrNotEmptyArgs
+	}
+	return nil
+}
+
+func Run() error {
+	content, err := config.GetEncodedConfig()
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
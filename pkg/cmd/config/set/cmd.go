@@ -0,0 +1,50 @@
+package set
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Set a config item`)
+
+		long = i18n.T(`
+		This command sets the value of a specified kusion config item, where the config item must be registered, and the value must be valid.
+		
+		The value of structured config item, such as "backends.prod", should be in json or yaml format.`)
+
+		example = i18n.T(`
+		# Set the type of a specified backend
+		kusion config set backends.prod.type s3
+
+		# Set a config item of a specified backend
+		kusion config set backends.prod.configs.bucket kusion
+
+		# Set the config of a specified backend
+		kusion config set backends.prod '{"type":"s3","configs":{"bucket":"kusion"}}'
+
+		# Set the current backend
+		kusion config set backends.current prod`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "set",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,102 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	"kusionstack.io/kusion/pkg/config"
+)
+
+func TestOptions_Complete(t *testing.T) {
+	testcases := []struct {
+		name         string
+		args         []string
+		success      bool
+		expectedOpts *Options
+	}{
+		{
+			name:         "successfully complete options",
+			args:         []string{"backends.current", "dev"},
+			success:      true,
+			expectedOpts: &Options{Item: "backends.current", Value: "dev"},
+		},
+		{
+			name:         "complete field invalid args",
+			args:         []string{"backends.current"},
+			success:      false,
+			expectedOpts: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := NewOptions()
+			err := opts.Complete(tc.args)
+			assert.Equal(t, tc.success, err == nil)
+			if tc.success {
+				assert.True(t, reflect.DeepEqual(opts, tc.expectedOpts))
+			}
+		})
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name:    "valid options",
+			opts:    &Options{Item: "backends.current", Value: "dev"},
+			success: true,
+		},
+		{
+			name:    "invalid options empty item",
+			opts:    &Options{},
+			success: false,
+		},
+		{
+			name:    "invalid options empty value",
+			opts:    &Options{Item: "backends.current"},
+			success: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			assert.Equal(t, tc.success, err == nil)
+		})
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name:    "successfully run",
+			opts:    &Options{Item: "backends.current", Value: "dev"},
+			success: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock set config item", t, func() {
+				mockey.Mock(config.SetEncodedConfigItem).
+					Return(nil).
+					Build()
+
+				err := tc.opts.Run()
+				assert.Equal(t, tc.success, err == nil)
+			})
+		})
+	}
+}
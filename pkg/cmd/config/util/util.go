@@ -0,0 +1,44 @@
+package util
+
+import (
+	"errors"
+)
+
+var (
+	ErrNotOneArgs   = errors.New("only one arg accepted")
+	ErrNotTwoArgs   = errors.New("only two args accepted")
+	ErrEmptyItem    = errors.New("empty config item")
+	ErrEmptyItemVal = errors.New("empty config item value")
+)
+
+// GetItemFromArgs returns the config item specified by args.
+func GetItemFromArgs(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", ErrNotOneArgs
+	}
+	return args[0], nil
+}
+
+// GetItemValueFromArgs returns the config item and its value specified by args.
+func GetItemValueFromArgs(args []string) (string, string, error) {
+	if len(args) != 2 {
+		return "", "", ErrNotTwoArgs
+	}
+	return args[0], args[1], nil
+}
+
+// ValidateItem returns the config item is valid or not.
+func ValidateItem(item string) error {
+	if item == "" {
+		return ErrEmptyItem
+	}
+	return nil
+}
+
+// ValidateItemValue returns the config item value is valid or not.
+func ValidateItemValue(value string) error {
+	if value == "" {
+		return ErrEmptyItemVal
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetItemFromArgs(t *testing.T) {
+	item, err := GetItemFromArgs([]string{"backends.current"})
+	assert.NoError(t, err)
+	assert.Equal(t, "backends.current", item)
+
+	_, err = GetItemFromArgs([]string{"backends.current", "dev"})
+	assert.ErrorIs(t, err, ErrNotOneArgs)
+}
+
+func TestGetItemValueFromArgs(t *testing.T) {
+	item, value, err := GetItemValueFromArgs([]string{"backends.current", "dev"})
+	assert.NoError(t, err)
+	assert.Equal(t, "backends.current", item)
+	assert.Equal(t, "dev", value)
+
+	_, _, err = GetItemValueFromArgs([]string{"backends.current"})
+	assert.ErrorIs(t, err, ErrNotTwoArgs)
+}
+
+func TestValidateItem(t *testing.T) {
+	assert.NoError(t, ValidateItem("backends.current"))
+	assert.ErrorIs(t, ValidateItem(""), ErrEmptyItem)
+	assert.NoError(t, ValidateItemValue("dev"))
+	assert.ErrorIs(t, ValidateItemValue(""), ErrEmptyItemVal)
+}
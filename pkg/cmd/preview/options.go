@@ -20,6 +20,7 @@ import (
 	"kusionstack.io/kusion/pkg/engine/runtime/terraform"
 	"kusionstack.io/kusion/pkg/engine/states"
 	"kusionstack.io/kusion/pkg/log"
+	"kusionstack.io/kusion/pkg/modules/generators/workload/secret"
 	"kusionstack.io/kusion/pkg/project"
 	"kusionstack.io/kusion/pkg/util/pretty"
 )
@@ -40,6 +41,8 @@ type Flags struct {
 	Output       string
 	IntentFile   string
 	IgnoreFields []string
+	// RotateSecrets are the names of the Secrets whose generated data are regenerated rather than reused
+	RotateSecrets []string
 }
 
 func NewPreviewOptions() *Options {
@@ -234,12 +237,33 @@ func Preview(
 ) (*opsmodels.Changes, error) {
 	log.Info("Start compute preview changes ...")
 
+	// parse cluster in arguments
+	cluster := o.Arguments["cluster"]
+
+	// Reuse the generated data of the latest State, such as the random tokens of Secrets, which are
+	// regenerated only if rotation is requested.
+	latestState, err := storage.GetLatestState(&states.StateQuery{
+		Stack:   stack.Name,
+		Project: project.Name,
+		Cluster: cluster,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get the latest state failed: %w", err)
+	}
+	var priorResources apiv1.Resources
+	if latestState != nil {
+		priorResources = latestState.Resources
+	}
+	if err = secret.ReuseGeneratedData(planResources, priorResources, o.RotateSecrets); err != nil {
+		return nil, err
+	}
+
 	// Check and install terraform executable binary for
 	// resources with the type of Terraform.
 	tfInstaller := terraform.CLIInstaller{
 		Intent: planResources,
 	}
-	if err := tfInstaller.CheckAndInstall(); err != nil {
+	if err = tfInstaller.CheckAndInstall(); err != nil {
 		return nil, err
 	}
 
@@ -256,8 +280,6 @@ func Preview(
 
 	log.Info("Start call pc.Preview() ...")
 
-	// parse cluster in arguments
-	cluster := o.Arguments["cluster"]
 	rsp, s := pc.Preview(&operation.PreviewRequest{
 		Request: opsmodels.Request{
 			Tenant:   "",
@@ -42,6 +42,7 @@ var (
 
 func Test_preview(t *testing.T) {
 	stateStorage := &local.FileSystemState{Path: filepath.Join("", local.KusionStateFileFile)}
+	defer os.Remove(local.KusionStateFileFile)
 	t.Run("preview success", func(t *testing.T) {
 		m := mockOperationPreview()
 		defer m.UnPatch()
@@ -50,11 +51,21 @@ func Test_preview(t *testing.T) {
 		_, err := Preview(o, stateStorage, &apiv1.Intent{Resources: []apiv1.Resource{sa1, sa2, sa3}}, p, s)
 		assert.Nil(t, err)
 	})
+	t.Run("rotate secret not found", func(t *testing.T) {
+		m := mockOperationPreview()
+		defer m.UnPatch()
+
+		o := NewPreviewOptions()
+		o.RotateSecrets = []string{"secret-token"}
+		_, err := Preview(o, stateStorage, &apiv1.Intent{Resources: []apiv1.Resource{sa1, sa2, sa3}}, p, s)
+		assert.EqualError(t, err, "secret secret-token to rotate is not found or has no generated data")
+	})
 }
 
 func TestPreviewOptions_Run(t *testing.T) {
 	defer func() {
 		os.Remove("kusion_state.json")
+		os.Remove(local.KusionStateFileFile)
 	}()
 
 	t.Run("no project or stack", func(t *testing.T) {
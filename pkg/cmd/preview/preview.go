@@ -75,4 +75,6 @@ func (o *Options) AddPreviewFlags(cmd *cobra.Command) {
 		i18n.T("Specify the output format"))
 	cmd.Flags().StringVarP(&o.IntentFile, "intent-file", "", "",
 		i18n.T("Specify the intent file path as input, and the intent file must be located in the working directory or its subdirectories"))
+	cmd.Flags().StringSliceVarP(&o.RotateSecrets, "rotate-secret", "", nil,
+		i18n.T("Specify the names of the secrets whose generated data are rotated rather than reused"))
 }
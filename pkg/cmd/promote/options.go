@@ -0,0 +1,233 @@
+package promote
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/pterm/pterm"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/build"
+	"kusionstack.io/kusion/pkg/cmd/build/builders"
+	opsmodels "kusionstack.io/kusion/pkg/engine/operation/models"
+	"kusionstack.io/kusion/pkg/modules"
+	"kusionstack.io/kusion/pkg/project"
+)
+
+var (
+	ErrEmptyFrom  = errors.New("the source workspace must be specified by --from")
+	ErrEmptyTo    = errors.New("the target workspace must be specified by --to")
+	ErrSameFromTo = errors.New("the source and target workspace must be different")
+)
+
+type Options struct {
+	From      string
+	To        string
+	WorkDir   string
+	Settings  []string
+	Arguments map[string]string
+	All       bool
+	NoStyle   bool
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Settings:  make([]string, 0),
+		Arguments: map[string]string{},
+	}
+}
+
+func (o *Options) Validate() error {
+	if o.From == "" {
+		return ErrEmptyFrom
+	}
+	if o.To == "" {
+		return ErrEmptyTo
+	}
+	if o.From == o.To {
+		return ErrSameFromTo
+	}
+	return nil
+}
+
+func (o *Options) Run() error {
+	if o.NoStyle {
+		pterm.DisableStyling()
+		pterm.DisableColor()
+	}
+
+	// The stack is deployed to the workspace with the same name, so the Intents of the source and target
+	// workspace are built from the stacks named by them in the project.
+	p, err := project.DetectProject(o.WorkDir)
+	if err != nil {
+		return err
+	}
+	fromStack, err := getStack(p, o.From)
+	if err != nil {
+		return err
+	}
+	toStack, err := getStack(p, o.To)
+	if err != nil {
+		return err
+	}
+
+	fromIntent, err := o.buildIntent(p, fromStack)
+	if err != nil {
+		return err
+	}
+	toIntent, err := o.buildIntent(p, toStack)
+	if err != nil {
+		return err
+	}
+
+	fromIntent = renameStack(fromIntent, p.Name, fromStack.Name, toStack.Name)
+	changes := opsmodels.NewChanges(p, toStack, compareIntents(fromIntent, toIntent, o.All))
+	if changes.AllUnChange() {
+		fmt.Printf("No resource differences between workspace %s and %s\n", o.From, o.To)
+		return nil
+	}
+	changes.Summary(os.Stdout)
+	fmt.Println(changes.Diffs())
+	return nil
+}
+
+// buildIntent builds the Intent of the stack as kusion build does in the stack directory.
+func (o *Options) buildIntent(p *v1.Project, s *v1.Stack) (*v1.Intent, error) {
+	bo := build.NewBuildOptions()
+	bo.WorkDir = s.Path
+	bo.Settings = append(bo.Settings, o.Settings...)
+	for k, v := range o.Arguments {
+		bo.Arguments[k] = v
+	}
+	bo.NoStyle = o.NoStyle
+	if err := bo.PreSet(project.IsStack); err != nil {
+		return nil, err
+	}
+
+	return build.IntentWithSpinner(
+		&builders.Options{
+			IsKclPkg:  bo.IsKclPkg,
+			WorkDir:   bo.WorkDir,
+			Filenames: bo.Filenames,
+			Settings:  bo.Settings,
+			Arguments: bo.Arguments,
+			NoStyle:   bo.NoStyle,
+		},
+		p,
+		s,
+	)
+}
+
+// getStack returns the stack of the project by name.
+func getStack(p *v1.Project, name string) (*v1.Stack, error) {
+	for _, s := range p.Stacks {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("stack %s not found in project %s", name, p.Name)
+}
+
+// renameStack returns a copy of the Intent whose names of the generated resources are renamed from the source stack
+// to the target stack, for the names of the resources are prefixed by the unique app name containing the stack, such
+// as proj-staging-app of the Deployment. The IDs and the dependencies are renamed as well, so that the same resources
+// of the two stacks are matched by ID.
+func renameStack(intent *v1.Intent, projectName, from, to string) *v1.Intent {
+	fromPrefix := modules.UniqueAppName(projectName, from, "")
+	toPrefix := modules.UniqueAppName(projectName, to, "")
+	renameID := func(id string) string {
+		return strings.ReplaceAll(id, ":"+fromPrefix, ":"+toPrefix)
+	}
+
+	renamed := &v1.Intent{Resources: make(v1.Resources, 0, len(intent.Resources))}
+	for _, r := range intent.Resources {
+		r.ID = renameID(r.ID)
+		if r.DependsOn != nil {
+			dependsOn := make([]string, 0, len(r.DependsOn))
+			for _, id := range r.DependsOn {
+				dependsOn = append(dependsOn, renameID(id))
+			}
+			r.DependsOn = dependsOn
+		}
+		r.Attributes, _ = renameValue(r.Attributes, fromPrefix, toPrefix).(map[string]interface{})
+		renamed.Resources = append(renamed.Resources, r)
+	}
+	return renamed
+}
+
+// renameValue returns a copy of the value whose strings with the prefix are renamed to the new prefix.
+func renameValue(value interface{}, from, to string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, from) {
+			return to + strings.TrimPrefix(v, from)
+		}
+		return v
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		renamed := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			renamed[key] = renameValue(val, from, to)
+		}
+		return renamed
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		renamed := make([]interface{}, 0, len(v))
+		for _, val := range v {
+			renamed = append(renamed, renameValue(val, from, to))
+		}
+		return renamed
+	default:
+		return v
+	}
+}
+
+// compareIntents returns the resource-level changes from the source Intent to the target Intent. The resources
+// of the target Intent come first in order, followed by the ones only in the source Intent. The unchanged
+// resources are included only if all is true.
+func compareIntents(from, to *v1.Intent, all bool) *opsmodels.ChangeOrder {
+	order := &opsmodels.ChangeOrder{
+		StepKeys:    []string{},
+		ChangeSteps: map[string]*opsmodels.ChangeStep{},
+	}
+	addStep := func(step *opsmodels.ChangeStep) {
+		if step.Action == opsmodels.UnChanged && !all {
+			return
+		}
+		order.StepKeys = append(order.StepKeys, step.ID)
+		order.ChangeSteps[step.ID] = step
+	}
+
+	fromResources := make(map[string]*v1.Resource, len(from.Resources))
+	for i := range from.Resources {
+		fromResources[from.Resources[i].ID] = &from.Resources[i]
+	}
+	toIDs := make(map[string]struct{}, len(to.Resources))
+	for i := range to.Resources {
+		r := &to.Resources[i]
+		toIDs[r.ID] = struct{}{}
+		old, ok := fromResources[r.ID]
+		switch {
+		case !ok:
+			addStep(opsmodels.NewChangeStep(r.ID, opsmodels.Create, nil, r))
+		case reflect.DeepEqual(old, r):
+			addStep(opsmodels.NewChangeStep(r.ID, opsmodels.UnChanged, old, r))
+		default:
+			addStep(opsmodels.NewChangeStep(r.ID, opsmodels.Update, old, r))
+		}
+	}
+	for i := range from.Resources {
+		r := &from.Resources[i]
+		if _, ok := toIDs[r.ID]; !ok {
+			addStep(opsmodels.NewChangeStep(r.ID, opsmodels.Delete, r, nil))
+		}
+	}
+	return order
+}
@@ -0,0 +1,174 @@
+package promote
+
+import (
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/network"
+	"kusionstack.io/kusion/pkg/cmd/build"
+	"kusionstack.io/kusion/pkg/cmd/build/builders"
+	opsmodels "kusionstack.io/kusion/pkg/engine/operation/models"
+	"kusionstack.io/kusion/pkg/modules/generators"
+	"kusionstack.io/kusion/pkg/project"
+)
+
+func mockIntent(replicas int) *v1.Intent {
+	return &v1.Intent{
+		Resources: v1.Resources{
+			{
+				ID:         "apps/v1:Deployment:foo:bar",
+				Type:       v1.Kubernetes,
+				Attributes: map[string]interface{}{"replicas": replicas},
+			},
+			{
+				ID:         "v1:Namespace:foo",
+				Type:       v1.Kubernetes,
+				Attributes: map[string]interface{}{"name": "foo"},
+			},
+		},
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name:    "valid options",
+			opts:    &Options{From: "staging", To: "prod"},
+			success: true,
+		},
+		{
+			name:    "invalid options empty from",
+			opts:    &Options{To: "prod"},
+			success: false,
+		},
+		{
+			name:    "invalid options empty to",
+			opts:    &Options{From: "staging"},
+			success: false,
+		},
+		{
+			name:    "invalid options same from and to",
+			opts:    &Options{From: "prod", To: "prod"},
+			success: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			assert.Equal(t, tc.success, err == nil)
+		})
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	p := &v1.Project{
+		Name: "foo",
+		Stacks: []*v1.Stack{
+			{Name: "staging", Path: "/foo/staging"},
+			{Name: "prod", Path: "/foo/prod"},
+		},
+	}
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name:    "successfully run",
+			opts:    &Options{From: "staging", To: "prod", NoStyle: true},
+			success: true,
+		},
+		{
+			name:    "failed to run not exist stack",
+			opts:    &Options{From: "staging", To: "test", NoStyle: true},
+			success: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock build intent", t, func() {
+				mockey.Mock(project.DetectProject).Return(p, nil).Build()
+				mockey.Mock(build.IntentWithSpinner).To(
+					func(_ *builders.Options, _ *v1.Project, s *v1.Stack) (*v1.Intent, error) {
+						if s.Name == "prod" {
+							return mockIntent(3), nil
+						}
+						return mockIntent(1), nil
+					}).Build()
+
+				err := tc.opts.Run()
+				assert.Equal(t, tc.success, err == nil)
+			})
+		})
+	}
+}
+
+func TestCompareIntents(t *testing.T) {
+	from := mockIntent(1)
+	from.Resources = append(from.Resources, v1.Resource{ID: "v1:ConfigMap:foo:bar", Type: v1.Kubernetes})
+	to := mockIntent(3)
+	to.Resources = append(to.Resources, v1.Resource{ID: "v1:Secret:foo:bar", Type: v1.Kubernetes})
+
+	order := compareIntents(from, to, false)
+	assert.Equal(t, []string{"apps/v1:Deployment:foo:bar", "v1:Secret:foo:bar", "v1:ConfigMap:foo:bar"}, order.StepKeys)
+	assert.Equal(t, opsmodels.Update, order.ChangeSteps["apps/v1:Deployment:foo:bar"].Action)
+	assert.Equal(t, opsmodels.Create, order.ChangeSteps["v1:Secret:foo:bar"].Action)
+	assert.Equal(t, opsmodels.Delete, order.ChangeSteps["v1:ConfigMap:foo:bar"].Action)
+
+	order = compareIntents(from, to, true)
+	assert.Equal(t, opsmodels.UnChanged, order.ChangeSteps["v1:Namespace:foo"].Action)
+	assert.Len(t, order.StepKeys, 4)
+}
+
+// generateIntent generates the Intent of the app in the stack by the generators as kusion build does.
+func generateIntent(t *testing.T, p *v1.Project, stackName string, replicas int32) *v1.Intent {
+	app := &v1.AppConfiguration{
+		Workload: &workload.Workload{
+			Header: workload.Header{Type: workload.TypeService},
+			Service: &workload.Service{
+				Base: workload.Base{
+					Containers: map[string]container.Container{"nginx": {Image: "nginx:v1"}},
+					Replicas:   &replicas,
+				},
+				Type:  workload.Deployment,
+				Ports: []network.Port{{Port: 80, Protocol: "TCP"}},
+			},
+		},
+	}
+	ws := &v1.Workspace{Name: stackName}
+	g, err := generators.NewAppConfigurationGenerator(p, &v1.Stack{Name: stackName}, "app", app, ws, nil)
+	require.NoError(t, err)
+	intent := &v1.Intent{}
+	require.NoError(t, g.Generate(intent))
+	return intent
+}
+
+func TestCompareIntents_GeneratedIntents(t *testing.T) {
+	p := &v1.Project{Name: "proj"}
+	from := renameStack(generateIntent(t, p, "staging", 1), p.Name, "staging", "prod")
+	to := generateIntent(t, p, "prod", 3)
+
+	order := compareIntents(from, to, false)
+	assert.Equal(t, []string{"apps/v1:Deployment:proj:proj-prod-app"}, order.StepKeys)
+	step := order.ChangeSteps["apps/v1:Deployment:proj:proj-prod-app"]
+	assert.Equal(t, opsmodels.Update, step.Action)
+	assert.Equal(t, "proj-prod-app", step.From.(*v1.Resource).Attributes["metadata"].(map[string]interface{})["name"])
+
+	order = compareIntents(from, to, true)
+	for _, step := range order.ChangeSteps {
+		assert.NotEqual(t, opsmodels.Create, step.Action, step.ID)
+		assert.NotEqual(t, opsmodels.Delete, step.Action, step.ID)
+	}
+}
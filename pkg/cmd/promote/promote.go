@@ -0,0 +1,63 @@
+package promote
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmdPromote() *cobra.Command {
+	var (
+		promoteShort = i18n.T(`Preview the resource differences of promoting the project from one workspace to another`)
+
+		promoteLong = i18n.T(`
+		Preview the resource differences of promoting the project from one workspace to another.
+
+		The command must be executed in a Project or by specifying a Project directory with the -w flag. The Intents
+		are built from the Stacks named by the source and target workspace, and the resource-level differences from
+		the source Intent to the target Intent are shown. No resource is changed by this command.`)
+
+		promoteExample = i18n.T(`
+		# Show the resource differences of promoting from staging to prod
+		kusion promote --from staging --to prod
+
+		# Show the differences with specified work directory, including the unchanged resources
+		kusion promote --from staging --to prod -w /path/to/project --all
+
+		# Show the differences without output style and color
+		kusion promote --from staging --to prod --no-style=true`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:     "promote",
+		Short:   promoteShort,
+		Long:    templates.LongDesc(promoteLong),
+		Example: templates.Examples(promoteExample),
+		RunE: func(_ *cobra.Command, _ []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.From, "from", "", "",
+		i18n.T("Specify the source workspace"))
+	cmd.Flags().StringVarP(&o.To, "to", "", "",
+		i18n.T("Specify the target workspace"))
+	cmd.Flags().StringVarP(&o.WorkDir, "workdir", "w", "",
+		i18n.T("Specify the work directory"))
+	cmd.Flags().StringSliceVarP(&o.Settings, "setting", "Y", []string{},
+		i18n.T("Specify the command line setting files"))
+	cmd.Flags().StringToStringVarP(&o.Arguments, "argument", "D", map[string]string{},
+		i18n.T("Specify the top-level argument"))
+	cmd.Flags().BoolVarP(&o.All, "all", "a", false,
+		i18n.T("Show the unchanged resources as well"))
+	cmd.Flags().BoolVarP(&o.NoStyle, "no-style", "", false,
+		i18n.T("Disable the output style and color"))
+
+	return cmd
+}
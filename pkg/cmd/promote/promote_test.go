@@ -0,0 +1,15 @@
+package promote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmdPromote(t *testing.T) {
+	t.Run("validate error", func(t *testing.T) {
+		cmd := NewCmdPromote()
+		err := cmd.Execute()
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,39 @@
+package secret
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/secret/decrypt"
+	"kusionstack.io/kusion/pkg/cmd/secret/edit"
+	"kusionstack.io/kusion/pkg/cmd/secret/encrypt"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Secret manages the local secret files encrypted by age or PGP`)
+
+		long = i18n.T(`
+		Secret manages the local secret files encrypted by age or PGP, which are used by the file secret store provider of the workspace.
+
+		The secret file is a YAML or JSON object keyed by the secret names, whose values are strings or objects of properties. The secret of a specific version is keyed by "name@version".`)
+	)
+
+	cmd := &cobra.Command{
+		Use:           "secret",
+		Short:         short,
+		Long:          templates.LongDesc(long),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	encryptCmd := encrypt.NewCmd()
+	decryptCmd := decrypt.NewCmd()
+	editCmd := edit.NewCmd()
+	cmd.AddCommand(encryptCmd, decryptCmd, editCmd)
+
+	return cmd
+}
@@ -0,0 +1,14 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmd(t *testing.T) {
+	t.Run("successfully get secret help", func(t *testing.T) {
+		cmd := NewCmd()
+		assert.NotNil(t, cmd)
+	})
+}
@@ -0,0 +1,48 @@
+package decrypt
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Decrypt a secret file encrypted by age or PGP`)
+
+		long = i18n.T(`
+		This command decrypts a secret file encrypted by age or PGP, and prints the plaintext or writes it to the output file.
+
+		The passphrase of the PGP private key is read from the environment variable KUSION_SECRET_PGP_PASSPHRASE.`)
+
+		example = i18n.T(`
+		# Decrypt a secret file with an age identity
+		kusion secret decrypt secrets.enc.yaml --age-identity-file key.txt
+
+		# Decrypt a secret file with a PGP private key to a file
+		kusion secret decrypt secrets.enc.yaml --pgp-private-key-file key.asc -o secrets.yaml`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "decrypt",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.DecryptionFlags.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "",
+		i18n.T("Specify the output file of the decrypted content, print to stdout if not specified"))
+	return cmd
+}
@@ -0,0 +1,20 @@
+package decrypt
+
+import (
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmd(t *testing.T) {
+	t.Run("successfully decrypt secret file", func(t *testing.T) {
+		mockey.PatchConvey("mock cmd", t, func() {
+			mockey.Mock((*Options).Run).Return(nil).Build()
+			cmd := NewCmd()
+			cmd.SetArgs([]string{"secrets.enc.yaml"})
+			err := cmd.Execute()
+			assert.Nil(t, err)
+		})
+	})
+}
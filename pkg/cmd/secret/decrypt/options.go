@@ -0,0 +1,44 @@
+package decrypt
+
+import (
+	"fmt"
+	"os"
+
+	"kusionstack.io/kusion/pkg/cmd/secret/util"
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+)
+
+type Options struct {
+	util.DecryptionFlags
+	File   string
+	Output string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	f, err := util.GetFileFromArgs(args)
+	if err != nil {
+		return err
+	}
+	o.File = f
+	return nil
+}
+
+func (o *Options) Validate() error {
+	return nil
+}
+
+func (o *Options) Run() error {
+	ciphertext, err := os.ReadFile(o.File)
+	if err != nil {
+		return fmt.Errorf("read file %s failed: %w", o.File, err)
+	}
+	plaintext, _, err := file.Decrypt(ciphertext, o.Keys())
+	if err != nil {
+		return err
+	}
+	return util.WriteOutput(o.Output, plaintext)
+}
@@ -0,0 +1,53 @@
+package decrypt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kusionstack.io/kusion/pkg/cmd/secret/util"
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+)
+
+func TestOptions_Complete(t *testing.T) {
+	opts := NewOptions()
+	assert.NoError(t, opts.Complete([]string{"secrets.enc.yaml"}))
+	assert.Equal(t, "secrets.enc.yaml", opts.File)
+	assert.ErrorIs(t, opts.Complete(nil), util.ErrNotOneArgs)
+}
+
+func TestOptions_Run(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	identityFile := filepath.Join(dir, "age.key")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()), 0o600))
+	ciphertext, err := file.Encrypt([]byte("token: s3cr3t\n"), file.EncryptionKeys{
+		AgeRecipients: []string{identity.Recipient().String()},
+	})
+	require.NoError(t, err)
+	input := filepath.Join(dir, "secrets.enc.yaml")
+	require.NoError(t, os.WriteFile(input, ciphertext, 0o600))
+
+	t.Run("decrypt secret file", func(t *testing.T) {
+		opts := &Options{
+			DecryptionFlags: util.DecryptionFlags{AgeIdentityFile: identityFile},
+			File:            input,
+			Output:          filepath.Join(dir, "secrets.yaml"),
+		}
+		require.NoError(t, opts.Run())
+		plaintext, err := os.ReadFile(opts.Output)
+		require.NoError(t, err)
+		assert.Equal(t, "token: s3cr3t\n", string(plaintext))
+	})
+
+	t.Run("missing identity", func(t *testing.T) {
+		t.Setenv(file.EnvAgeIdentityFile, "")
+		opts := &Options{File: input}
+		assert.ErrorIs(t, opts.Run(), file.ErrMissingAgeIdentity)
+	})
+}
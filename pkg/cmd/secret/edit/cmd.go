@@ -0,0 +1,47 @@
+package edit
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Edit a secret file encrypted by age or PGP`)
+
+		long = i18n.T(`
+		This command decrypts a secret file into a temporary file, opens it with the editor, and encrypts the edited content back to the secret file.
+
+		The editor is specified by the environment variable KUSION_EDITOR or EDITOR, defaults to vi. The recipients are not stored in the secret file, so all of them must be specified to re-encrypt the edited file to, otherwise the others can not decrypt it anymore.`)
+
+		example = i18n.T(`
+		# Edit a secret file encrypted by age to two recipients
+		kusion secret edit secrets.enc.yaml --age-identity-file key.txt --age-recipient age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p,age1lggyhqrw2nlhcxprm67z43rta597azn8gknawjehu9d9dl0jq3yqqvfafg
+
+		# Edit a secret file encrypted by PGP
+		kusion secret edit secrets.enc.yaml --pgp-private-key-file private.asc --pgp-public-key-file public.asc`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "edit",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.DecryptionFlags.AddFlags(cmd)
+	o.EncryptionFlags.AddFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,20 @@
+package edit
+
+import (
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmd(t *testing.T) {
+	t.Run("successfully edit secret file", func(t *testing.T) {
+		mockey.PatchConvey("mock cmd", t, func() {
+			mockey.Mock((*Options).Run).Return(nil).Build()
+			cmd := NewCmd()
+			cmd.SetArgs([]string{"secrets.enc.yaml", "--age-recipient", "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"})
+			err := cmd.Execute()
+			assert.Nil(t, err)
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package edit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"kusionstack.io/kusion/pkg/cmd/secret/util"
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+)
+
+const (
+	// EnvEditor is the environment variable of the editor command, which takes precedence over EDITOR.
+	EnvEditor     = "KUSION_EDITOR"
+	defaultEditor = "vi"
+)
+
+var ErrEmptyEditor = errors.New("empty editor command")
+
+type Options struct {
+	util.DecryptionFlags
+	util.EncryptionFlags
+	File string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	f, err := util.GetFileFromArgs(args)
+	if err != nil {
+		return err
+	}
+	o.File = f
+	return nil
+}
+
+// Validate requires the recipients to re-encrypt the edited file to, for the recipients of the secret file are not
+// stored in it, and re-encrypting to the decryption keys only would drop the other recipients silently.
+func (o *Options) Validate() error {
+	if o.IsEmpty() {
+		return file.ErrMissingRecipients
+	}
+	if len(o.AgeRecipients) != 0 && o.PGPPublicKeyFile != "" {
+		return file.ErrMultipleRecipientTypes
+	}
+	return nil
+}
+
+func (o *Options) Run() error {
+	fileInfo, err := os.Stat(o.File)
+	if err != nil {
+		return fmt.Errorf("stat file %s failed: %w", o.File, err)
+	}
+	ciphertext, err := os.ReadFile(o.File)
+	if err != nil {
+		return fmt.Errorf("read file %s failed: %w", o.File, err)
+	}
+	plaintext, _, err := file.Decrypt(ciphertext, o.DecryptionFlags.Keys())
+	if err != nil {
+		return err
+	}
+
+	edited, err := editPlaintext(o.File, plaintext)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(edited, plaintext) {
+		fmt.Printf("secret file %s is not changed\n", o.File)
+		return nil
+	}
+	if _, err = file.ParseData(edited); err != nil {
+		return err
+	}
+
+	if ciphertext, err = file.Encrypt(edited, o.EncryptionFlags.Keys()); err != nil {
+		return err
+	}
+	if err = os.WriteFile(o.File, ciphertext, fileInfo.Mode().Perm()); err != nil {
+		return fmt.Errorf("write file %s failed: %w", o.File, err)
+	}
+	fmt.Printf("edit secret file %s successfully\n", o.File)
+	return nil
+}
+
+// editPlaintext writes the plaintext into a temporary file readable only by the current user, opens it with the
+// editor, and returns the edited content. The temporary file is removed after editing.
+func editPlaintext(name string, plaintext []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "kusion-secret-")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary directory failed: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// keep the extension for the syntax highlighting of the editor, such as secrets.enc.yaml to secrets.yaml
+	base := filepath.Base(name)
+	ext := filepath.Ext(base)
+	tempFile := filepath.Join(dir, strings.TrimSuffix(strings.TrimSuffix(base, ext), ".enc")+ext)
+	if err = os.WriteFile(tempFile, plaintext, 0o600); err != nil {
+		return nil, fmt.Errorf("write temporary file failed: %w", err)
+	}
+	if err = runEditor(tempFile); err != nil {
+		return nil, err
+	}
+	edited, err := os.ReadFile(tempFile)
+	if err != nil {
+		return nil, fmt.Errorf("read temporary file failed: %w", err)
+	}
+	return edited, nil
+}
+
+// runEditor opens the file with the editor of KUSION_EDITOR, EDITOR or vi, and waits for the editor to exit.
+func runEditor(path string) error {
+	editor := os.Getenv(EnvEditor)
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		return ErrEmptyEditor
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run editor %s failed: %w", editor, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package edit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kusionstack.io/kusion/pkg/cmd/secret/util"
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+)
+
+func TestOptions_Complete(t *testing.T) {
+	opts := NewOptions()
+	assert.NoError(t, opts.Complete([]string{"secrets.enc.yaml"}))
+	assert.Equal(t, "secrets.enc.yaml", opts.File)
+	assert.ErrorIs(t, opts.Complete(nil), util.ErrNotOneArgs)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	assert.ErrorIs(t, NewOptions().Validate(), file.ErrMissingRecipients)
+	opts := &Options{EncryptionFlags: util.EncryptionFlags{AgeRecipients: []string{"age1"}}}
+	assert.NoError(t, opts.Validate())
+	opts = &Options{EncryptionFlags: util.EncryptionFlags{
+		AgeRecipients:    []string{"age1"},
+		PGPPublicKeyFile: "public.asc",
+	}}
+	assert.ErrorIs(t, opts.Validate(), file.ErrMultipleRecipientTypes)
+}
+
+func TestOptions_Run(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	identityFile := filepath.Join(dir, "age.key")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()), 0o600))
+	ciphertext, err := file.Encrypt([]byte("token: s3cr3t\n"), file.EncryptionKeys{
+		AgeRecipients: []string{identity.Recipient().String()},
+	})
+	require.NoError(t, err)
+	input := filepath.Join(dir, "secrets.enc.yaml")
+	require.NoError(t, os.WriteFile(input, ciphertext, 0o600))
+
+	opts := &Options{
+		DecryptionFlags: util.DecryptionFlags{AgeIdentityFile: identityFile},
+		EncryptionFlags: util.EncryptionFlags{AgeRecipients: []string{identity.Recipient().String()}},
+		File:            input,
+	}
+
+	mockey.PatchConvey("edit secret file", t, func() {
+		mockey.Mock(runEditor).To(func(path string) error {
+			assert.Equal(t, "secrets.yaml", filepath.Base(path))
+			return os.WriteFile(path, []byte("token: n3w\n"), 0o600)
+		}).Build()
+
+		require.NoError(t, opts.Run())
+		content, err := os.ReadFile(input)
+		require.NoError(t, err)
+		plaintext, _, err := file.Decrypt(content, file.DecryptionKeys{AgeIdentityFile: identityFile})
+		require.NoError(t, err)
+		assert.Equal(t, "token: n3w\n", string(plaintext))
+	})
+
+	mockey.PatchConvey("invalid edited content", t, func() {
+		mockey.Mock(runEditor).To(func(path string) error {
+			return os.WriteFile(path, []byte("- token\n"), 0o600)
+		}).Build()
+
+		assert.Error(t, opts.Run())
+	})
+}
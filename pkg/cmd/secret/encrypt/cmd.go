@@ -0,0 +1,48 @@
+package encrypt
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Encrypt a secret file with age or PGP`)
+
+		long = i18n.T(`
+		This command encrypts a plaintext secret file, which is a YAML or JSON object keyed by the secret names, to the age recipients or the PGP public keys.
+
+		The encrypted file is ASCII armored, and can be used by the file secret store provider of the workspace.`)
+
+		example = i18n.T(`
+		# Encrypt a secret file to an age recipient
+		kusion secret encrypt secrets.yaml --age-recipient age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p -o secrets.enc.yaml
+
+		# Encrypt a secret file to a PGP public key
+		kusion secret encrypt secrets.yaml --pgp-public-key-file pubkey.asc -o secrets.enc.yaml`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "encrypt",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.EncryptionFlags.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "",
+		i18n.T("Specify the output file of the encrypted content, print to stdout if not specified"))
+	return cmd
+}
@@ -0,0 +1,53 @@
+package encrypt
+
+import (
+	"fmt"
+	"os"
+
+	"kusionstack.io/kusion/pkg/cmd/secret/util"
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+)
+
+type Options struct {
+	util.EncryptionFlags
+	File   string
+	Output string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	f, err := util.GetFileFromArgs(args)
+	if err != nil {
+		return err
+	}
+	o.File = f
+	return nil
+}
+
+func (o *Options) Validate() error {
+	if o.IsEmpty() {
+		return file.ErrMissingRecipients
+	}
+	if len(o.AgeRecipients) != 0 && o.PGPPublicKeyFile != "" {
+		return file.ErrMultipleRecipientTypes
+	}
+	return nil
+}
+
+func (o *Options) Run() error {
+	plaintext, err := os.ReadFile(o.File)
+	if err != nil {
+		return fmt.Errorf("read file %s failed: %w", o.File, err)
+	}
+	if _, err = file.ParseData(plaintext); err != nil {
+		return err
+	}
+	ciphertext, err := file.Encrypt(plaintext, o.Keys())
+	if err != nil {
+		return err
+	}
+	return util.WriteOutput(o.Output, ciphertext)
+}
@@ -0,0 +1,87 @@
+package encrypt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kusionstack.io/kusion/pkg/cmd/secret/util"
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+)
+
+func TestOptions_Complete(t *testing.T) {
+	opts := NewOptions()
+	assert.NoError(t, opts.Complete([]string{"secrets.yaml"}))
+	assert.Equal(t, "secrets.yaml", opts.File)
+	assert.ErrorIs(t, opts.Complete(nil), util.ErrNotOneArgs)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name   string
+		opts   *Options
+		expErr error
+	}{
+		{
+			name: "valid options",
+			opts: &Options{EncryptionFlags: util.EncryptionFlags{AgeRecipients: []string{"age1"}}},
+		},
+		{
+			name:   "missing recipients",
+			opts:   &Options{},
+			expErr: file.ErrMissingRecipients,
+		},
+		{
+			name: "multiple recipient types",
+			opts: &Options{EncryptionFlags: util.EncryptionFlags{
+				AgeRecipients:    []string{"age1"},
+				PGPPublicKeyFile: "public.asc",
+			}},
+			expErr: file.ErrMultipleRecipientTypes,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.opts.Validate(), tc.expErr)
+		})
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	identityFile := filepath.Join(dir, "age.key")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()), 0o600))
+
+	t.Run("encrypt secret file", func(t *testing.T) {
+		input := filepath.Join(dir, "secrets.yaml")
+		require.NoError(t, os.WriteFile(input, []byte("token: s3cr3t\n"), 0o600))
+		opts := &Options{
+			EncryptionFlags: util.EncryptionFlags{AgeRecipients: []string{identity.Recipient().String()}},
+			File:            input,
+			Output:          filepath.Join(dir, "secrets.enc.yaml"),
+		}
+		require.NoError(t, opts.Run())
+
+		ciphertext, err := os.ReadFile(opts.Output)
+		require.NoError(t, err)
+		plaintext, _, err := file.Decrypt(ciphertext, file.DecryptionKeys{AgeIdentityFile: identityFile})
+		require.NoError(t, err)
+		assert.Equal(t, "token: s3cr3t\n", string(plaintext))
+	})
+
+	t.Run("invalid secret file", func(t *testing.T) {
+		input := filepath.Join(dir, "invalid.yaml")
+		require.NoError(t, os.WriteFile(input, []byte("- token\n"), 0o600))
+		opts := &Options{
+			EncryptionFlags: util.EncryptionFlags{AgeRecipients: []string{identity.Recipient().String()}},
+			File:            input,
+		}
+		assert.Error(t, opts.Run())
+	})
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+var ErrNotOneArgs = errors.New("only one arg accepted as the secret file")
+
+// GetFileFromArgs returns the secret file specified by args.
+func GetFileFromArgs(args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", ErrNotOneArgs
+	}
+	return args[0], nil
+}
+
+// DecryptionFlags are the flags of the private keys to decrypt the secret file.
+type DecryptionFlags struct {
+	AgeIdentityFile   string
+	PGPPrivateKeyFile string
+}
+
+// AddFlags adds the decryption flags to the command.
+func (f *DecryptionFlags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.AgeIdentityFile, "age-identity-file", "", "",
+		i18n.T("Specify the age identity file to decrypt, defaults to the environment variable "+file.EnvAgeIdentityFile))
+	cmd.Flags().StringVarP(&f.PGPPrivateKeyFile, "pgp-private-key-file", "", "",
+		i18n.T("Specify the armored PGP private key file to decrypt, defaults to the environment variable "+file.EnvPGPPrivateKeyFile))
+}
+
+// Keys returns the decryption keys, where the unset keys are read from the environment variables.
+func (f *DecryptionFlags) Keys() file.DecryptionKeys {
+	keys := file.DecryptionKeys{
+		AgeIdentityFile:   f.AgeIdentityFile,
+		PGPPrivateKeyFile: f.PGPPrivateKeyFile,
+	}
+	keys.Complete()
+	return keys
+}
+
+// EncryptionFlags are the flags of the recipients to encrypt the secret file to.
+type EncryptionFlags struct {
+	AgeRecipients    []string
+	PGPPublicKeyFile string
+}
+
+// AddFlags adds the encryption flags to the command.
+func (f *EncryptionFlags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&f.AgeRecipients, "age-recipient", "", nil,
+		i18n.T("Specify the age recipients to encrypt to"))
+	cmd.Flags().StringVarP(&f.PGPPublicKeyFile, "pgp-public-key-file", "", "",
+		i18n.T("Specify the armored PGP public key file to encrypt to"))
+}
+
+// IsEmpty returns true if no recipient is specified.
+func (f *EncryptionFlags) IsEmpty() bool {
+	return len(f.AgeRecipients) == 0 && f.PGPPublicKeyFile == ""
+}
+
+// Keys returns the encryption keys.
+func (f *EncryptionFlags) Keys() file.EncryptionKeys {
+	return file.EncryptionKeys{
+		AgeRecipients:    f.AgeRecipients,
+		PGPPublicKeyFile: f.PGPPublicKeyFile,
+	}
+}
+
+// WriteOutput writes the content to the output file, or prints it if the output is empty.
+func WriteOutput(output string, content []byte) error {
+	if output == "" {
+		fmt.Print(string(content))
+		return nil
+	}
+	if err := os.WriteFile(output, content, 0o600); err != nil {
+		return fmt.Errorf("write file %s failed: %w", output, err)
+	}
+	return nil
+}
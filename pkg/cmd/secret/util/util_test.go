@@ -0,0 +1,42 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"kusionstack.io/kusion/pkg/secrets/providers/file"
+)
+
+func TestGetFileFromArgs(t *testing.T) {
+	f, err := GetFileFromArgs([]string{"secrets.enc.yaml"})
+	assert.NoError(t, err)
+	assert.Equal(t, "secrets.enc.yaml", f)
+
+	_, err = GetFileFromArgs(nil)
+	assert.ErrorIs(t, err, ErrNotOneArgs)
+	_, err = GetFileFromArgs([]string{"a", "b"})
+	assert.ErrorIs(t, err, ErrNotOneArgs)
+}
+
+func TestDecryptionFlags_Keys(t *testing.T) {
+	t.Setenv(file.EnvAgeIdentityFile, "env.key")
+	t.Setenv(file.EnvPGPPassphrase, "passphrase")
+
+	flags := &DecryptionFlags{PGPPrivateKeyFile: "private.asc"}
+	assert.Equal(t, file.DecryptionKeys{
+		AgeIdentityFile:   "env.key",
+		PGPPrivateKeyFile: "private.asc",
+		PGPPassphrase:     "passphrase",
+	}, flags.Keys())
+}
+
+func TestWriteOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.yaml")
+	assert.NoError(t, WriteOutput(output, []byte("content")))
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
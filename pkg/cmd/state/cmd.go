@@ -0,0 +1,49 @@
+package state
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/state/list"
+	"kusionstack.io/kusion/pkg/cmd/state/migrate"
+	"kusionstack.io/kusion/pkg/cmd/state/mv"
+	"kusionstack.io/kusion/pkg/cmd/state/pull"
+	"kusionstack.io/kusion/pkg/cmd/state/push"
+	"kusionstack.io/kusion/pkg/cmd/state/rekey"
+	"kusionstack.io/kusion/pkg/cmd/state/rm"
+	"kusionstack.io/kusion/pkg/cmd/state/show"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`State is a record of an operation's result, which is used to manage the resources of a stack`)
+
+		long = i18n.T(`
+		State is a record of an operation's result, which is a mapping between the resources in the intent and the actual infra resources.
+
+		The state subcommands operate on the state of the current stack, which is stored in the backend configured by the cli backend options, environment variables or the workspace.`)
+	)
+
+	cmd := &cobra.Command{
+		Use:           "state",
+		Short:         short,
+		Long:          templates.LongDesc(long),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	listCmd := list.NewCmd()
+	showCmd := show.NewCmd()
+	rmCmd := rm.NewCmd()
+	mvCmd := mv.NewCmd()
+	pullCmd := pull.NewCmd()
+	pushCmd := push.NewCmd()
+	migrateCmd := migrate.NewCmd()
+	rekeyCmd := rekey.NewCmd()
+	cmd.AddCommand(listCmd, showCmd, rmCmd, mvCmd, pullCmd, pushCmd, migrateCmd, rekeyCmd)
+
+	return cmd
+}
@@ -0,0 +1,45 @@
+package list
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`List the resources in the state`)
+
+		long = i18n.T(`
+		This command lists the resources recorded in the latest state of the current stack.`)
+
+		example = i18n.T(`
+		# List the resources in the state of current stack
+		kusion state list
+
+		# List the resources in the state stored in mysql
+		kusion state list --backend-type mysql -C dbName=kusion -C user=kusion -C host=127.0.0.1 -C port=3306`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "list",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
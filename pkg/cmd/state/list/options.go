@@ -0,0 +1,46 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+)
+
+type Options struct {
+	util.Options
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 0 {
+		return util.ErrUnexpectedArgs
+	}
+	return nil
+}
+
+func (o *Options) Run() error {
+	storage, query, err := o.StateStorage()
+	if err != nil {
+		return err
+	}
+	state, err := util.GetLatestState(storage, query)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Project: %s, Stack: %s, Serial: %d, Resources: %d\n\n", state.Project, state.Stack, state.Serial, len(state.Resources))
+	if len(state.Resources) == 0 {
+		return nil
+	}
+	data := pterm.TableData{{"ID", "Type", "DependsOn"}}
+	for _, r := range state.Resources {
+		data = append(data, []string{r.ID, string(r.Type), fmt.Sprintf("%d", len(r.DependsOn))})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithSeparator("  ").WithData(data).WithWriter(os.Stdout).Render()
+}
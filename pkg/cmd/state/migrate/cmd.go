@@ -0,0 +1,57 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Migrate the state to another backend`)
+
+		long = i18n.T(`
+		This command migrates the state of the current stack from the current backend to the target backend.
+
+		The latest state is copied, and so is the history of the state if the current backend keeps it. After copied, the state is read back from the target backend to verify the migration. The workspace backends config can be updated to the target backend by specifying --update-workspace.`)
+
+		example = i18n.T(`
+		# Migrate the state of current stack from the local backend to s3
+		kusion state migrate --to-backend-type s3 --to-backend-config bucket=kusion --to-backend-config region=us-east-1
+
+		# Migrate the state to mysql, and update the backends config of the workspace
+		kusion state migrate --to-backend-type mysql --to-backend-config dbName=kusion --to-backend-config user=kusion --to-backend-config host=127.0.0.1 --to-backend-config port=3306 --update-workspace`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "migrate",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.ToBackendType, "to-backend-type", "",
+		i18n.T("Specify the type of the target backend"))
+	cmd.Flags().StringSliceVar(&o.ToBackendConfig, "to-backend-config", []string{},
+		i18n.T("Specify the config of the target backend, with the format key=value"))
+	cmd.Flags().BoolVarP(&o.UpdateWorkspace, "update-workspace", "", false,
+		i18n.T("Update the backends config of the workspace to the target backend after migrated"))
+	cmd.Flags().BoolVarP(&o.Force, "force", "", false,
+		i18n.T("Migrate the state even though the state already exists in the target backend"))
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", false,
+		i18n.T("Automatically approve and migrate the state without prompting"))
+
+	return cmd
+}
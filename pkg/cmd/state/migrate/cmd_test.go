@@ -0,0 +1,20 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmd(t *testing.T) {
+	t.Run("successfully migrate state", func(t *testing.T) {
+		mockey.PatchConvey("mock cmd", t, func() {
+			mockey.Mock((*Options).Run).Return(nil).Build()
+			cmd := NewCmd()
+			cmd.SetArgs([]string{"--to-backend-type", "s3", "--to-backend-config", "bucket=kusion"})
+			err := cmd.Execute()
+			assert.Nil(t, err)
+		})
+	})
+}
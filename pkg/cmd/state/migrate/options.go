@@ -0,0 +1,220 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/backend"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/project"
+	"kusionstack.io/kusion/pkg/workspace"
+)
+
+var (
+	ErrEmptyToBackendType = errors.New("empty --to-backend-type")
+	ErrSameBackend        = errors.New("the target backend is the same as the current backend")
+)
+
+type Options struct {
+	util.Options
+	ToBackendType   string
+	ToBackendConfig []string
+	UpdateWorkspace bool
+	Force           bool
+	Yes             bool
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 0 {
+		return util.ErrUnexpectedArgs
+	}
+	return nil
+}
+
+func (o *Options) Validate() error {
+	if o.ToBackendType == "" {
+		return ErrEmptyToBackendType
+	}
+	// the target backend config is validated by its ConfigSchema
+	if err := o.toBackendOptions().Validate(); err != nil {
+		return fmt.Errorf("invalid target backend, %w", err)
+	}
+	return o.Options.Validate()
+}
+
+func (o *Options) Run() error {
+	project, stack, err := project.DetectProjectAndStack(o.WorkDir)
+	if err != nil {
+		return err
+	}
+	sourceConfig, err := backend.NewStateStorageConfig(stack, &o.BackendOptions)
+	if err != nil {
+		return err
+	}
+	targetConfig, err := backend.NewConfig(stack.Path, nil, o.toBackendOptions())
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(sourceConfig, targetConfig) {
+		return ErrSameBackend
+	}
+	sourceStorage, err := sourceConfig.NewStateStorage()
+	if err != nil {
+		return err
+	}
+	targetStorage, err := targetConfig.NewStateStorage()
+	if err != nil {
+		return fmt.Errorf("new state storage of the target backend failed, %w", err)
+	}
+	query := &states.StateQuery{
+		Tenant:  "",
+		Stack:   stack.Name,
+		Project: project.Name,
+	}
+
+	migratedStates, err := getMigratedStates(sourceStorage, query)
+	if err != nil {
+		return err
+	}
+	latestState := migratedStates[len(migratedStates)-1]
+	targetState, err := targetStorage.GetLatestState(query)
+	if err != nil {
+		return fmt.Errorf("get state from the target backend failed, %w", err)
+	}
+	if targetState != nil && !o.Force {
+		return fmt.Errorf("state of stack %s already exists in the target backend %s, use --force to overwrite it",
+			query.Stack, targetConfig.Type)
+	}
+
+	if !o.Yes {
+		confirmed, err := util.Confirm(fmt.Sprintf("Do you want to migrate %d state(s) of stack %s to the %s backend?",
+			len(migratedStates), query.Stack, targetConfig.Type))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("operation canceled")
+			return nil
+		}
+	}
+
+	for _, state := range migratedStates {
+		// the id is generated by the target backend
+		state.ID = 0
+		if targetState != nil && state == latestState && state.Serial <= targetState.Serial {
+			// keep the migrated state as the latest one in the target backend
+			state.Serial = targetState.Serial + 1
+		}
+		if err = targetStorage.Apply(state); err != nil {
+			return fmt.Errorf("apply state of serial %d to the target backend failed, %w", state.Serial, err)
+		}
+	}
+	if err = verifyMigratedState(targetStorage, query, latestState); err != nil {
+		return err
+	}
+	fmt.Printf("migrate %d state(s) of stack %s to the %s backend successfully\n", len(migratedStates), query.Stack, targetConfig.Type)
+
+	if o.UpdateWorkspace {
+		if err = updateWorkspaceBackends(stack.Name, targetConfig); err != nil {
+			return err
+		}
+		fmt.Printf("update backends of workspace %s to the %s backend successfully\n", stack.Name, targetConfig.Type)
+	}
+	return nil
+}
+
+func (o *Options) toBackendOptions() *backend.BackendOptions {
+	return &backend.BackendOptions{
+		Type:   o.ToBackendType,
+		Config: o.ToBackendConfig,
+	}
+}
+
+// getMigratedStates returns the history states if the storage keeps them, otherwise the latest state only.
+// The states are in ascending order of serial, and the last one is the latest state.
+func getMigratedStates(storage states.StateStorage, query *states.StateQuery) ([]*states.State, error) {
+	if historyStorage, ok := storage.(states.HistoryStateStorage); ok {
+		historyStates, err := historyStorage.GetHistoryStates(query)
+		if err != nil && !errors.Is(err, states.ErrHistoryNotSupported) {
+			return nil, err
+		}
+		if len(historyStates) != 0 {
+			return historyStates, nil
+		}
+	}
+	state, err := util.GetLatestState(storage, query)
+	if err != nil {
+		return nil, err
+	}
+	return []*states.State{state}, nil
+}
+
+// verifyMigratedState reads the latest state back from the target storage, and checks it is the same as
+// the migrated one.
+func verifyMigratedState(storage states.StateStorage, query *states.StateQuery, expected *states.State) error {
+	state, err := storage.GetLatestState(query)
+	if err != nil {
+		return fmt.Errorf("verify migrated state failed, %w", err)
+	}
+	if state == nil {
+		return errors.New("verify migrated state failed, no state found in the target backend")
+	}
+	if state.Serial != expected.Serial {
+		return fmt.Errorf("verify migrated state failed, serial %d mismatches the expected %d", state.Serial, expected.Serial)
+	}
+	got, err := marshalSortedResources(state.Resources)
+	if err != nil {
+		return err
+	}
+	want, err := marshalSortedResources(expected.Resources)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return errors.New("verify migrated state failed, resources mismatch")
+	}
+	return nil
+}
+
+// marshalSortedResources marshals the sorted resources, for the backends may reorder the resources, and
+// may decode the numbers of the attributes to different types.
+func marshalSortedResources(resources v1.Resources) (string, error) {
+	sorted := make(v1.Resources, len(resources))
+	copy(sorted, resources)
+	sort.Stable(sorted)
+	content, err := json.Marshal(sorted)
+	if err != nil {
+		return "", fmt.Errorf("json marshal resources failed, %w", err)
+	}
+	return string(content), nil
+}
+
+// updateWorkspaceBackends updates the backends of the workspace to the target backend.
+func updateWorkspaceBackends(name string, config *backend.StateStorageConfig) error {
+	backendConfigs, err := config.ToWorkspaceBackendConfigs()
+	if err != nil {
+		return err
+	}
+	wsOperator, err := workspace.NewValidDefaultOperator()
+	if err != nil {
+		return err
+	}
+	ws, err := wsOperator.GetRawWorkspace(name)
+	if err != nil {
+		return fmt.Errorf("get workspace %s failed, %w", name, err)
+	}
+	ws.Backends = backendConfigs
+	if err = wsOperator.UpdateWorkspace(ws); err != nil {
+		return fmt.Errorf("update workspace %s failed, %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package migrate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/backend"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+	"kusionstack.io/kusion/pkg/project"
+	"kusionstack.io/kusion/pkg/workspace"
+)
+
+func mockState() *states.State {
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 3
+	state.Resources = v1.Resources{
+		{
+			ID:         "v1:Namespace:default",
+			Type:       v1.Kubernetes,
+			Attributes: map[string]interface{}{"replicas": 2},
+		},
+	}
+	return state
+}
+
+// mockBackends mocks the current backend with a local state file in a temp dir, and the stack path to
+// another temp dir, which is used by the local target backend.
+func mockBackends(t *testing.T) (source, target *local.FileSystemState) {
+	sourcePath := filepath.Join(t.TempDir(), local.KusionStateFileFile)
+	stackPath := t.TempDir()
+	mockey.Mock(project.DetectProjectAndStack).Return(&v1.Project{Name: "foo"}, &v1.Stack{Name: "dev", Path: stackPath}, nil).Build()
+	mockey.Mock(backend.NewStateStorageConfig).Return(&backend.StateStorageConfig{
+		Type:   v1.DeprecatedBackendLocal,
+		Config: map[string]any{"path": sourcePath},
+	}, nil).Build()
+	return &local.FileSystemState{Path: sourcePath}, &local.FileSystemState{Path: filepath.Join(stackPath, local.KusionStateFileFile)}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name: "valid options",
+			opts: &Options{
+				ToBackendType:   v1.DeprecatedBackendS3,
+				ToBackendConfig: []string{"bucket=kusion", "region=us-east-1"},
+			},
+			success: true,
+		},
+		{
+			name:    "empty target backend type",
+			opts:    &Options{},
+			success: false,
+		},
+		{
+			name: "invalid target backend config",
+			opts: &Options{
+				ToBackendType:   v1.DeprecatedBackendS3,
+				ToBackendConfig: []string{"dbName=kusion"},
+			},
+			success: false,
+		},
+		{
+			name: "invalid current backend options",
+			opts: &Options{
+				Options: util.Options{
+					BackendOptions: backend.BackendOptions{Config: []string{"bucket=kusion"}},
+				},
+				ToBackendType: v1.DeprecatedBackendLocal,
+			},
+			success: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			assert.Equal(t, tc.success, err == nil)
+		})
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	mockey.PatchConvey("migrate state", t, func() {
+		source, target := mockBackends(t)
+		assert.NoError(t, source.Apply(mockState()))
+
+		opts := &Options{ToBackendType: v1.DeprecatedBackendLocal, Yes: true}
+		assert.Nil(t, opts.Run())
+		state, err := target.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(3), state.Serial)
+		assert.Equal(t, "v1:Namespace:default", state.Resources[0].ID)
+
+		// the state exists in the target backend
+		assert.Error(t, opts.Run())
+		opts.Force = true
+		assert.Nil(t, opts.Run())
+		state, err = target.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(4), state.Serial)
+	})
+
+	mockey.PatchConvey("migrate not existed state", t, func() {
+		mockBackends(t)
+		opts := &Options{ToBackendType: v1.DeprecatedBackendLocal, Yes: true}
+		assert.Error(t, opts.Run())
+	})
+
+	mockey.PatchConvey("migrate to the same backend", t, func() {
+		stackPath := t.TempDir()
+		mockey.Mock(project.DetectProjectAndStack).Return(&v1.Project{Name: "foo"}, &v1.Stack{Name: "dev", Path: stackPath}, nil).Build()
+		mockey.Mock(backend.NewStateStorageConfig).Return(backend.NewDefaultStateStorageConfig(stackPath), nil).Build()
+		opts := &Options{ToBackendType: v1.DeprecatedBackendLocal, Yes: true}
+		assert.ErrorIs(t, opts.Run(), ErrSameBackend)
+	})
+
+	mockey.PatchConvey("migrate state and update workspace", t, func() {
+		source, _ := mockBackends(t)
+		assert.NoError(t, source.Apply(mockState()))
+		operator, err := workspace.NewOperator(t.TempDir())
+		assert.NoError(t, err)
+		assert.NoError(t, operator.CreateWorkspace(&v1.Workspace{
+			Name: "dev",
+			Backends: &v1.DeprecatedBackendConfigs{
+				Mysql: &v1.DeprecatedMysqlConfig{DBName: "kusion", User: "kusion", Host: "127.0.0.1"},
+			},
+		}))
+		mockey.Mock(workspace.NewValidDefaultOperator).Return(operator, nil).Build()
+
+		opts := &Options{ToBackendType: v1.DeprecatedBackendLocal, UpdateWorkspace: true, Yes: true}
+		assert.Nil(t, opts.Run())
+		ws, err := operator.GetWorkspace("dev")
+		assert.Nil(t, err)
+		assert.Equal(t, &v1.DeprecatedBackendConfigs{Local: &v1.DeprecatedLocalFileConfig{}}, ws.Backends)
+	})
+}
@@ -0,0 +1,47 @@
+package mv
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Rename a resource in the state`)
+
+		long = i18n.T(`
+		This command renames a specified resource in the latest state of the current stack, and the references in dependsOn of other resources are updated as well. It is useful when the resource id changes while the actual resource is kept.`)
+
+		example = i18n.T(`
+		# Rename a resource in the state of current stack
+		kusion state mv v1:Namespace:default v1:Namespace:default-new
+
+		# Rename a resource without confirmation
+		kusion state mv v1:Namespace:default v1:Namespace:default-new --yes`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "mv",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", false,
+		i18n.T("Automatically approve and rename the resource without prompting"))
+
+	return cmd
+}
@@ -0,0 +1,20 @@
+package mv
+
+import (
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmd(t *testing.T) {
+	t.Run("successfully mv state", func(t *testing.T) {
+		mockey.PatchConvey("mock cmd", t, func() {
+			mockey.Mock((*Options).Run).Return(nil).Build()
+			cmd := NewCmd()
+			cmd.SetArgs([]string{"v1:Namespace:default", "v1:Namespace:foo"})
+			err := cmd.Execute()
+			assert.Nil(t, err)
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package mv
+
+import (
+	"fmt"
+
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+)
+
+type Options struct {
+	util.Options
+	SourceID      string
+	DestinationID string
+	Yes           bool
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 2 {
+		return util.ErrNotTwoArgs
+	}
+	o.SourceID = args[0]
+	o.DestinationID = args[1]
+	return nil
+}
+
+func (o *Options) Validate() error {
+	if err := util.ValidateResourceID(o.SourceID); err != nil {
+		return err
+	}
+	if err := util.ValidateResourceID(o.DestinationID); err != nil {
+		return err
+	}
+	if o.SourceID == o.DestinationID {
+		return fmt.Errorf("the source and destination resource id are the same")
+	}
+	return o.Options.Validate()
+}
+
+func (o *Options) Run() error {
+	storage, query, err := o.StateStorage()
+	if err != nil {
+		return err
+	}
+	state, err := util.GetLatestState(storage, query)
+	if err != nil {
+		return err
+	}
+	index := util.GetResourceIndex(state, o.SourceID)
+	if index == -1 {
+		return fmt.Errorf("resource %s not found in the state", o.SourceID)
+	}
+	if util.GetResourceIndex(state, o.DestinationID) != -1 {
+		return fmt.Errorf("resource %s already exists in the state", o.DestinationID)
+	}
+
+	if !o.Yes {
+		confirmed, err := util.Confirm(fmt.Sprintf("Do you want to rename resource %s to %s in the state of stack %s?", o.SourceID, o.DestinationID, query.Stack))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("operation canceled")
+			return nil
+		}
+	}
+
+	state.Resources[index].ID = o.DestinationID
+	for i := range state.Resources {
+		for j, d := range state.Resources[i].DependsOn {
+			if d == o.SourceID {
+				state.Resources[i].DependsOn[j] = o.DestinationID
+			}
+		}
+	}
+	if err = util.ApplyState(storage, state); err != nil {
+		return err
+	}
+	fmt.Printf("rename resource %s to %s in the state successfully\n", o.SourceID, o.DestinationID)
+	return nil
+}
@@ -0,0 +1,69 @@
+package mv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+)
+
+func mockStorage(t *testing.T) *local.FileSystemState {
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	state.Resources = v1.Resources{
+		{ID: "v1:Namespace:default", Type: v1.Kubernetes},
+		{ID: "v1:ConfigMap:default:foo", Type: v1.Kubernetes, DependsOn: []string{"v1:Namespace:default"}},
+	}
+	assert.NoError(t, storage.Apply(state))
+	return storage
+}
+
+func mockStateStorage(storage states.StateStorage) {
+	mockey.Mock((*util.Options).StateStorage).Return(storage, &states.StateQuery{Project: "foo", Stack: "dev"}, nil).Build()
+}
+
+func TestOptions_CompleteAndValidate(t *testing.T) {
+	opts := NewOptions()
+	assert.ErrorIs(t, opts.Complete([]string{"a"}), util.ErrNotTwoArgs)
+	assert.Nil(t, opts.Complete([]string{"a", "a"}))
+	assert.Error(t, opts.Validate())
+	assert.Nil(t, opts.Complete([]string{"a", ""}))
+	assert.ErrorIs(t, opts.Validate(), util.ErrEmptyResourceID)
+	assert.Nil(t, opts.Complete([]string{"a", "b"}))
+	assert.Nil(t, opts.Validate())
+}
+
+func TestOptions_Run(t *testing.T) {
+	mockey.PatchConvey("rename to existed resource", t, func() {
+		mockStateStorage(mockStorage(t))
+		opts := &Options{SourceID: "v1:Namespace:default", DestinationID: "v1:ConfigMap:default:foo", Yes: true}
+		assert.Error(t, opts.Run())
+	})
+
+	mockey.PatchConvey("rename not existed resource", t, func() {
+		mockStateStorage(mockStorage(t))
+		opts := &Options{SourceID: "v1:Namespace:foo", DestinationID: "v1:Namespace:bar", Yes: true}
+		assert.Error(t, opts.Run())
+	})
+
+	mockey.PatchConvey("rename resource", t, func() {
+		storage := mockStorage(t)
+		mockStateStorage(storage)
+		opts := &Options{SourceID: "v1:Namespace:default", DestinationID: "v1:Namespace:foo", Yes: true}
+		assert.Nil(t, opts.Run())
+		state, err := storage.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "v1:Namespace:foo", state.Resources[0].ID)
+		assert.Equal(t, []string{"v1:Namespace:foo"}, state.Resources[1].DependsOn)
+		assert.Equal(t, uint64(2), state.Serial)
+	})
+}
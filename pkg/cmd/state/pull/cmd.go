@@ -0,0 +1,47 @@
+package pull
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Pull the state from the backend`)
+
+		long = i18n.T(`
+		This command pulls the latest state of the current stack from the backend, and writes it to the stdout or a specified file in YAML format.`)
+
+		example = i18n.T(`
+		# Pull the state of current stack to stdout
+		kusion state pull
+
+		# Pull the state of current stack to a file
+		kusion state pull -o state.yaml`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "pull",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "",
+		i18n.T("Specify the file to write the state to, default to stdout"))
+
+	return cmd
+}
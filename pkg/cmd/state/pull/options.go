@@ -0,0 +1,51 @@
+package pull
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+)
+
+type Options struct {
+	util.Options
+	Output string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 0 {
+		return util.ErrUnexpectedArgs
+	}
+	return nil
+}
+
+func (o *Options) Run() error {
+	storage, query, err := o.StateStorage()
+	if err != nil {
+		return err
+	}
+	state, err := util.GetLatestState(storage, query)
+	if err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("yaml marshal state failed: %w", err)
+	}
+
+	if o.Output == "" {
+		fmt.Print(string(content))
+		return nil
+	}
+	if err = os.WriteFile(o.Output, content, 0o600); err != nil {
+		return fmt.Errorf("write state to %s failed: %w", o.Output, err)
+	}
+	fmt.Printf("pull state of stack %s to %s successfully\n", query.Stack, o.Output)
+	return nil
+}
@@ -0,0 +1,55 @@
+package pull
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+)
+
+func mockStorage(t *testing.T) *local.FileSystemState {
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	state.Resources = v1.Resources{
+		{ID: "v1:Namespace:default", Type: v1.Kubernetes},
+		{ID: "v1:ConfigMap:default:foo", Type: v1.Kubernetes, DependsOn: []string{"v1:Namespace:default"}},
+	}
+	assert.NoError(t, storage.Apply(state))
+	return storage
+}
+
+func mockStateStorage(storage states.StateStorage) {
+	mockey.Mock((*util.Options).StateStorage).Return(storage, &states.StateQuery{Project: "foo", Stack: "dev"}, nil).Build()
+}
+
+func TestOptions_Complete(t *testing.T) {
+	opts := NewOptions()
+	assert.Nil(t, opts.Complete(nil))
+	assert.ErrorIs(t, opts.Complete([]string{"foo"}), util.ErrUnexpectedArgs)
+}
+
+func TestOptions_Run(t *testing.T) {
+	mockey.PatchConvey("pull state to stdout", t, func() {
+		mockStateStorage(mockStorage(t))
+		assert.Nil(t, NewOptions().Run())
+	})
+
+	mockey.PatchConvey("pull state to file", t, func() {
+		mockStateStorage(mockStorage(t))
+		output := filepath.Join(t.TempDir(), "state.yaml")
+		assert.Nil(t, (&Options{Output: output}).Run())
+		content, err := os.ReadFile(output)
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), "v1:ConfigMap:default:foo")
+	})
+}
@@ -0,0 +1,49 @@
+package push
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Push a local state file to the backend`)
+
+		long = i18n.T(`
+		This command pushes a local state file in YAML format to the backend as the latest state of the current stack. The serial of the state file must not be less than the latest state in the backend, unless --force is specified.`)
+
+		example = i18n.T(`
+		# Push the state file to the backend of current stack
+		kusion state push state.yaml
+
+		# Push the state file even though its serial is less than the latest state
+		kusion state push state.yaml --force --yes`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "push",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", false,
+		i18n.T("Automatically approve and push the state without prompting"))
+	cmd.Flags().BoolVarP(&o.Force, "force", "", false,
+		i18n.T("Push the state even though its serial is less than the latest state"))
+
+	return cmd
+}
@@ -0,0 +1,91 @@
+package push
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/states"
+)
+
+type Options struct {
+	util.Options
+	File  string
+	Yes   bool
+	Force bool
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 1 {
+		return util.ErrNotOneArgs
+	}
+	o.File = args[0]
+	return nil
+}
+
+func (o *Options) Run() error {
+	content, err := os.ReadFile(o.File)
+	if err != nil {
+		return fmt.Errorf("read state file %s failed: %w", o.File, err)
+	}
+	state := &states.State{}
+	if err = yaml.Unmarshal(content, state); err != nil {
+		return fmt.Errorf("yaml unmarshal state file %s failed: %w", o.File, err)
+	}
+
+	storage, query, err := o.StateStorage()
+	if err != nil {
+		return err
+	}
+	if state.Project == "" {
+		state.Project = query.Project
+	}
+	if state.Stack == "" {
+		state.Stack = query.Stack
+	}
+	if state.Project != query.Project || state.Stack != query.Stack {
+		return fmt.Errorf("state file of project %s stack %s mismatches the current project %s stack %s",
+			state.Project, state.Stack, query.Project, query.Stack)
+	}
+
+	latestState, err := storage.GetLatestState(query)
+	if err != nil {
+		return err
+	}
+	var latestSerial uint64
+	if latestState != nil {
+		latestSerial = latestState.Serial
+	}
+	if state.Serial < latestSerial && !o.Force {
+		return fmt.Errorf("serial %d of the state file is less than serial %d of the latest state, use --force to push anyway",
+			state.Serial, latestSerial)
+	}
+
+	if !o.Yes {
+		confirmed, err := util.Confirm(fmt.Sprintf("Do you want to overwrite the state of stack %s with %s?", query.Stack, o.File))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("operation canceled")
+			return nil
+		}
+	}
+
+	// the pushed state always becomes the latest one
+	state.Serial = latestSerial
+	if latestState != nil {
+		state.ID = latestState.ID
+	}
+	if err = util.ApplyState(storage, state); err != nil {
+		return err
+	}
+	fmt.Printf("push state file %s to stack %s successfully\n", o.File, query.Stack)
+	return nil
+}
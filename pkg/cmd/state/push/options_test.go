@@ -0,0 +1,78 @@
+package push
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+)
+
+func mockStorage(t *testing.T) *local.FileSystemState {
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	state.Resources = v1.Resources{
+		{ID: "v1:Namespace:default", Type: v1.Kubernetes},
+		{ID: "v1:ConfigMap:default:foo", Type: v1.Kubernetes, DependsOn: []string{"v1:Namespace:default"}},
+	}
+	assert.NoError(t, storage.Apply(state))
+	return storage
+}
+
+func mockStateStorage(storage states.StateStorage) {
+	mockey.Mock((*util.Options).StateStorage).Return(storage, &states.StateQuery{Project: "foo", Stack: "dev"}, nil).Build()
+}
+
+func mockStateFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "state.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestOptions_Complete(t *testing.T) {
+	opts := NewOptions()
+	assert.ErrorIs(t, opts.Complete(nil), util.ErrNotOneArgs)
+	assert.Nil(t, opts.Complete([]string{"state.yaml"}))
+	assert.Equal(t, "state.yaml", opts.File)
+}
+
+func TestOptions_Run(t *testing.T) {
+	mockey.PatchConvey("push state of other stack", t, func() {
+		mockStateStorage(mockStorage(t))
+		file := mockStateFile(t, "project: foo\nstack: prod\nserial: 1\n")
+		assert.Error(t, (&Options{File: file, Yes: true}).Run())
+	})
+
+	mockey.PatchConvey("push stale state", t, func() {
+		storage := mockStorage(t)
+		mockStateStorage(storage)
+		file := mockStateFile(t, "serial: 0\nresources: []\n")
+		assert.Error(t, (&Options{File: file, Yes: true}).Run())
+
+		assert.Nil(t, (&Options{File: file, Yes: true, Force: true}).Run())
+		state, err := storage.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Empty(t, state.Resources)
+		assert.Equal(t, uint64(2), state.Serial)
+	})
+
+	mockey.PatchConvey("cancel pushing state", t, func() {
+		storage := mockStorage(t)
+		mockStateStorage(storage)
+		mockey.Mock(util.Confirm).Return(false, nil).Build()
+		file := mockStateFile(t, "project: foo\nstack: dev\nserial: 1\nresources: []\n")
+		assert.Nil(t, (&Options{File: file}).Run())
+		state, err := storage.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Len(t, state.Resources, 2)
+	})
+}
@@ -0,0 +1,53 @@
+package rekey
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Re-encrypt the state with the current encryption key`)
+
+		long = i18n.T(`
+		This command re-encrypts the latest state of the current stack with the encryption key configured in the backend config, which is used to rotate the key.
+
+		The state encrypted by the previous key can be decrypted by specifying the previous key with the old key flags, and the plaintext state can be encrypted directly.`)
+
+		example = i18n.T(`
+		# Rotate the key file of the state encryption
+		kusion state rekey --backend-type local -C encryptionKeyFile=new.key --old-key-file old.key
+
+		# Rotate the age recipient of the state encryption
+		kusion state rekey --backend-type s3 -C bucket=kusion -C encryptionAgeRecipients=age1new... --old-age-identity-file old-age.key`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "rekey",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.OldKeyFile, "old-key-file", "", "",
+		i18n.T("Specify the previous key file to decrypt the state"))
+	cmd.Flags().StringVarP(&o.OldKeyEnv, "old-key-env", "", "",
+		i18n.T("Specify the environment variable of the previous key to decrypt the state"))
+	cmd.Flags().StringVarP(&o.OldAgeIdentityFile, "old-age-identity-file", "", "",
+		i18n.T("Specify the previous age identity file to decrypt the state"))
+
+	return cmd
+}
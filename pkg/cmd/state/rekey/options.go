@@ -0,0 +1,79 @@
+package rekey
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/states/encrypted"
+)
+
+var ErrEncryptionNotConfigured = errors.New("state encryption is not configured in the backend config")
+
+type Options struct {
+	util.Options
+	OldKeyFile         string
+	OldKeyEnv          string
+	OldAgeIdentityFile string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 0 {
+		return util.ErrUnexpectedArgs
+	}
+	return nil
+}
+
+func (o *Options) Validate() error {
+	return o.Options.Validate()
+}
+
+func (o *Options) Run() error {
+	storage, query, err := o.StateStorage()
+	if err != nil {
+		return err
+	}
+	encryptedStorage, ok := storage.(*encrypted.EncryptedState)
+	if !ok {
+		return ErrEncryptionNotConfigured
+	}
+	if oldKeyConfig := o.oldKeyConfig(); len(oldKeyConfig) != 0 {
+		var oldKey encrypted.Key
+		if oldKey, err = encrypted.NewKey(oldKeyConfig); err != nil {
+			return fmt.Errorf("invalid previous key: %w", err)
+		}
+		encryptedStorage.AddDecryptionKeys(oldKey)
+	}
+
+	state, err := util.GetLatestState(encryptedStorage, query)
+	if err != nil {
+		return err
+	}
+	if err = util.ApplyState(encryptedStorage, state); err != nil {
+		return err
+	}
+
+	key := encryptedStorage.Key()
+	fmt.Printf("rekey state of stack %s with %s key %s successfully\n", query.Stack, key.Type(), key.ID())
+	return nil
+}
+
+// oldKeyConfig converts the old key flags to the encryption config.
+func (o *Options) oldKeyConfig() map[string]any {
+	config := make(map[string]any)
+	if o.OldKeyFile != "" {
+		config[v1.BackendEncryptionKeyFile] = o.OldKeyFile
+	}
+	if o.OldKeyEnv != "" {
+		config[v1.BackendEncryptionKeyEnv] = o.OldKeyEnv
+	}
+	if o.OldAgeIdentityFile != "" {
+		config[v1.BackendEncryptionAgeIdentityFile] = o.OldAgeIdentityFile
+	}
+	return config
+}
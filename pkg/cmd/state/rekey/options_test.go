@@ -0,0 +1,113 @@
+package rekey
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/backend"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/encrypted"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+	"kusionstack.io/kusion/pkg/project"
+)
+
+func mockKey(t *testing.T, content string) (encrypted.Key, string) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(content))
+	path := filepath.Join(t.TempDir(), "state.key")
+	assert.NoError(t, os.WriteFile(path, []byte(encoded), 0o600))
+	key, err := encrypted.NewAESKey([]byte(encoded))
+	assert.NoError(t, err)
+	return key, path
+}
+
+func TestOptions_Complete(t *testing.T) {
+	opts := NewOptions()
+	assert.Nil(t, opts.Complete(nil))
+	assert.ErrorIs(t, opts.Complete([]string{"dev"}), util.ErrUnexpectedArgs)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name:    "valid options",
+			opts:    &Options{},
+			success: true,
+		},
+		{
+			name: "valid options with backend options",
+			opts: &Options{
+				Options: util.Options{
+					BackendOptions: backend.BackendOptions{
+						Type:   v1.DeprecatedBackendLocal,
+						Config: []string{"encryptionKeyFile=state.key"},
+					},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid backend options",
+			opts: &Options{
+				Options: util.Options{
+					BackendOptions: backend.BackendOptions{
+						Config: []string{"encryptionKeyFile=state.key"},
+					},
+				},
+			},
+			success: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			assert.Equal(t, tc.success, err == nil)
+		})
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	oldKey, oldKeyFile := mockKey(t, "0123456789abcdef0123456789abcdef")
+	newKey, _ := mockKey(t, "fedcba9876543210fedcba9876543210")
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	assert.NoError(t, encrypted.NewEncryptedState(storage, oldKey).Apply(state))
+
+	mockey.PatchConvey("mock project and state storage", t, func() {
+		mockey.Mock(project.DetectProjectAndStack).Return(&v1.Project{Name: "foo"}, &v1.Stack{Name: "dev"}, nil).Build()
+		mockey.Mock(backend.NewStateStorage).To(func(*v1.Stack, *backend.BackendOptions) (states.StateStorage, error) {
+			return encrypted.NewEncryptedState(storage, newKey), nil
+		}).Build()
+
+		opts := &Options{}
+		assert.Error(t, opts.Run())
+
+		opts.OldKeyFile = oldKeyFile
+		assert.Nil(t, opts.Run())
+		stored, err := storage.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, newKey.ID(), stored.Encryption.KeyID)
+		assert.Equal(t, uint64(2), stored.Serial)
+	})
+
+	mockey.PatchConvey("mock state storage without encryption", t, func() {
+		mockey.Mock(project.DetectProjectAndStack).Return(&v1.Project{Name: "foo"}, &v1.Stack{Name: "dev"}, nil).Build()
+		mockey.Mock(backend.NewStateStorage).Return(storage, nil).Build()
+
+		err := (&Options{}).Run()
+		assert.ErrorIs(t, err, ErrEncryptionNotConfigured)
+	})
+}
@@ -0,0 +1,47 @@
+package rm
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Remove a resource from the state`)
+
+		long = i18n.T(`
+		This command removes a specified resource from the latest state of the current stack, while the actual resource is not deleted. It is useful when the resource is no longer managed by Kusion.`)
+
+		example = i18n.T(`
+		# Remove a resource from the state of current stack
+		kusion state rm v1:Namespace:default
+
+		# Remove a resource without confirmation
+		kusion state rm v1:Namespace:default --yes`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "rm",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", false,
+		i18n.T("Automatically approve and remove the resource without prompting"))
+
+	return cmd
+}
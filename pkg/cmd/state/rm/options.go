@@ -0,0 +1,74 @@
+package rm
+
+import (
+	"fmt"
+
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+)
+
+type Options struct {
+	util.Options
+	ID  string
+	Yes bool
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 1 {
+		return util.ErrNotOneArgs
+	}
+	o.ID = args[0]
+	return nil
+}
+
+func (o *Options) Validate() error {
+	if err := util.ValidateResourceID(o.ID); err != nil {
+		return err
+	}
+	return o.Options.Validate()
+}
+
+func (o *Options) Run() error {
+	storage, query, err := o.StateStorage()
+	if err != nil {
+		return err
+	}
+	state, err := util.GetLatestState(storage, query)
+	if err != nil {
+		return err
+	}
+	index := util.GetResourceIndex(state, o.ID)
+	if index == -1 {
+		return fmt.Errorf("resource %s not found in the state", o.ID)
+	}
+
+	if !o.Yes {
+		confirmed, err := util.Confirm(fmt.Sprintf("Do you want to remove resource %s from the state of stack %s?", o.ID, query.Stack))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("operation canceled")
+			return nil
+		}
+	}
+
+	for i, r := range state.Resources {
+		if i != index {
+			for _, d := range r.DependsOn {
+				if d == o.ID {
+					fmt.Printf("warning: resource %s depends on the removed resource %s\n", r.ID, o.ID)
+				}
+			}
+		}
+	}
+	state.Resources = append(state.Resources[:index], state.Resources[index+1:]...)
+	if err = util.ApplyState(storage, state); err != nil {
+		return err
+	}
+	fmt.Printf("remove resource %s from the state successfully\n", o.ID)
+	return nil
+}
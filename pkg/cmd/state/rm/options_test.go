@@ -0,0 +1,69 @@
+package rm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+)
+
+func mockStorage(t *testing.T) *local.FileSystemState {
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	state.Resources = v1.Resources{
+		{ID: "v1:Namespace:default", Type: v1.Kubernetes},
+		{ID: "v1:ConfigMap:default:foo", Type: v1.Kubernetes, DependsOn: []string{"v1:Namespace:default"}},
+	}
+	assert.NoError(t, storage.Apply(state))
+	return storage
+}
+
+func mockStateStorage(storage states.StateStorage) {
+	mockey.Mock((*util.Options).StateStorage).Return(storage, &states.StateQuery{Project: "foo", Stack: "dev"}, nil).Build()
+}
+
+func TestOptions_CompleteAndValidate(t *testing.T) {
+	opts := NewOptions()
+	assert.ErrorIs(t, opts.Complete([]string{"a", "b"}), util.ErrNotOneArgs)
+	assert.Nil(t, opts.Complete([]string{""}))
+	assert.ErrorIs(t, opts.Validate(), util.ErrEmptyResourceID)
+}
+
+func TestOptions_Run(t *testing.T) {
+	mockey.PatchConvey("remove not existed resource", t, func() {
+		mockStateStorage(mockStorage(t))
+		assert.Error(t, (&Options{ID: "v1:Namespace:foo", Yes: true}).Run())
+	})
+
+	mockey.PatchConvey("cancel removing resource", t, func() {
+		storage := mockStorage(t)
+		mockStateStorage(storage)
+		mockey.Mock(util.Confirm).Return(false, nil).Build()
+		assert.Nil(t, (&Options{ID: "v1:Namespace:default"}).Run())
+		state, err := storage.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Len(t, state.Resources, 2)
+		assert.Equal(t, uint64(1), state.Serial)
+	})
+
+	mockey.PatchConvey("remove resource", t, func() {
+		storage := mockStorage(t)
+		mockStateStorage(storage)
+		mockey.Mock(util.Confirm).Return(true, nil).Build()
+		assert.Nil(t, (&Options{ID: "v1:Namespace:default"}).Run())
+		state, err := storage.GetLatestState(nil)
+		assert.Nil(t, err)
+		assert.Len(t, state.Resources, 1)
+		assert.Equal(t, "v1:ConfigMap:default:foo", state.Resources[0].ID)
+		assert.Equal(t, uint64(2), state.Serial)
+	})
+}
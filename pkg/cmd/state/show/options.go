@@ -0,0 +1,54 @@
+package show
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+)
+
+type Options struct {
+	util.Options
+	ID string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) != 1 {
+		return util.ErrNotOneArgs
+	}
+	o.ID = args[0]
+	return nil
+}
+
+func (o *Options) Validate() error {
+	if err := util.ValidateResourceID(o.ID); err != nil {
+		return err
+	}
+	return o.Options.Validate()
+}
+
+func (o *Options) Run() error {
+	storage, query, err := o.StateStorage()
+	if err != nil {
+		return err
+	}
+	state, err := util.GetLatestState(storage, query)
+	if err != nil {
+		return err
+	}
+	index := util.GetResourceIndex(state, o.ID)
+	if index == -1 {
+		return fmt.Errorf("resource %s not found in the state", o.ID)
+	}
+	content, err := yaml.Marshal(state.Resources[index])
+	if err != nil {
+		return fmt.Errorf("yaml marshal resource failed: %w", err)
+	}
+	fmt.Print(string(content))
+	return nil
+}
@@ -0,0 +1,51 @@
+package show
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/state/util"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+)
+
+func mockStorage(t *testing.T) *local.FileSystemState {
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	state.Resources = v1.Resources{
+		{ID: "v1:Namespace:default", Type: v1.Kubernetes},
+		{ID: "v1:ConfigMap:default:foo", Type: v1.Kubernetes, DependsOn: []string{"v1:Namespace:default"}},
+	}
+	assert.NoError(t, storage.Apply(state))
+	return storage
+}
+
+func mockStateStorage(storage states.StateStorage) {
+	mockey.Mock((*util.Options).StateStorage).Return(storage, &states.StateQuery{Project: "foo", Stack: "dev"}, nil).Build()
+}
+
+func TestOptions_CompleteAndValidate(t *testing.T) {
+	opts := NewOptions()
+	assert.ErrorIs(t, opts.Complete(nil), util.ErrNotOneArgs)
+	assert.Nil(t, opts.Complete([]string{""}))
+	assert.ErrorIs(t, opts.Validate(), util.ErrEmptyResourceID)
+	assert.Nil(t, opts.Complete([]string{"v1:Namespace:default"}))
+	assert.Nil(t, opts.Validate())
+}
+
+func TestOptions_Run(t *testing.T) {
+	mockey.PatchConvey("mock state storage", t, func() {
+		mockStateStorage(mockStorage(t))
+		opts := &Options{ID: "v1:Namespace:default"}
+		assert.Nil(t, opts.Run())
+		opts.ID = "v1:Namespace:foo"
+		assert.Error(t, opts.Run())
+	})
+}
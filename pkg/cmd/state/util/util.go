@@ -0,0 +1,114 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"kusionstack.io/kusion/pkg/engine/backend"
+	_ "kusionstack.io/kusion/pkg/engine/backend/init"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/project"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+var (
+	ErrUnexpectedArgs  = errors.New("no args accepted")
+	ErrNotOneArgs      = errors.New("only one arg accepted")
+	ErrNotTwoArgs      = errors.New("only two args accepted")
+	ErrEmptyResourceID = errors.New("empty resource id")
+)
+
+// Options contains the flags to locate the state of the stack, which are shared by the state subcommands.
+type Options struct {
+	WorkDir string
+	backend.BackendOptions
+}
+
+// AddFlags adds the work directory and backend flags.
+func (o *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.WorkDir, "workdir", "w", "",
+		i18n.T("Specify the work directory"))
+	o.AddBackendFlags(cmd)
+}
+
+// Validate checks the backend options if specified.
+func (o *Options) Validate() error {
+	if !o.BackendOptions.IsEmpty() {
+		if err := o.BackendOptions.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StateStorage returns the state storage of the stack in the work directory, and the query to get its state.
+func (o *Options) StateStorage() (states.StateStorage, *states.StateQuery, error) {
+	project, stack, err := project.DetectProjectAndStack(o.WorkDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	storage, err := backend.NewStateStorage(stack, &o.BackendOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	query := &states.StateQuery{
+		Tenant:  "",
+		Stack:   stack.Name,
+		Project: project.Name,
+	}
+	return storage, query, nil
+}
+
+// GetLatestState gets the latest state, and returns error if the state does not exist.
+func GetLatestState(storage states.StateStorage, query *states.StateQuery) (*states.State, error) {
+	state, err := storage.GetLatestState(query)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no state found for stack %s of project %s", query.Stack, query.Project)
+	}
+	return state, nil
+}
+
+// ApplyState increases the serial of the state and applies it, every write of the state should use this
+// function.
+func ApplyState(storage states.StateStorage, state *states.State) error {
+	state.Serial += 1
+	return storage.Apply(state)
+}
+
+// GetResourceIndex returns the index of the resource with the id in the state, or -1 if not exists.
+func GetResourceIndex(state *states.State, id string) int {
+	for i := range state.Resources {
+		if state.Resources[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ValidateResourceID returns the resource id is valid or not.
+func ValidateResourceID(id string) error {
+	if id == "" {
+		return ErrEmptyResourceID
+	}
+	return nil
+}
+
+// Confirm prompts the message and returns whether the destructive change is confirmed.
+func Confirm(message string) (bool, error) {
+	prompt := &survey.Confirm{
+		Message: message,
+		Default: false,
+	}
+	var confirmed bool
+	if err := survey.AskOne(prompt, &confirmed); err != nil {
+		fmt.Printf("Prompt failed %v\n", err)
+		return false, err
+	}
+	return confirmed, nil
+}
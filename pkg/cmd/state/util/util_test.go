@@ -0,0 +1,63 @@
+package util
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/engine/backend"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+	"kusionstack.io/kusion/pkg/project"
+)
+
+func mockState() *states.State {
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	state.Resources = v1.Resources{
+		{ID: "v1:Namespace:default", Type: v1.Kubernetes},
+	}
+	return state
+}
+
+func TestOptions_StateStorage(t *testing.T) {
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	mockey.PatchConvey("mock project and state storage", t, func() {
+		mockey.Mock(project.DetectProjectAndStack).Return(&v1.Project{Name: "foo"}, &v1.Stack{Name: "dev"}, nil).Build()
+		mockey.Mock(backend.NewStateStorage).Return(storage, nil).Build()
+
+		s, query, err := (&Options{}).StateStorage()
+		assert.Nil(t, err)
+		assert.Equal(t, storage, s)
+		assert.Equal(t, &states.StateQuery{Project: "foo", Stack: "dev"}, query)
+	})
+}
+
+func TestGetLatestStateAndApplyState(t *testing.T) {
+	storage := &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+	query := &states.StateQuery{Project: "foo", Stack: "dev"}
+
+	_, err := GetLatestState(storage, query)
+	assert.Error(t, err)
+
+	assert.Nil(t, ApplyState(storage, mockState()))
+	state, err := GetLatestState(storage, query)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), state.Serial)
+}
+
+func TestGetResourceIndex(t *testing.T) {
+	state := mockState()
+	assert.Equal(t, 0, GetResourceIndex(state, "v1:Namespace:default"))
+	assert.Equal(t, -1, GetResourceIndex(state, "v1:Namespace:foo"))
+}
+
+func TestValidateResourceID(t *testing.T) {
+	assert.Nil(t, ValidateResourceID("v1:Namespace:default"))
+	assert.ErrorIs(t, ValidateResourceID(""), ErrEmptyResourceID)
+}
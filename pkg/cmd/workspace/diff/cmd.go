@@ -0,0 +1,44 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"kusionstack.io/kusion/pkg/cmd/util"
+	"kusionstack.io/kusion/pkg/util/i18n"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		short = i18n.T(`Show the differences between two workspace configurations`)
+
+		long = i18n.T(`
+		This command compares the effective configurations of two workspaces, and shows the differences of the modules, runtimes and secret store.`)
+
+		example = i18n.T(`
+		# Show the differences between workspace staging and prod
+		kusion workspace diff staging prod
+
+		# Show the differences in raw format
+		kusion workspace diff staging prod -o raw`)
+	)
+
+	o := NewOptions()
+	cmd := &cobra.Command{
+		Use:                   "diff",
+		Short:                 short,
+		Long:                  templates.LongDesc(long),
+		Example:               templates.Examples(example),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			util.CheckErr(o.Complete(args))
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, i18n.T("the output style of the differences, human or raw"))
+	return cmd
+}
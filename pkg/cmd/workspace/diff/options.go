@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"fmt"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/cmd/workspace/util"
+	"kusionstack.io/kusion/pkg/util/diff"
+	"kusionstack.io/kusion/pkg/workspace"
+)
+
+type Options struct {
+	From   string
+	To     string
+	Output string
+}
+
+func NewOptions() *Options {
+	return &Options{Output: diff.OutputHuman}
+}
+
+func (o *Options) Complete(args []string) error {
+	from, to, err := util.GetTwoNamesFromArgs(args)
+	if err != nil {
+		return err
+	}
+	o.From = from
+	o.To = to
+	return nil
+}
+
+func (o *Options) Validate() error {
+	if err := util.ValidateName(o.From); err != nil {
+		return err
+	}
+	if err := util.ValidateName(o.To); err != nil {
+		return err
+	}
+	if o.Output != diff.OutputHuman && o.Output != diff.OutputRaw {
+		return fmt.Errorf("invalid output style `%s`", o.Output)
+	}
+	return nil
+}
+
+func (o *Options) Run() error {
+	// the secret references are compared rather than the secret data, which should not be shown
+	from, err := workspace.GetEffectiveWorkspaceByDefaultOperator(o.From)
+	if err != nil {
+		return err
+	}
+	to, err := workspace.GetEffectiveWorkspaceByDefaultOperator(o.To)
+	if err != nil {
+		return err
+	}
+
+	report, err := diff.ToReport(diffContent(from.Workspace), diffContent(to.Workspace))
+	if err != nil {
+		return fmt.Errorf("compare workspace %s and %s failed: %w", o.From, o.To, err)
+	}
+	if len(report.Diffs) == 0 {
+		fmt.Printf("No differences between workspace %s and %s\n", o.From, o.To)
+		return nil
+	}
+	content, err := diff.ToReportString(diff.NewHumanReport(report), o.Output)
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
+
+// diffContent returns the compared configurations of the effective workspace, which are modules, runtimes and
+// secret store.
+func diffContent(ws *v1.Workspace) map[string]any {
+	content := make(map[string]any)
+	if ws.Modules != nil {
+		content["modules"] = ws.Modules
+	}
+	if ws.Runtimes != nil {
+		content["runtimes"] = ws.Runtimes
+	}
+	if ws.SecretStore != nil {
+		content["secretStore"] = ws.SecretStore
+	}
+	return content
+}
@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/workspace"
+)
+
+func TestOptions_Complete(t *testing.T) {
+	testcases := []struct {
+		name         string
+		args         []string
+		success      bool
+		expectedOpts *Options
+	}{
+		{
+			name:         "successfully complete options",
+			args:         []string{"staging", "prod"},
+			success:      true,
+			expectedOpts: &Options{From: "staging", To: "prod", Output: "human"},
+		},
+		{
+			name:         "complete field invalid args",
+			args:         []string{"staging"},
+			success:      false,
+			expectedOpts: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := NewOptions()
+			err := opts.Complete(tc.args)
+			assert.Equal(t, tc.success, err == nil)
+			if tc.success {
+				assert.True(t, reflect.DeepEqual(opts, tc.expectedOpts))
+			}
+		})
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name:    "valid options",
+			opts:    &Options{From: "staging", To: "prod", Output: "human"},
+			success: true,
+		},
+		{
+			name:    "invalid options empty name",
+			opts:    &Options{From: "staging", Output: "human"},
+			success: false,
+		},
+		{
+			name:    "invalid options unsupported output",
+			opts:    &Options{From: "staging", To: "prod", Output: "json"},
+			success: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			assert.Equal(t, tc.success, err == nil)
+		})
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	workspaces := map[string]*v1.Workspace{
+		"staging": {
+			Name: "staging",
+			Modules: v1.ModuleConfigs{
+				"database": {Default: v1.GenericConfig{"instanceType": "db.t3.micro"}},
+			},
+		},
+		"prod": {
+			Name: "prod",
+			Modules: v1.ModuleConfigs{
+				"database": {Default: v1.GenericConfig{"instanceType": "db.m5.large"}},
+			},
+			Runtimes: &v1.RuntimeConfigs{Kubernetes: &v1.KubernetesConfig{KubeConfig: "/etc/kubeconfig.yaml"}},
+		},
+	}
+	testcases := []struct {
+		name    string
+		opts    *Options
+		success bool
+	}{
+		{
+			name:    "successfully run",
+			opts:    &Options{From: "staging", To: "prod", Output: "human"},
+			success: true,
+		},
+		{
+			name:    "successfully run raw output",
+			opts:    &Options{From: "staging", To: "prod", Output: "raw"},
+			success: true,
+		},
+		{
+			name:    "successfully run no differences",
+			opts:    &Options{From: "prod", To: "prod", Output: "human"},
+			success: true,
+		},
+		{
+			name:    "failed to run not exist workspace",
+			opts:    &Options{From: "staging", To: "test", Output: "human"},
+			success: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock get workspace", t, func() {
+				mockey.Mock(workspace.GetEffectiveWorkspaceByDefaultOperator).To(
+					func(name string) (*workspace.EffectiveWorkspace, error) {
+						ws, ok := workspaces[name]
+						if !ok {
+							return nil, workspace.ErrWorkspaceNotExist
+						}
+						return &workspace.EffectiveWorkspace{Workspace: ws}, nil
+					}).Build()
+
+				err := tc.opts.Run()
+				assert.Equal(t, tc.success, err == nil)
+			})
+		})
+	}
+}
@@ -13,11 +13,17 @@ func NewCmd() *cobra.Command {
 		short = i18n.T(`Show a workspace configuration`)
 
 		long = i18n.T(`
-		This command gets a specified workspace configuration.`)
+		This command gets a specified workspace configuration.
+
+		The workspace configuration is shown as it is stored by default. If the workspace extends others, use
+		--effective to show the merged configuration, where the workspace each value comes from is commented.`)
 
 		example = i18n.T(`
 		# Show a workspace configuration
-		kusion workspace show dev`)
+		kusion workspace show dev
+
+		# Show the effective workspace configuration with the origin of each value
+		kusion workspace show dev --effective`)
 	)
 
 	o := NewOptions()
@@ -35,5 +41,7 @@ func NewCmd() *cobra.Command {
 			return
 		},
 	}
+
+	cmd.Flags().BoolVarP(&o.Effective, "effective", "", false, i18n.T("show the effective configuration merged with the extended workspaces"))
 	return cmd
 }
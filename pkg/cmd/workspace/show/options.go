@@ -10,7 +10,8 @@ import (
 )
 
 type Options struct {
-	Name string
+	Name      string
+	Effective bool
 }
 
 func NewOptions() *Options {
@@ -34,7 +35,10 @@ func (o *Options) Validate() error {
 }
 
 func (o *Options) Run() error {
-	ws, err := workspace.GetWorkspaceByDefaultOperator(o.Name)
+	if o.Effective {
+		return o.runEffective()
+	}
+	ws, err := workspace.GetRawWorkspaceByDefaultOperator(o.Name)
 	if err != nil {
 		return err
 	}
@@ -45,3 +49,16 @@ func (o *Options) Run() error {
 	fmt.Print(string(content))
 	return nil
 }
+
+func (o *Options) runEffective() error {
+	ws, err := workspace.GetEffectiveWorkspaceByDefaultOperator(o.Name)
+	if err != nil {
+		return err
+	}
+	content, err := ws.MarshalYAMLWithOrigins()
+	if err != nil {
+		return fmt.Errorf("yaml marshal effective workspace configuration failed: %w", err)
+	}
+	fmt.Print(string(content))
+	return nil
+}
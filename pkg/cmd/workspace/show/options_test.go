@@ -85,14 +85,25 @@ func TestOptions_Run(t *testing.T) {
 			},
 			success: true,
 		},
+		{
+			name: "successfully run effective",
+			opts: &Options{
+				Name:      "dev",
+				Effective: true,
+			},
+			success: true,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			mockey.PatchConvey("mock show workspace", t, func() {
-				mockey.Mock(workspace.GetWorkspaceByDefaultOperator).
+				mockey.Mock(workspace.GetRawWorkspaceByDefaultOperator).
 					Return(&v1.Workspace{Name: "dev"}, nil).
 					Build()
+				mockey.Mock(workspace.GetEffectiveWorkspaceByDefaultOperator).
+					Return(&workspace.EffectiveWorkspace{Workspace: &v1.Workspace{Name: "dev"}}, nil).
+					Build()
 
 				err := tc.opts.Run()
 				assert.Equal(t, tc.success, err == nil)
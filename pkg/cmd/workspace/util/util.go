@@ -8,11 +8,13 @@ import (
 	"gopkg.in/yaml.v3"
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/modules/schemas"
 	"kusionstack.io/kusion/pkg/workspace"
 )
 
 var (
 	ErrNotOneArgs    = errors.New("only one arg accepted")
+	ErrNotTwoArgs    = errors.New("only two args accepted")
 	ErrEmptyName     = errors.New("empty workspace name")
 	ErrEmptyFilePath = errors.New("empty configuration file path")
 )
@@ -25,6 +27,14 @@ func GetNameFromArgs(args []string) (string, error) {
 	return args[0], nil
 }
 
+// GetTwoNamesFromArgs returns the two workspace names specified by args.
+func GetTwoNamesFromArgs(args []string) (string, string, error) {
+	if len(args) != 2 {
+		return "", "", ErrNotTwoArgs
+	}
+	return args[0], args[1], nil
+}
+
 // ValidateName returns the workspace name is valid or not.
 func ValidateName(name string) error {
 	if name == "" {
@@ -57,5 +67,12 @@ func GetValidWorkspaceFromFile(filePath, name string) (*v1.Workspace, error) {
 	if err = workspace.ValidateWorkspace(ws); err != nil {
 		return nil, fmt.Errorf("invalid workspace configuration: %w", err)
 	}
+	// the module configs of the workspace which extends others can be partial, which are validated against the
+	// schemas after merged when building
+	if len(ws.Extends) == 0 {
+		if err = schemas.ValidateModuleConfigs(ws.Modules); err != nil {
+			return nil, fmt.Errorf("invalid module configs: %w", err)
+		}
+	}
 	return ws, nil
 }
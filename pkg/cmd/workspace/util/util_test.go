@@ -33,6 +33,12 @@ func TestGetValidWorkspaceFromFile(t *testing.T) {
 			wsName:   "invalid_ws",
 			success:  false,
 		},
+		{
+			name:     "failed to get workspace invalid module config",
+			filePath: testFilePath("invalid_module_ws.yaml"),
+			wsName:   "invalid_module_ws",
+			success:  false,
+		},
 		{
 			name:     "failed to get workspace not exist file",
 			filePath: testFilePath("not_exist_ws.yaml"),
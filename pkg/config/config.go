@@ -0,0 +1,316 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/config/validation"
+	"kusionstack.io/kusion/pkg/util/kfile"
+)
+
+var (
+	ErrEmptyConfigItemKey    = errors.New("empty config item key")
+	ErrEmptyConfigItemValue  = errors.New("empty config item value")
+	ErrUnsupportedConfigItem = errors.New("unsupported config item")
+	ErrNotSetConfigItem      = errors.New("config item is not set")
+)
+
+// configFile is the name of the kusion config file, which is under kfile.KusionDataFolder().
+const configFile = "config.yaml"
+
+// GetConfigFilePath returns the path of the kusion config file.
+func GetConfigFilePath() (string, error) {
+	kusionDataDir, err := kfile.KusionDataFolder()
+	if err != nil {
+		return "", fmt.Errorf("get kusion data folder failed, %w", err)
+	}
+	return filepath.Join(kusionDataDir, configFile), nil
+}
+
+// GetConfig returns the structured and validated kusion config. If the config file does not exist, an
+// empty config is returned.
+func GetConfig() (*v1.Config, error) {
+	path, err := GetConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &v1.Config{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read config file %s failed, %w", path, err)
+	}
+
+	config := &v1.Config{}
+	if err = yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("yaml unmarshal config file %s failed, %w", path, err)
+	}
+	if err = validation.ValidateConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// GetCurrentBackend returns the name and config of the current backend. If the current backend is not
+// set, the returned name is empty and the config is nil.
+func GetCurrentBackend(config *v1.Config) (string, *v1.BackendConfig, error) {
+	if config == nil || config.Backends == nil || config.Backends.Current == "" {
+		return "", nil, nil
+	}
+	name := config.Backends.Current
+	backendConfig := config.Backends.Backends[name]
+	if backendConfig == nil {
+		return "", nil, fmt.Errorf("%w, current backend %s does not exist", validation.ErrUnexpectedInvalidConfig, name)
+	}
+	return name, backendConfig, nil
+}
+
+// GetEncodedConfig returns the whole kusion config in yaml format.
+func GetEncodedConfig() (string, error) {
+	config, err := GetConfig()
+	if err != nil {
+		return "", err
+	}
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("yaml marshal config failed, %w", err)
+	}
+	return string(content), nil
+}
+
+// GetEncodedConfigItem returns the value of the config item specified by the dotted key, such as
+// "backends.dev.configs.bucket". The string and int values are returned as they are, while the others
+// are returned in json format.
+func GetEncodedConfigItem(key string) (string, error) {
+	if _, err := checkConfigItemKey(key); err != nil {
+		return "", err
+	}
+	config, err := GetConfig()
+	if err != nil {
+		return "", err
+	}
+	m, err := toGenericMap(config)
+	if err != nil {
+		return "", err
+	}
+	val, ok := getItem(m, strings.Split(key, "."))
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotSetConfigItem, key)
+	}
+
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	default:
+		content, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("json marshal config item %s failed, %w", key, err)
+		}
+		return string(content), nil
+	}
+}
+
+// SetEncodedConfigItem sets the value of the config item specified by the dotted key. The value is decoded
+// according to the type of the config item, where the value of structured config item is in json or yaml
+// format. The registered validation is done before writing the config file.
+func SetEncodedConfigItem(key, value string) error {
+	info, err := checkConfigItemKey(key)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return ErrEmptyConfigItemValue
+	}
+	val, err := decodeItemValue(info.zeroValue, value)
+	if err != nil {
+		return fmt.Errorf("invalid value of config item %s, %w", key, err)
+	}
+
+	config, err := GetConfig()
+	if err != nil {
+		return err
+	}
+	if info.validateFunc != nil {
+		if err = info.validateFunc(config, key, val); err != nil {
+			return fmt.Errorf("invalid config item %s, %w", key, err)
+		}
+	}
+	m, err := toGenericMap(config)
+	if err != nil {
+		return err
+	}
+	genericVal, err := toGeneric(val)
+	if err != nil {
+		return err
+	}
+	setItem(m, strings.Split(key, "."), genericVal)
+	return writeGenericMap(m)
+}
+
+// DeleteConfigItem deletes the config item specified by the dotted key. The registered validation is done
+// before writing the config file.
+func DeleteConfigItem(key string) error {
+	info, err := checkConfigItemKey(key)
+	if err != nil {
+		return err
+	}
+	config, err := GetConfig()
+	if err != nil {
+		return err
+	}
+	if info.validateUnsetFunc != nil {
+		if err = info.validateUnsetFunc(config, key); err != nil {
+			return fmt.Errorf("invalid unset config item %s, %w", key, err)
+		}
+	}
+	m, err := toGenericMap(config)
+	if err != nil {
+		return err
+	}
+	deleteItem(m, strings.Split(key, "."))
+	return writeGenericMap(m)
+}
+
+// checkConfigItemKey checks the key is supported and returns its registered information.
+func checkConfigItemKey(key string) (*itemInfo, error) {
+	if key == "" {
+		return nil, ErrEmptyConfigItemKey
+	}
+	info := getItemInfo(key)
+	if info == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedConfigItem, key)
+	}
+	return info, nil
+}
+
+// decodeItemValue decodes the value to the type of zeroValue.
+func decodeItemValue(zeroValue any, value string) (any, error) {
+	switch zeroValue.(type) {
+	case string:
+		return value, nil
+	case int:
+		return strconv.Atoi(value)
+	}
+
+	typ := reflect.TypeOf(zeroValue)
+	isPtr := typ.Kind() == reflect.Ptr
+	if isPtr {
+		typ = typ.Elem()
+	}
+	ptr := reflect.New(typ)
+	decoder := yaml.NewDecoder(bytes.NewBufferString(value))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(ptr.Interface()); err != nil {
+		return nil, err
+	}
+	if isPtr {
+		return ptr.Interface(), nil
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// toGeneric converts the value to the generic value composed of map[string]any, []any and scalars.
+func toGeneric(val any) (any, error) {
+	content, err := yaml.Marshal(val)
+	if err != nil {
+		return nil, fmt.Errorf("yaml marshal failed, %w", err)
+	}
+	var generic any
+	if err = yaml.Unmarshal(content, &generic); err != nil {
+		return nil, fmt.Errorf("yaml unmarshal failed, %w", err)
+	}
+	return generic, nil
+}
+
+// toGenericMap converts the config to the generic map.
+func toGenericMap(config *v1.Config) (map[string]any, error) {
+	generic, err := toGeneric(config)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := generic.(map[string]any)
+	if !ok {
+		m = make(map[string]any)
+	}
+	return m, nil
+}
+
+// writeGenericMap converts the generic map to config, and writes the config file after validation.
+func writeGenericMap(m map[string]any) error {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("yaml marshal config failed, %w", err)
+	}
+	config := &v1.Config{}
+	if err = yaml.Unmarshal(content, config); err != nil {
+		return fmt.Errorf("yaml unmarshal config failed, %w", err)
+	}
+	if err = validation.ValidateConfig(config); err != nil {
+		return err
+	}
+	if content, err = yaml.Marshal(config); err != nil {
+		return fmt.Errorf("yaml marshal config failed, %w", err)
+	}
+
+	path, err := GetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(path, content, 0o640); err != nil {
+		return fmt.Errorf("write config file %s failed, %w", path, err)
+	}
+	return nil
+}
+
+func getItem(m map[string]any, fields []string) (any, bool) {
+	val, ok := m[fields[0]]
+	if !ok || len(fields) == 1 {
+		return val, ok
+	}
+	next, ok := val.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return getItem(next, fields[1:])
+}
+
+func setItem(m map[string]any, fields []string, val any) {
+	if len(fields) == 1 {
+		m[fields[0]] = val
+		return
+	}
+	next, ok := m[fields[0]].(map[string]any)
+	if !ok {
+		next = make(map[string]any)
+		m[fields[0]] = next
+	}
+	setItem(next, fields[1:], val)
+}
+
+// deleteItem deletes the item, and the parent items which become empty after deleting.
+func deleteItem(m map[string]any, fields []string) {
+	if len(fields) == 1 {
+		delete(m, fields[0])
+		return
+	}
+	next, ok := m[fields[0]].(map[string]any)
+	if !ok {
+		return
+	}
+	deleteItem(next, fields[1:])
+	if len(next) == 0 {
+		delete(m, fields[0])
+	}
+}
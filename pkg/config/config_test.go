@@ -0,0 +1,343 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/util/kfile"
+)
+
+func TestGetConfig(t *testing.T) {
+	testcases := []struct {
+		name           string
+		success        bool
+		content        string
+		expectedConfig *v1.Config
+	}{
+		{
+			name:           "not exist config file",
+			success:        true,
+			expectedConfig: &v1.Config{},
+		},
+		{
+			name:    "valid config file",
+			success: true,
+			content: `
+backends:
+  current: dev
+  dev:
+    type: mysql
+    configs:
+      dbName: kusion
+      user: kusion
+      host: 127.0.0.1
+      port: 3306
+`,
+			expectedConfig: &v1.Config{
+				Backends: &v1.BackendConfigs{
+					Current: "dev",
+					Backends: map[string]*v1.BackendConfig{
+						"dev": {
+							Type: v1.BackendTypeMysql,
+							Configs: map[string]any{
+								v1.BackendMysqlDBName: "kusion",
+								v1.BackendMysqlUser:   "kusion",
+								v1.BackendMysqlHost:   "127.0.0.1",
+								v1.BackendMysqlPort:   3306,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid config file unsupported backend type",
+			success: false,
+			content: `
+backends:
+  dev:
+    type: not-support
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock kusion data folder", t, func() {
+				dir := t.TempDir()
+				mockey.Mock(kfile.KusionDataFolder).Return(dir, nil).Build()
+				if tc.content != "" {
+					assert.NoError(t, os.WriteFile(filepath.Join(dir, configFile), []byte(tc.content), 0o640))
+				}
+
+				config, err := GetConfig()
+				assert.Equal(t, tc.success, err == nil)
+				if tc.success {
+					assert.Equal(t, tc.expectedConfig, config)
+				}
+			})
+		})
+	}
+}
+
+func TestGetCurrentBackend(t *testing.T) {
+	backendConfig := &v1.BackendConfig{Type: v1.BackendTypeLocal}
+	name, config, err := GetCurrentBackend(&v1.Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", name)
+	assert.Nil(t, config)
+
+	name, config, err = GetCurrentBackend(&v1.Config{Backends: &v1.BackendConfigs{
+		Current:  "dev",
+		Backends: map[string]*v1.BackendConfig{"dev": backendConfig},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", name)
+	assert.Equal(t, backendConfig, config)
+
+	_, _, err = GetCurrentBackend(&v1.Config{Backends: &v1.BackendConfigs{Current: "dev"}})
+	assert.Error(t, err)
+}
+
+func mockConfigFile(t *testing.T, content string) {
+	dir := t.TempDir()
+	mockey.Mock(kfile.KusionDataFolder).Return(dir, nil).Build()
+	if content != "" {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, configFile), []byte(content), 0o640))
+	}
+}
+
+const mockConfigContent = `
+backends:
+  current: dev
+  dev:
+    type: mysql
+    configs:
+      dbName: kusion
+      user: kusion
+      host: 127.0.0.1
+      port: 3306
+  prod:
+    type: s3
+    configs:
+      bucket: kusion
+`
+
+func TestGetEncodedConfigItem(t *testing.T) {
+	testcases := []struct {
+		name          string
+		success       bool
+		key           string
+		expectedValue string
+	}{
+		{
+			name:          "get string item",
+			success:       true,
+			key:           "backends.current",
+			expectedValue: "dev",
+		},
+		{
+			name:          "get int item",
+			success:       true,
+			key:           "backends.dev.configs.port",
+			expectedValue: "3306",
+		},
+		{
+			name:          "get structured item",
+			success:       true,
+			key:           "backends.prod",
+			expectedValue: `{"configs":{"bucket":"kusion"},"type":"s3"}`,
+		},
+		{
+			name:    "failed to get not set item",
+			success: false,
+			key:     "backends.prod.configs.region",
+		},
+		{
+			name:    "failed to get unsupported item",
+			success: false,
+			key:     "backends.prod.configs.not-support",
+		},
+		{
+			name:    "failed to get empty key",
+			success: false,
+			key:     "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock config file", t, func() {
+				mockConfigFile(t, mockConfigContent)
+
+				value, err := GetEncodedConfigItem(tc.key)
+				assert.Equal(t, tc.success, err == nil)
+				assert.Equal(t, tc.expectedValue, value)
+			})
+		})
+	}
+}
+
+func TestSetEncodedConfigItem(t *testing.T) {
+	testcases := []struct {
+		name          string
+		success       bool
+		key           string
+		value         string
+		expectedValue string
+	}{
+		{
+			name:          "set current backend",
+			success:       true,
+			key:           "backends.current",
+			value:         "prod",
+			expectedValue: "prod",
+		},
+		{
+			name:          "set int item",
+			success:       true,
+			key:           "backends.dev.configs.port",
+			value:         "3307",
+			expectedValue: "3307",
+		},
+		{
+			name:          "set item of new backend",
+			success:       true,
+			key:           "backends.pre.type",
+			value:         "local",
+			expectedValue: "local",
+		},
+		{
+			name:          "set structured item",
+			success:       true,
+			key:           "backends.pre",
+			value:         `{"type":"oss","configs":{"bucket":"kusion","endpoint":"oss.aliyuncs.com"}}`,
+			expectedValue: `{"configs":{"bucket":"kusion","endpoint":"oss.aliyuncs.com"},"type":"oss"}`,
+		},
+		{
+			name:          "set encryption item",
+			success:       true,
+			key:           "backends.prod.configs.encryptionKeyEnv",
+			value:         "KUSION_STATE_KEY",
+			expectedValue: "KUSION_STATE_KEY",
+		},
+		{
+			name:    "failed to set not exist current backend",
+			success: false,
+			key:     "backends.current",
+			value:   "pre",
+		},
+		{
+			name:    "failed to set invalid port",
+			success: false,
+			key:     "backends.dev.configs.port",
+			value:   "not-int",
+		},
+		{
+			name:    "failed to set conflict backend type item",
+			success: false,
+			key:     "backends.dev.configs.bucket",
+			value:   "kusion",
+		},
+		{
+			name:    "failed to set unknown field of structured item",
+			success: false,
+			key:     "backends.pre",
+			value:   `{"type":"oss","unknown":"kusion"}`,
+		},
+		{
+			name:    "failed to set empty value",
+			success: false,
+			key:     "backends.current",
+			value:   "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock config file", t, func() {
+				mockConfigFile(t, mockConfigContent)
+
+				err := SetEncodedConfigItem(tc.key, tc.value)
+				assert.Equal(t, tc.success, err == nil)
+				if tc.success {
+					value, err := GetEncodedConfigItem(tc.key)
+					assert.NoError(t, err)
+					assert.Equal(t, tc.expectedValue, value)
+				}
+			})
+		})
+	}
+}
+
+func TestDeleteConfigItem(t *testing.T) {
+	testcases := []struct {
+		name    string
+		success bool
+		key     string
+	}{
+		{
+			name:    "unset config item",
+			success: true,
+			key:     "backends.dev.configs.port",
+		},
+		{
+			name:    "unset not current backend",
+			success: true,
+			key:     "backends.prod",
+		},
+		{
+			name:    "unset not set config item",
+			success: true,
+			key:     "backends.prod.configs.region",
+		},
+		{
+			name:    "failed to unset current backend",
+			success: false,
+			key:     "backends.dev",
+		},
+		{
+			name:    "failed to unset backend type with config items",
+			success: false,
+			key:     "backends.prod.type",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock config file", t, func() {
+				mockConfigFile(t, mockConfigContent)
+
+				err := DeleteConfigItem(tc.key)
+				assert.Equal(t, tc.success, err == nil)
+				if tc.success {
+					_, err = GetEncodedConfigItem(tc.key)
+					assert.ErrorIs(t, err, ErrNotSetConfigItem)
+				}
+			})
+		})
+	}
+}
+
+func TestGetEncodedConfig(t *testing.T) {
+	mockey.PatchConvey("mock config file", t, func() {
+		mockConfigFile(t, "")
+		assert.NoError(t, SetEncodedConfigItem("backends.dev.type", "local"))
+		assert.NoError(t, SetEncodedConfigItem("backends.current", "dev"))
+
+		content, err := GetEncodedConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "backends:\n    current: dev\n    dev:\n        type: local\n", content)
+
+		// unsetting the last item removes the empty backend
+		assert.NoError(t, SetEncodedConfigItem("backends.pre.type", "s3"))
+		assert.NoError(t, DeleteConfigItem("backends.pre.type"))
+		_, err = GetEncodedConfigItem("backends.pre")
+		assert.ErrorIs(t, err, ErrNotSetConfigItem)
+	})
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/config/validation"
+)
+
+// wildcard is the key segment which matches any backend name.
+const wildcard = "*"
+
+// itemInfo contains the information of a registered config item.
+type itemInfo struct {
+	// zeroValue is the zero value of the config item, which decides how to decode the item value from string.
+	zeroValue any
+
+	// validateFunc is used to check that setting the config item is valid or not, nil means no check.
+	validateFunc validation.ValidateFunc
+
+	// validateUnsetFunc is used to check that unsetting the config item is valid or not, nil means no check.
+	validateUnsetFunc validation.ValidateUnsetFunc
+}
+
+// registeredItems are all the supported config items, whose key is the dotted path of the config item, where
+// the backend name is represented as wildcard.
+var registeredItems = newRegisteredItems()
+
+func newRegisteredItems() map[string]*itemInfo {
+	backendKey := v1.ConfigBackends + "." + wildcard
+	configItemsKey := backendKey + "." + v1.BackendConfigItems
+	configItemKey := func(item string) string {
+		return configItemsKey + "." + item
+	}
+
+	return map[string]*itemInfo{
+		v1.ConfigBackends: {&v1.BackendConfigs{}, nil, nil},
+		v1.ConfigBackends + "." + v1.BackendCurrent: {"", validation.ValidateCurrentBackend, nil},
+		backendKey:                                  {&v1.BackendConfig{}, validation.ValidateBackendConfig, validation.ValidateUnsetBackendConfig},
+		backendKey + "." + v1.BackendType:           {"", validation.ValidateBackendType, validation.ValidateUnsetBackendType},
+		configItemsKey:                              {map[string]any{}, validation.ValidateBackendConfigItems, nil},
+		configItemKey(v1.BackendLocalPath):          {"", validation.ValidateLocalBackendItem, nil},
+		configItemKey(v1.BackendMysqlDBName):        {"", validation.ValidateMysqlBackendItem, nil},
+		configItemKey(v1.BackendMysqlUser):          {"", validation.ValidateMysqlBackendItem, nil},
+		configItemKey(v1.BackendMysqlPassword):      {"", validation.ValidateMysqlBackendItem, nil},
+		configItemKey(v1.BackendMysqlHost):          {"", validation.ValidateMysqlBackendItem, nil},
+		configItemKey(v1.BackendMysqlPort):          {0, validation.ValidateMysqlBackendPort, nil},
+		configItemKey(v1.BackendGenericOssEndpoint): {"", validation.ValidateGenericOssBackendItem, nil},
+		configItemKey(v1.BackendGenericOssAK):       {"", validation.ValidateGenericOssBackendItem, nil},
+		configItemKey(v1.BackendGenericOssSK):       {"", validation.ValidateGenericOssBackendItem, nil},
+		configItemKey(v1.BackendGenericOssBucket):   {"", validation.ValidateGenericOssBackendItem, nil},
+		configItemKey(v1.BackendGenericOssPrefix):   {"", validation.ValidateGenericOssBackendItem, nil},
+		configItemKey(v1.BackendS3Region):           {"", validation.ValidateS3BackendItem, nil},
+		configItemKey(v1.BackendHttpURLPrefix):      {"", validation.ValidateHttpBackendItem, nil},
+		configItemKey(v1.BackendHttpToken):          {"", validation.ValidateHttpBackendItem, nil},
+
+		configItemKey(v1.BackendEncryptionKeyFile):         {"", validation.ValidateBackendEncryptionItem, nil},
+		configItemKey(v1.BackendEncryptionKeyEnv):          {"", validation.ValidateBackendEncryptionItem, nil},
+		configItemKey(v1.BackendEncryptionAgeRecipients):   {"", validation.ValidateBackendEncryptionItem, nil},
+		configItemKey(v1.BackendEncryptionAgeIdentityFile): {"", validation.ValidateBackendEncryptionItem, nil},
+	}
+}
+
+// getItemInfo returns the registered information of the config item, or nil if the key is not supported.
+// The exact registered key takes precedence over the key with wildcard, e.g. "backends.current".
+func getItemInfo(key string) *itemInfo {
+	if info, ok := registeredItems[key]; ok {
+		return info
+	}
+	fields := strings.Split(key, ".")
+	if len(fields) < 2 || fields[0] != v1.ConfigBackends || fields[1] == "" || fields[1] == v1.BackendCurrent {
+		return nil
+	}
+	fields[1] = wildcard
+	return registeredItems[strings.Join(fields, ".")]
+}
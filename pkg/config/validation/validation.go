@@ -87,7 +87,7 @@ func ValidateUnsetBackendConfig(config *v1.Config, key string) error {
 // ValidateBackendType is used to check that setting the backend type is valid or not.
 func ValidateBackendType(config *v1.Config, key string, val any) error {
 	backendType, _ := val.(string)
-	if backendType != v1.BackendTypeLocal && backendType != v1.BackendTypeMysql && backendType != v1.BackendTypeOss && backendType != v1.BackendTypeS3 {
+	if backendType != v1.BackendTypeLocal && backendType != v1.BackendTypeMysql && backendType != v1.BackendTypeOss && backendType != v1.BackendTypeS3 && backendType != v1.BackendTypeHttp {
 		return ErrUnsupportedBackendType
 	}
 
@@ -162,6 +162,17 @@ func ValidateS3BackendItem(config *v1.Config, key string, _ any) error {
 	return checkBackendTypeForBackendItem(config, key, v1.BackendTypeS3)
 }
 
+// ValidateHttpBackendItem is used to check that setting the config item of http-type backend is valid or not.
+func ValidateHttpBackendItem(config *v1.Config, key string, _ any) error {
+	return checkBackendTypeForBackendItem(config, key, v1.BackendTypeHttp)
+}
+
+// ValidateBackendEncryptionItem is used to check that setting the state encryption config item, which is supported
+// by all types of backend, is valid or not.
+func ValidateBackendEncryptionItem(config *v1.Config, key string, _ any) error {
+	return checkBackendTypeForBackendItem(config, key, v1.BackendTypeLocal, v1.BackendTypeMysql, v1.BackendTypeOss, v1.BackendTypeS3, v1.BackendTypeHttp)
+}
+
 // validateBackendConfig is used to check that setting the backend config is valid or not, which is called
 // ValidateBackendConfig and ValidateBackendConfigItems.
 func validateBackendConfig(backendConfig *v1.BackendConfig) error {
@@ -185,6 +196,11 @@ func validateBackendConfig(backendConfig *v1.BackendConfig) error {
 		if err := ValidateGenericObjectStorageConfig(ossBackend.GenericBackendObjectStorageConfig); err != nil {
 			return err
 		}
+	case v1.BackendTypeHttp:
+		httpBackend := backendConfig.ToHttpBackend()
+		if err := ValidateHttpConfig(httpBackend); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -234,16 +250,35 @@ func validateBasalBackendConfig(backendConfig *v1.BackendConfig) error {
 		if err := checkBasalBackendConfigItems(backendConfig, items); err != nil {
 			return err
 		}
+	case v1.BackendTypeHttp:
+		items := map[string]checkTypeFunc{
+			v1.BackendHttpURLPrefix: checkString,
+			v1.BackendHttpToken:     checkString,
+		}
+		if err := checkBasalBackendConfigItems(backendConfig, items); err != nil {
+			return err
+		}
 	default:
 		return ErrUnsupportedBackendType
 	}
 	return nil
 }
 
+// encryptionItems are the state encryption config items, which are supported by all types of backend.
+var encryptionItems = map[string]checkTypeFunc{
+	v1.BackendEncryptionKeyFile:         checkString,
+	v1.BackendEncryptionKeyEnv:          checkString,
+	v1.BackendEncryptionAgeRecipients:   checkString,
+	v1.BackendEncryptionAgeIdentityFile: checkString,
+}
+
 // checkBasalBackendConfigItems is used to check type of the backend config and whether it's the supported item.
 func checkBasalBackendConfigItems(backend *v1.BackendConfig, items map[string]checkTypeFunc) error {
 	for configItem, configValue := range backend.Configs {
 		checkType, ok := items[configItem]
+		if !ok {
+			checkType, ok = encryptionItems[configItem]
+		}
 		if !ok {
 			return fmt.Errorf("do not support %s for backend with type %s", configItem, backend.Type)
 		}
@@ -323,6 +358,7 @@ var (
 	ErrEmptyMysqlHost   = errors.New("empty mysql host")
 	ErrInvalidMysqlPort = errors.New("mysql port must be between 1 and 65535")
 	ErrEmptyBucket      = errors.New("empty bucket")
+	ErrEmptyURLPrefix   = errors.New("empty url prefix")
 )
 
 // ValidateMysqlConfig is used to validate v1.BackendMysqlConfig is valid or not.
@@ -350,3 +386,11 @@ func ValidateGenericObjectStorageConfig(config *v1.GenericBackendObjectStorageCo
 	}
 	return nil
 }
+
+// ValidateHttpConfig is used to validate v1.BackendHttpConfig is valid or not.
+func ValidateHttpConfig(config *v1.BackendHttpConfig) error {
+	if config.URLPrefix == "" {
+		return ErrEmptyURLPrefix
+	}
+	return nil
+}
@@ -168,6 +168,27 @@ func TestValidateBackendConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid http backend",
+			success: true,
+			val: &v1.BackendConfig{
+				Type: v1.BackendTypeHttp,
+				Configs: map[string]any{
+					v1.BackendHttpURLPrefix: "https://kusion.example.com",
+					v1.BackendHttpToken:     "kusion-token",
+				},
+			},
+		},
+		{
+			name:    "invalid http backend empty url prefix",
+			success: false,
+			val: &v1.BackendConfig{
+				Type: v1.BackendTypeHttp,
+				Configs: map[string]any{
+					v1.BackendHttpToken: "kusion-token",
+				},
+			},
+		},
 		{
 			name:    "invalid backend config invalid backend type",
 			success: false,
@@ -3,13 +3,17 @@ package backend
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	"github.com/zclconf/go-cty/cty/gocty"
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	backendinit "kusionstack.io/kusion/pkg/engine/backend/init"
 	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/encrypted"
 	"kusionstack.io/kusion/pkg/engine/states/local"
+	httpstate "kusionstack.io/kusion/pkg/engine/states/remote/http"
+	"kusionstack.io/kusion/pkg/engine/states/remote/sqlite"
 	"kusionstack.io/kusion/pkg/workspace"
 )
 
@@ -21,8 +25,22 @@ type StateStorageConfig struct {
 
 // NewConfig news a StateStorageConfig from workspace DeprecatedBackendConfigs, BackendOptions and environment variables.
 func NewConfig(workDir string, configs *v1.DeprecatedBackendConfigs, opts *BackendOptions) (*StateStorageConfig, error) {
-	var config, overrideConfig *StateStorageConfig
-	config = convertWorkspaceBackendConfig(workDir, configs)
+	return newConfig(convertWorkspaceBackendConfig(workDir, configs), opts)
+}
+
+// NewConfigFromBackendConfig news a StateStorageConfig from the backend config of kusion config, BackendOptions
+// and environment variables.
+func NewConfigFromBackendConfig(workDir string, backendConfig *v1.BackendConfig, opts *BackendOptions) (*StateStorageConfig, error) {
+	config, err := convertBackendConfig(workDir, backendConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newConfig(config, opts)
+}
+
+// newConfig merges the config with the cli backend options and environment variables.
+func newConfig(config *StateStorageConfig, opts *BackendOptions) (*StateStorageConfig, error) {
+	var overrideConfig *StateStorageConfig
 	if opts != nil && !opts.IsEmpty() {
 		var err error
 		if overrideConfig, err = opts.toStateStorageConfig(); err != nil {
@@ -48,15 +66,17 @@ func NewDefaultStateStorageConfig(workDir string) *StateStorageConfig {
 	}
 }
 
-// NewStateStorage news a StateStorage using the StateStorageConfig.
+// NewStateStorage news a StateStorage using the StateStorageConfig. If the encryption config items are set,
+// the StateStorage is wrapped to store the State encrypted.
 func (c *StateStorageConfig) NewStateStorage() (states.StateStorage, error) {
 	backendFunc := backendinit.GetBackend(c.Type)
 	if backendFunc == nil {
 		return nil, fmt.Errorf("do not support state backend type %s", c.Type)
 	}
+	backendConfig, encryptionConfig := encrypted.SplitConfig(c.Config)
 	bf := backendFunc()
 	backendSchema := bf.ConfigSchema()
-	ctyBackend, err := gocty.ToCtyValue(c.Config, backendSchema)
+	ctyBackend, err := gocty.ToCtyValue(backendConfig, backendSchema)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +84,146 @@ func (c *StateStorageConfig) NewStateStorage() (states.StateStorage, error) {
 	if err != nil {
 		return nil, err
 	}
-	return bf.StateStorage(), nil
+	return encrypted.NewStateStorage(bf.StateStorage(), encryptionConfig)
+}
+
+// ToWorkspaceBackendConfigs converts the StateStorageConfig to the workspace DeprecatedBackendConfigs, which is
+// the reverse of convertWorkspaceBackendConfig. The encryption config items are not supported by the workspace,
+// and are ignored.
+func (c *StateStorageConfig) ToWorkspaceBackendConfigs() (*v1.DeprecatedBackendConfigs, error) {
+	config, _ := encrypted.SplitConfig(c.Config)
+	getString := func(key string) string {
+		if v, ok := config[key]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+	objectStorageConfig := v1.GenericObjectStorageConfig{
+		Endpoint:        getString(v1.BackendGenericOssEndpoint),
+		AccessKeyID:     getString(v1.BackendGenericOssAK),
+		AccessKeySecret: getString(v1.BackendGenericOssSK),
+		Bucket:          getString(v1.BackendGenericOssBucket),
+	}
+
+	configs := &v1.DeprecatedBackendConfigs{}
+	switch c.Type {
+	case v1.DeprecatedBackendLocal:
+		configs.Local = &v1.DeprecatedLocalFileConfig{}
+	case v1.DeprecatedBackendMysql:
+		configs.Mysql = &v1.DeprecatedMysqlConfig{
+			DBName:   getString(v1.BackendMysqlDBName),
+			User:     getString(v1.BackendMysqlUser),
+			Password: getString(v1.BackendMysqlPassword),
+			Host:     getString(v1.BackendMysqlHost),
+		}
+		if portStr := getString(v1.BackendMysqlPort); portStr != "" {
+			port, err := strconv.Atoi(portStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mysql port %s, %w", portStr, err)
+			}
+			configs.Mysql.Port = &port
+		}
+		workspace.CompleteMysqlConfig(configs.Mysql)
+	case v1.DeprecatedBackendPostgres:
+		configs.Postgres = &v1.DeprecatedPostgresConfig{
+			DBName:   getString(v1.BackendMysqlDBName),
+			User:     getString(v1.BackendMysqlUser),
+			Password: getString(v1.BackendMysqlPassword),
+			Host:     getString(v1.BackendMysqlHost),
+			SSLMode:  getString(v1.BackendPostgresSSLMode),
+		}
+		if portStr := getString(v1.BackendMysqlPort); portStr != "" {
+			port, err := strconv.Atoi(portStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid postgres port %s, %w", portStr, err)
+			}
+			configs.Postgres.Port = &port
+		}
+		workspace.CompletePostgresConfig(configs.Postgres)
+	case v1.DeprecatedBackendSqlite:
+		configs.Sqlite = &v1.DeprecatedSqliteConfig{
+			Path: getString(v1.BackendLocalPath),
+		}
+	case v1.DeprecatedBackendOss:
+		configs.Oss = &v1.DeprecatedOssConfig{GenericObjectStorageConfig: objectStorageConfig}
+	case v1.DeprecatedBackendS3:
+		configs.S3 = &v1.DeprecatedS3Config{
+			GenericObjectStorageConfig: objectStorageConfig,
+			Region:                     getString(v1.BackendS3Region),
+		}
+	default:
+		return nil, fmt.Errorf("do not support state backend type %s in workspace", c.Type)
+	}
+	if err := workspace.ValidateBackendConfigs(configs); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// convertBackendConfig converts the backend config of kusion config to StateStorageConfig. The local backend
+// keeps storing the state file under the stack directory, and the state encryption config items are supported
+// by all types of backend.
+func convertBackendConfig(workDir string, backendConfig *v1.BackendConfig) (*StateStorageConfig, error) {
+	var config map[string]any
+	switch backendConfig.Type {
+	case v1.BackendTypeLocal:
+		config = NewDefaultStateStorageConfig(workDir).Config
+	case v1.BackendTypeMysql:
+		mysqlConfig := backendConfig.ToMysqlBackend()
+		port := mysqlConfig.Port
+		if port == 0 {
+			port = v1.DefaultMysqlPort
+		}
+		config = map[string]any{
+			"dbName":   mysqlConfig.DBName,
+			"user":     mysqlConfig.User,
+			"password": mysqlConfig.Password,
+			"host":     mysqlConfig.Host,
+			"port":     port,
+		}
+	case v1.BackendTypeOss:
+		ossConfig := backendConfig.ToOssBackend()
+		config = map[string]any{
+			"endpoint":        ossConfig.Endpoint,
+			"bucket":          ossConfig.Bucket,
+			"accessKeyID":     ossConfig.AccessKeyID,
+			"accessKeySecret": ossConfig.AccessKeySecret,
+		}
+	case v1.BackendTypeS3:
+		s3Config := backendConfig.ToS3Backend()
+		config = map[string]any{
+			"endpoint":        s3Config.Endpoint,
+			"bucket":          s3Config.Bucket,
+			"accessKeyID":     s3Config.AccessKeyID,
+			"accessKeySecret": s3Config.AccessKeySecret,
+			"region":          s3Config.Region,
+		}
+	case v1.BackendTypeHttp:
+		httpConfig := backendConfig.ToHttpBackend()
+		config = map[string]any{
+			"urlPrefix":          httpConfig.URLPrefix,
+			"applyURLFormat":     httpstate.DefaultApplyURLFormat,
+			"getLatestURLFormat": httpstate.DefaultGetLatestURLFormat,
+			"deleteURLFormat":    httpstate.DefaultDeleteURLFormat,
+			"historyURLFormat":   httpstate.DefaultHistoryURLFormat,
+			"listURLFormat":      httpstate.DefaultListURLFormat,
+			"lockURLFormat":      httpstate.DefaultLockURLFormat,
+		}
+		if httpConfig.Token != "" {
+			config["token"] = httpConfig.Token
+		}
+	default:
+		return nil, fmt.Errorf("do not support state backend type %s", backendConfig.Type)
+	}
+
+	_, encryptionConfig := encrypted.SplitConfig(backendConfig.Configs)
+	for k, v := range encryptionConfig {
+		config[k] = v
+	}
+	return &StateStorageConfig{
+		Type:   backendConfig.Type,
+		Config: config,
+	}, nil
 }
 
 // convertWorkspaceBackendConfig converts workspace backend config to StateStorageConfig.
@@ -97,6 +256,25 @@ func convertWorkspaceBackendConfig(workDir string, configs *v1.DeprecatedBackend
 			"accessKeySecret": configs.S3.AccessKeySecret,
 			"region":          configs.S3.Region,
 		}
+	case v1.DeprecatedBackendPostgres:
+		config = map[string]any{
+			"dbName":   configs.Postgres.DBName,
+			"user":     configs.Postgres.User,
+			"password": configs.Postgres.Password,
+			"host":     configs.Postgres.Host,
+			"port":     *configs.Postgres.Port,
+		}
+		if configs.Postgres.SSLMode != "" {
+			config["sslMode"] = configs.Postgres.SSLMode
+		}
+	case v1.DeprecatedBackendSqlite:
+		path := configs.Sqlite.Path
+		if path == "" {
+			path = filepath.Join(workDir, sqlite.KusionStateDBFile)
+		}
+		config = map[string]any{
+			"path": path,
+		}
 	}
 	return &StateStorageConfig{
 		Type:   name,
@@ -121,6 +299,11 @@ func getEnvBackendConfig(backendType string) map[string]any {
 		if accessKeySecret != "" {
 			config["accessKeySecret"] = accessKeySecret
 		}
+	case v1.DeprecatedBackendPostgres:
+		password := workspace.GetPostgresPasswordFromEnv()
+		if password != "" {
+			config["password"] = password
+		}
 	case v1.DeprecatedBackendS3:
 		accessKeyID, accessKeySecret, region := workspace.GetS3SensitiveDataFromEnv()
 		if accessKeyID != "" {
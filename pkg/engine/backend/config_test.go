@@ -10,10 +10,12 @@ import (
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	"kusionstack.io/kusion/pkg/engine/states"
 	"kusionstack.io/kusion/pkg/engine/states/local"
+	httpstate "kusionstack.io/kusion/pkg/engine/states/remote/http"
 )
 
 func TestNewConfig(t *testing.T) {
 	mysqlPort := 3306
+	postgresPort := 5432
 	testcases := []struct {
 		name                     string
 		success                  bool
@@ -105,6 +107,53 @@ func TestNewConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "postgres workspace config",
+			success: true,
+			workDir: "/testProject/testStack",
+			configs: &v1.DeprecatedBackendConfigs{
+				Postgres: &v1.DeprecatedPostgresConfig{
+					DBName:  "kusion_db",
+					User:    "kusion",
+					Host:    "127.0.0.1",
+					Port:    &postgresPort,
+					SSLMode: "disable",
+				},
+			},
+			opts: &BackendOptions{},
+			setEnvFunc: func() {
+				_ = os.Setenv(v1.EnvBackendPostgresPassword, "postgres_pwd")
+			},
+			unSetEnvFunc: func() {
+				_ = os.Unsetenv(v1.EnvBackendPostgresPassword)
+			},
+			expectedConfig: &StateStorageConfig{
+				Type: v1.DeprecatedBackendPostgres,
+				Config: map[string]any{
+					"dbName":   "kusion_db",
+					"user":     "kusion",
+					"password": "postgres_pwd",
+					"host":     "127.0.0.1",
+					"port":     5432,
+					"sslMode":  "disable",
+				},
+			},
+		},
+		{
+			name:    "sqlite workspace config default path",
+			success: true,
+			workDir: "/testProject/testStack",
+			configs: &v1.DeprecatedBackendConfigs{
+				Sqlite: &v1.DeprecatedSqliteConfig{},
+			},
+			opts: &BackendOptions{},
+			expectedConfig: &StateStorageConfig{
+				Type: v1.DeprecatedBackendSqlite,
+				Config: map[string]any{
+					"path": "/testProject/testStack/kusion_state.db",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -122,6 +171,103 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
+func TestNewConfigFromBackendConfig(t *testing.T) {
+	testcases := []struct {
+		name           string
+		success        bool
+		backendConfig  *v1.BackendConfig
+		opts           *BackendOptions
+		expectedConfig *StateStorageConfig
+	}{
+		{
+			name:    "local backend with encryption",
+			success: true,
+			backendConfig: &v1.BackendConfig{
+				Type: v1.BackendTypeLocal,
+				Configs: map[string]any{
+					v1.BackendLocalPath:        "/etc/kusion",
+					v1.BackendEncryptionKeyEnv: "KUSION_STATE_KEY",
+				},
+			},
+			opts: &BackendOptions{},
+			expectedConfig: &StateStorageConfig{
+				Type: v1.BackendTypeLocal,
+				Config: map[string]any{
+					"path":                     "/test_project/test_stack/kusion_state.yaml",
+					v1.BackendEncryptionKeyEnv: "KUSION_STATE_KEY",
+				},
+			},
+		},
+		{
+			name:    "mysql backend with default port and override options",
+			success: true,
+			backendConfig: &v1.BackendConfig{
+				Type: v1.BackendTypeMysql,
+				Configs: map[string]any{
+					v1.BackendMysqlDBName: "kusion",
+					v1.BackendMysqlUser:   "kusion",
+					v1.BackendMysqlHost:   "127.0.0.1",
+				},
+			},
+			opts: &BackendOptions{
+				Type:   v1.BackendTypeMysql,
+				Config: []string{"password=kusion"},
+			},
+			expectedConfig: &StateStorageConfig{
+				Type: v1.BackendTypeMysql,
+				Config: map[string]any{
+					"dbName":   "kusion",
+					"user":     "kusion",
+					"password": "kusion",
+					"host":     "127.0.0.1",
+					"port":     v1.DefaultMysqlPort,
+				},
+			},
+		},
+		{
+			name:    "http backend",
+			success: true,
+			backendConfig: &v1.BackendConfig{
+				Type: v1.BackendTypeHttp,
+				Configs: map[string]any{
+					v1.BackendHttpURLPrefix: "https://kusion.example.com",
+					v1.BackendHttpToken:     "kusion-token",
+				},
+			},
+			opts: &BackendOptions{},
+			expectedConfig: &StateStorageConfig{
+				Type: v1.BackendTypeHttp,
+				Config: map[string]any{
+					"urlPrefix":          "https://kusion.example.com",
+					"token":              "kusion-token",
+					"applyURLFormat":     httpstate.DefaultApplyURLFormat,
+					"getLatestURLFormat": httpstate.DefaultGetLatestURLFormat,
+					"deleteURLFormat":    httpstate.DefaultDeleteURLFormat,
+					"historyURLFormat":   httpstate.DefaultHistoryURLFormat,
+					"listURLFormat":      httpstate.DefaultListURLFormat,
+					"lockURLFormat":      httpstate.DefaultLockURLFormat,
+				},
+			},
+		},
+		{
+			name:    "unsupported backend type",
+			success: false,
+			backendConfig: &v1.BackendConfig{
+				Type: "not-support",
+			},
+			opts: &BackendOptions{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := NewConfigFromBackendConfig("/test_project/test_stack", tc.backendConfig, tc.opts)
+			assert.Equal(t, tc.success, err == nil)
+			assert.Equal(t, tc.expectedConfig, config)
+		})
+	}
+}
+
 func TestStateStorageConfig_NewStateStorage(t *testing.T) {
 	testcases := []struct {
 		name                 string
@@ -153,6 +299,160 @@ func TestStateStorageConfig_NewStateStorage(t *testing.T) {
 	}
 }
 
+func TestStateStorageConfig_ToWorkspaceBackendConfigs(t *testing.T) {
+	mysqlPort := 3307
+	defaultMysqlPort := v1.DefaultMysqlPort
+	defaultPostgresPort := v1.DefaultPostgresPort
+	testcases := []struct {
+		name            string
+		success         bool
+		config          *StateStorageConfig
+		expectedConfigs *v1.DeprecatedBackendConfigs
+	}{
+		{
+			name:    "local config",
+			success: true,
+			config: &StateStorageConfig{
+				Type: v1.DeprecatedBackendLocal,
+				Config: map[string]any{
+					"encryptionKeyFile": "/path/to/key",
+				},
+			},
+			expectedConfigs: &v1.DeprecatedBackendConfigs{
+				Local: &v1.DeprecatedLocalFileConfig{},
+			},
+		},
+		{
+			name:    "mysql config",
+			success: true,
+			config: &StateStorageConfig{
+				Type: v1.DeprecatedBackendMysql,
+				Config: map[string]any{
+					"dbName": "kusion_db",
+					"user":   "kusion",
+					"host":   "127.0.0.1",
+					"port":   "3307",
+				},
+			},
+			expectedConfigs: &v1.DeprecatedBackendConfigs{
+				Mysql: &v1.DeprecatedMysqlConfig{
+					DBName: "kusion_db",
+					User:   "kusion",
+					Host:   "127.0.0.1",
+					Port:   &mysqlPort,
+				},
+			},
+		},
+		{
+			name:    "mysql config default port",
+			success: true,
+			config: &StateStorageConfig{
+				Type: v1.DeprecatedBackendMysql,
+				Config: map[string]any{
+					"dbName": "kusion_db",
+					"user":   "kusion",
+					"host":   "127.0.0.1",
+				},
+			},
+			expectedConfigs: &v1.DeprecatedBackendConfigs{
+				Mysql: &v1.DeprecatedMysqlConfig{
+					DBName: "kusion_db",
+					User:   "kusion",
+					Host:   "127.0.0.1",
+					Port:   &defaultMysqlPort,
+				},
+			},
+		},
+		{
+			name:    "s3 config",
+			success: true,
+			config: &StateStorageConfig{
+				Type: v1.DeprecatedBackendS3,
+				Config: map[string]any{
+					"bucket": "kusion_test",
+					"region": "us-east-1",
+				},
+			},
+			expectedConfigs: &v1.DeprecatedBackendConfigs{
+				S3: &v1.DeprecatedS3Config{
+					GenericObjectStorageConfig: v1.GenericObjectStorageConfig{
+						Bucket: "kusion_test",
+					},
+					Region: "us-east-1",
+				},
+			},
+		},
+		{
+			name:    "postgres config",
+			success: true,
+			config: &StateStorageConfig{
+				Type: v1.DeprecatedBackendPostgres,
+				Config: map[string]any{
+					"dbName":  "kusion_db",
+					"user":    "kusion",
+					"host":    "127.0.0.1",
+					"sslMode": "disable",
+				},
+			},
+			expectedConfigs: &v1.DeprecatedBackendConfigs{
+				Postgres: &v1.DeprecatedPostgresConfig{
+					DBName:  "kusion_db",
+					User:    "kusion",
+					Host:    "127.0.0.1",
+					Port:    &defaultPostgresPort,
+					SSLMode: "disable",
+				},
+			},
+		},
+		{
+			name:    "sqlite config",
+			success: true,
+			config: &StateStorageConfig{
+				Type: v1.DeprecatedBackendSqlite,
+				Config: map[string]any{
+					"path": "/test_project/kusion_state.db",
+				},
+			},
+			expectedConfigs: &v1.DeprecatedBackendConfigs{
+				Sqlite: &v1.DeprecatedSqliteConfig{
+					Path: "/test_project/kusion_state.db",
+				},
+			},
+		},
+		{
+			name:    "invalid oss config",
+			success: false,
+			config: &StateStorageConfig{
+				Type:   v1.DeprecatedBackendOss,
+				Config: map[string]any{},
+			},
+		},
+		{
+			name:    "invalid mysql port",
+			success: false,
+			config: &StateStorageConfig{
+				Type: v1.DeprecatedBackendMysql,
+				Config: map[string]any{
+					"dbName": "kusion_db",
+					"user":   "kusion",
+					"host":   "127.0.0.1",
+					"port":   "invalid",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			configs, err := tc.config.ToWorkspaceBackendConfigs()
+			assert.Equal(t, tc.success, err == nil)
+			if tc.success {
+				assert.Equal(t, tc.expectedConfigs, configs)
+			}
+		})
+	}
+}
+
 func TestMergeConfig(t *testing.T) {
 	testcases := []struct {
 		name                   string
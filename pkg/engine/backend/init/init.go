@@ -7,7 +7,9 @@ import (
 	"kusionstack.io/kusion/pkg/engine/states/remote/http"
 	"kusionstack.io/kusion/pkg/engine/states/remote/mysql"
 	"kusionstack.io/kusion/pkg/engine/states/remote/oss"
+	"kusionstack.io/kusion/pkg/engine/states/remote/postgres"
 	"kusionstack.io/kusion/pkg/engine/states/remote/s3"
+	"kusionstack.io/kusion/pkg/engine/states/remote/sqlite"
 )
 
 // backends store all available backend
@@ -16,11 +18,13 @@ var backends map[string]func() states.Backend
 // init backends map with all support backend
 func init() {
 	backends = map[string]func() states.Backend{
-		v1.DeprecatedBackendLocal: local.NewLocalBackend,
-		v1.DeprecatedBackendMysql: mysql.NewMysqlBackend,
-		v1.DeprecatedBackendOss:   oss.NewOssBackend,
-		v1.DeprecatedBackendS3:    s3.NewS3Backend,
-		"http":                    http.NewHTTPBackend,
+		v1.DeprecatedBackendLocal:    local.NewLocalBackend,
+		v1.DeprecatedBackendMysql:    mysql.NewMysqlBackend,
+		v1.DeprecatedBackendOss:      oss.NewOssBackend,
+		v1.DeprecatedBackendS3:       s3.NewS3Backend,
+		v1.DeprecatedBackendPostgres: postgres.NewPostgresBackend,
+		v1.DeprecatedBackendSqlite:   sqlite.NewSqliteBackend,
+		v1.BackendTypeHttp:           http.NewHTTPBackend,
 	}
 }
 
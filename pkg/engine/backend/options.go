@@ -10,6 +10,7 @@ import (
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	backendinit "kusionstack.io/kusion/pkg/engine/backend/init"
+	"kusionstack.io/kusion/pkg/engine/states/encrypted"
 	"kusionstack.io/kusion/pkg/util/i18n"
 )
 
@@ -30,11 +31,14 @@ type BackendOptions struct {
 	//		mysql	- state is stored to mysql
 	//    	oss 	- state is stored to aliyun oss
 	//		s3 		- state is stored to aws s3
+	//		postgres	- state is stored to postgresql
+	//		sqlite	- state is stored to a sqlite database file
 	//		http  	- state is stored by http service
 	Type string
 
 	// Config is a group of configurations of the specified type backend, each configuration item with
-	// the format "key=value", such as "dbName=kusion-db" for type mysql
+	// the format "key=value", such as "dbName=kusion-db" for type mysql. The state encryption items, such
+	// as "encryptionKeyFile=/path/to/key", are supported by all types
 	Config []string
 }
 
@@ -94,14 +98,16 @@ func (o *BackendOptions) toStateStorageConfig() (*StateStorageConfig, error) {
 }
 
 // validBackendConfig checks state backend config from BackendOptions, it only checks whether there are
-// unsupported backend configuration items for now.
+// unsupported backend configuration items for now. The encryption configuration items are supported by
+// all the backends.
 func validBackendConfig(config *StateStorageConfig, schema cty.Type) error {
-	for k := range config.Config {
+	backendConfig, _ := encrypted.SplitConfig(config.Config)
+	for k := range backendConfig {
 		if !schema.HasAttribute(k) {
 			return fmt.Errorf("%w: %s", ErrUnsupportedBackendConfigItem, k)
 		}
 	}
-	if config.Type == v1.DeprecatedBackendLocal && len(config.Config) != 0 {
+	if config.Type == v1.DeprecatedBackendLocal && len(backendConfig) != 0 {
 		return fmt.Errorf("%w for backend local", ErrNotSupportBackendConfig)
 	}
 	return nil
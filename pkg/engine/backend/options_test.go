@@ -83,6 +83,14 @@ func TestBackendOptions_Validate(t *testing.T) {
 				Config: []string{"path=unsupported_kusion_state.yaml"},
 			},
 		},
+		{
+			name:    "valid backend options local backend encryption config",
+			success: true,
+			opts: &BackendOptions{
+				Type:   "local",
+				Config: []string{"encryptionKeyFile=/home/kusion/state.key"},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -4,18 +4,46 @@ import (
 	"fmt"
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/config"
 	"kusionstack.io/kusion/pkg/engine/states"
 	"kusionstack.io/kusion/pkg/workspace"
 )
 
-// NewStateStorage news a StateStorage by configs of workspace, cli backend options, and environment variables.
+// NewStateStorage news a StateStorage by kusion config or configs of workspace, cli backend options, and environment variables.
 func NewStateStorage(stack *v1.Stack, opts *BackendOptions) (states.StateStorage, error) {
+	stateStorageConfig, err := NewStateStorageConfig(stack, opts)
+	if err != nil {
+		return nil, err
+	}
+	return stateStorageConfig.NewStateStorage()
+}
+
+// NewStateStorageConfig news a StateStorageConfig by the current backend of kusion config, cli backend options,
+// and environment variables. If the current backend is not set, the deprecated backend configs of workspace are
+// used instead.
+func NewStateStorageConfig(stack *v1.Stack, opts *BackendOptions) (*StateStorageConfig, error) {
+	kusionConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get kusion config failed, %w", err)
+	}
+	_, backendConfig, err := config.GetCurrentBackend(kusionConfig)
+	if err != nil {
+		return nil, err
+	}
+	if backendConfig != nil {
+		return NewConfigFromBackendConfig(stack.Path, backendConfig, opts)
+	}
+
 	var backendConfigs *v1.DeprecatedBackendConfigs
 	wsOperator, err := workspace.NewValidDefaultOperator()
 	if err != nil {
 		return nil, fmt.Errorf("new default workspace opearator failed, %w", err)
 	}
-	if wsOperator.WorkspaceExist(stack.Name) {
+	exist, err := wsOperator.WorkspaceExist(stack.Name)
+	if err != nil {
+		return nil, fmt.Errorf("check existence of workspace %s failed, %w", stack.Name, err)
+	}
+	if exist {
 		var ws *v1.Workspace
 		ws, err = wsOperator.GetWorkspace(stack.Name)
 		if err != nil {
@@ -28,9 +56,5 @@ func NewStateStorage(stack *v1.Stack, opts *BackendOptions) (states.StateStorage
 			}
 		}
 	}
-	stateStorageConfig, err := NewConfig(stack.Path, backendConfigs, opts)
-	if err != nil {
-		return nil, err
-	}
-	return stateStorageConfig.NewStateStorage()
+	return NewConfig(stack.Path, backendConfigs, opts)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/config"
 	"kusionstack.io/kusion/pkg/util/kfile"
 )
 
@@ -98,3 +99,31 @@ func Test_NewStateStorage(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewStateStorageConfigFromKusionConfig(t *testing.T) {
+	t.Setenv(v1.EnvAwsRegion, "")
+	mockey.PatchConvey("mock kusion config", t, func() {
+		mockey.Mock(kfile.KusionDataFolder).Return(testDataFolder(), nil).Build()
+		mockey.Mock(config.GetConfig).Return(&v1.Config{
+			Backends: &v1.BackendConfigs{
+				Current: "prod",
+				Backends: map[string]*v1.BackendConfig{
+					"prod": {
+						Type: v1.BackendTypeS3,
+						Configs: map[string]any{
+							v1.BackendGenericOssBucket: "kusion",
+							v1.BackendS3Region:         "us-east-1",
+						},
+					},
+				},
+			},
+		}, nil).Build()
+
+		// the backend configs of workspace are ignored if the current backend is set
+		stateStorageConfig, err := NewStateStorageConfig(mockStack("invalid_backend_ws"), &BackendOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, v1.BackendTypeS3, stateStorageConfig.Type)
+		assert.Equal(t, "kusion", stateStorageConfig.Config["bucket"])
+		assert.Equal(t, "us-east-1", stateStorageConfig.Config["region"])
+	})
+}
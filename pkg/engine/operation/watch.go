@@ -111,7 +111,7 @@ func (wo *WatchOperation) Watch(req *WatchRequest) error {
 					e := recv.Interface().(k8swatch.Event)
 					o := e.Object.(*unstructured.Unstructured)
 					var detail string
-					var ready bool
+					var ready, failed bool
 					if e.Type == k8swatch.Deleted {
 						detail = fmt.Sprintf("%s has beed deleted", o.GetName())
 						ready = true
@@ -119,11 +119,15 @@ func (wo *WatchOperation) Watch(req *WatchRequest) error {
 						// Restore to actual type
 						target := printers.Convert(o)
 						detail, ready = printers.Generate(target)
+						failed = printers.Failed(target)
 					}
 
-					// Mark ready for breaking loop
-					if ready {
+					// Mark ready or failed for breaking loop
+					switch {
+					case ready:
 						e.Type = printers.READY
+					case failed:
+						e.Type = printers.FAILED
 					}
 
 					// Save watched msg
@@ -168,6 +172,13 @@ func (wo *WatchOperation) Watch(req *WatchRequest) error {
 		<-ticker.C
 		wo.printTables(writer, ids, tables)
 	}
+
+	// The failed dependents, e.g. the retried pods of a job, are ignored as long as the root resource succeeds
+	for _, id := range ids {
+		if table, ok := tables[id]; ok && table.RootFailed() {
+			return fmt.Errorf("%s failed", id)
+		}
+	}
 	return nil
 }
 
@@ -42,6 +42,52 @@ func TestWatchOperation_Watch(t *testing.T) {
 	})
 }
 
+func TestWatchOperation_WatchFailedJob(t *testing.T) {
+	mockey.PatchConvey("test watch operation: watch failed job", t, func() {
+		req := &WatchRequest{
+			Request: opsmodels.Request{
+				Intent: &apiv1.Intent{
+					Resources: apiv1.Resources{
+						{
+							ID:         "batch/v1:Job:foo:bar",
+							Type:       runtime.Kubernetes,
+							Attributes: barFailedJob,
+						},
+					},
+				},
+			},
+		}
+		mockey.Mock(runtimeinit.Runtimes).To(func(
+			resources apiv1.Resources,
+		) (map[apiv1.Type]runtime.Runtime, v1.Status) {
+			return map[apiv1.Type]runtime.Runtime{runtime.Kubernetes: failedJobRuntime}, nil
+		}).Build()
+		wo := &WatchOperation{opsmodels.Operation{RuntimeMap: map[apiv1.Type]runtime.Runtime{runtime.Kubernetes: failedJobRuntime}}}
+		err := wo.Watch(req)
+		assert.ErrorContains(t, err, "batch/v1:Job:foo:bar failed")
+	})
+}
+
+var barFailedJob = map[string]interface{}{
+	"apiVersion": "batch/v1",
+	"kind":       "Job",
+	"metadata": map[string]interface{}{
+		"namespace": "foo",
+		"name":      "bar",
+	},
+	"spec": map[string]interface{}{
+		"completions": int64(1),
+	},
+	"status": map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"type":   "Failed",
+				"status": "True",
+			},
+		},
+	},
+}
+
 var barDeployment = map[string]interface{}{
 	"apiVersion": "apps/v1",
 	"kind":       "Deployment",
@@ -105,3 +151,28 @@ func (f *fooWatchRuntime) Watch(ctx context.Context, request *runtime.WatchReque
 		Status: nil,
 	}
 }
+
+var failedJobRuntime = &failedJobWatchRuntime{}
+
+type failedJobWatchRuntime struct {
+	fooWatchRuntime
+}
+
+func (f *failedJobWatchRuntime) Watch(ctx context.Context, request *runtime.WatchRequest) *runtime.WatchResponse {
+	out := make(chan k8sWatch.Event)
+	go func() {
+		out <- k8sWatch.Event{
+			Type:   k8sWatch.Modified,
+			Object: &unstructured.Unstructured{Object: barFailedJob},
+		}
+		close(out)
+	}()
+
+	return &runtime.WatchResponse{
+		Watchers: &runtime.SequentialWatchers{
+			IDs:      []string{"batch/v1:Job:foo:bar"},
+			Watchers: []<-chan k8sWatch.Event{out},
+		},
+		Status: nil,
+	}
+}
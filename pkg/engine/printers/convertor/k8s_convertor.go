@@ -2,6 +2,7 @@ package convertor
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
@@ -38,6 +39,11 @@ const (
 	ControllerRevision = "ControllerRevision"
 )
 
+// APIs in autoscaling/v2
+const (
+	HorizontalPodAutoscaler = "HorizontalPodAutoscaler"
+)
+
 // APIs in batch/v1
 const (
 	CronJob = "CronJob"
@@ -60,6 +66,8 @@ func ToK8s(o *unstructured.Unstructured) runtime.Object {
 		return convertCoreV1(o)
 	case appsv1.SchemeGroupVersion:
 		return convertAppsV1(o)
+	case autoscalingv2.SchemeGroupVersion:
+		return convertAutoscalingV2(o)
 	case batchv1.SchemeGroupVersion:
 		return convertBatchV1(o)
 	case discoveryv1.SchemeGroupVersion:
@@ -137,6 +145,21 @@ func convertAppsV1(o *unstructured.Unstructured) runtime.Object {
 	return target
 }
 
+func convertAutoscalingV2(o *unstructured.Unstructured) runtime.Object {
+	var target runtime.Object
+	switch o.GetKind() {
+	case HorizontalPodAutoscaler:
+		target = &autoscalingv2.HorizontalPodAutoscaler{}
+	default:
+		return nil
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.Object, target); err != nil {
+		return nil
+	}
+	return target
+}
+
 func convertBatchV1(o *unstructured.Unstructured) runtime.Object {
 	var target runtime.Object
 	switch o.GetKind() {
@@ -15,3 +15,8 @@ func init() {
 func Generate(obj runtime.Object) (string, bool) {
 	return tg.GenerateTable(obj)
 }
+
+// Failed returns true if the obj is terminated with failure.
+func Failed(obj runtime.Object) bool {
+	return printer.Failed(obj)
+}
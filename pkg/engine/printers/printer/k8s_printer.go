@@ -10,6 +10,7 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
@@ -58,6 +59,8 @@ func AddK8sHandlers(h PrintHandler) {
 	h.TableHandler(printDaemonSet)
 	h.TableHandler(printStatefulSet)
 	h.TableHandler(printControllerRevision)
+	// autoscaling/v2
+	h.TableHandler(printHorizontalPodAutoscaler)
 	// discovery.k8s.io/v1
 	h.TableHandler(printEndpointSlice)
 	// batch/v1
@@ -600,7 +603,8 @@ func printPod(pod *corev1.Pod) (string, bool) {
 	}
 
 	readyStr := fmt.Sprintf("%d/%d", readyContainers, totalContainers)
-	ready := readyContainers == totalContainers
+	// The succeeded pods, e.g. the pods of the jobs, won't be changed anymore.
+	ready := readyContainers == totalContainers || pod.Status.Phase == corev1.PodSucceeded
 	age := translateTimestampSince(pod.CreationTimestamp)
 
 	return fmt.Sprintf("Ready: %s, Status: %s, Restart: %s, Age: %s", readyStr, reason, restartsStr, age), ready
@@ -687,7 +691,11 @@ func printServiceAccount(obj *corev1.ServiceAccount) (string, bool) {
 }
 
 func printDeployment(obj *appsv1.Deployment) (string, bool) {
-	desiredReplicas := *(obj.Spec.Replicas)
+	// replicas defaults to 1 if not specified
+	desiredReplicas := int32(1)
+	if obj.Spec.Replicas != nil {
+		desiredReplicas = *obj.Spec.Replicas
+	}
 	updatedReplicas := obj.Status.UpdatedReplicas
 	readyReplicas := obj.Status.ReadyReplicas
 	availableReplicas := obj.Status.AvailableReplicas
@@ -695,6 +703,18 @@ func printDeployment(obj *appsv1.Deployment) (string, bool) {
 		readyReplicas, desiredReplicas, updatedReplicas, availableReplicas), desiredReplicas == availableReplicas
 }
 
+func printHorizontalPodAutoscaler(obj *autoscalingv2.HorizontalPodAutoscaler) (string, bool) {
+	minReplicas := int32(1)
+	if obj.Spec.MinReplicas != nil {
+		minReplicas = *obj.Spec.MinReplicas
+	}
+	currentReplicas := obj.Status.CurrentReplicas
+	desiredReplicas := obj.Status.DesiredReplicas
+	return fmt.Sprintf("Replicas: %d/%d, MinPods: %d, MaxPods: %d, Age: %s",
+			currentReplicas, desiredReplicas, minReplicas, obj.Spec.MaxReplicas, translateTimestampSince(obj.CreationTimestamp)),
+		desiredReplicas > 0 && currentReplicas == desiredReplicas
+}
+
 func printEndpointSlice(obj *discoveryv1.EndpointSlice) (string, bool) {
 	addressType := string(obj.AddressType)
 	ports := formatDiscoveryPorts(obj.Ports)
@@ -772,15 +792,20 @@ func printDaemonSet(obj *appsv1.DaemonSet) (string, bool) {
 
 	return fmt.Sprintf("Desired: %d, Current: %d, Ready: %d, Up-to-date: %d, Available: %d",
 			desiredScheduled, currentScheduled, numberReady, numberUpdated, numberAvailable),
-		desiredScheduled == numberReady
+		desiredScheduled == numberReady && desiredScheduled == numberUpdated
 }
 
 func printStatefulSet(obj *appsv1.StatefulSet) (string, bool) {
-	desiredReplicas := *(obj.Spec.Replicas)
+	// replicas defaults to 1 if not specified
+	desiredReplicas := int32(1)
+	if obj.Spec.Replicas != nil {
+		desiredReplicas = *obj.Spec.Replicas
+	}
 	readyReplicas := obj.Status.ReadyReplicas
+	updatedReplicas := obj.Status.UpdatedReplicas
 	createTime := translateTimestampSince(obj.CreationTimestamp)
-	return fmt.Sprintf("Ready: %d/%d, Age: %s", desiredReplicas, readyReplicas, createTime),
-		desiredReplicas == readyReplicas
+	return fmt.Sprintf("Ready: %d/%d, Up-to-date: %d, Age: %s", readyReplicas, desiredReplicas, updatedReplicas, createTime),
+		desiredReplicas == readyReplicas && desiredReplicas == updatedReplicas
 }
 
 func printControllerRevision(obj *appsv1.ControllerRevision) (string, bool) {
@@ -810,9 +835,10 @@ func printCronJob(obj *batchv1.CronJob) (string, bool) {
 		lastScheduleTime = translateTimestampSince(*obj.Status.LastScheduleTime)
 	}
 
+	// The CronJob is ready once created, as the executions are scheduled later.
 	return fmt.Sprintf("Schedule: %s, Suspend: %s, Active: %d, Last Schedule: %s",
 			obj.Spec.Schedule, printBoolPtr(obj.Spec.Suspend), len(obj.Status.Active), lastScheduleTime),
-		lastScheduleTime != "<none>"
+		true
 }
 
 func printBoolPtr(value *bool) string {
@@ -855,9 +881,42 @@ func printJob(obj *batchv1.Job) (string, bool) {
 		jobDuration = duration.HumanDuration(obj.Status.CompletionTime.Sub(obj.Status.StartTime.Time))
 	}
 
-	return fmt.Sprintf("Completions: %s, Duration: %s, Age: %s",
-			completions, jobDuration, translateTimestampSince(obj.CreationTimestamp)),
-		obj.Status.Succeeded == *obj.Spec.Completions
+	// The job is finished once it is complete, and the failed job is reported by Failed.
+	status := "Running"
+	switch {
+	case hasJobCondition(obj.Status.Conditions, batchv1.JobComplete):
+		status = "Complete"
+	case hasJobCondition(obj.Status.Conditions, batchv1.JobFailed):
+		status = "Failed"
+	case obj.Spec.Suspend != nil && *obj.Spec.Suspend:
+		status = "Suspended"
+	}
+
+	return fmt.Sprintf("Status: %s, Completions: %s, Duration: %s, Age: %s",
+			status, completions, jobDuration, translateTimestampSince(obj.CreationTimestamp)),
+		status == "Complete"
+}
+
+// Failed returns true if the obj is terminated with failure, which won't be changed anymore,
+// e.g. the failed pods and jobs.
+func Failed(obj runtime.Object) bool {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		return o.Status.Phase == corev1.PodFailed
+	case *batchv1.Job:
+		return hasJobCondition(o.Status.Conditions, batchv1.JobFailed)
+	default:
+		return false
+	}
+}
+
+func hasJobCondition(conditions []batchv1.JobCondition, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
 }
 
 func printIngress(obj *networkingv1.Ingress) (string, bool) {
@@ -0,0 +1,108 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPrintPod(t *testing.T) {
+	tests := []struct {
+		name       string
+		phase      corev1.PodPhase
+		ready      bool
+		wantReady  bool
+		wantFailed bool
+	}{
+		{
+			name:      "running pod with ready containers",
+			phase:     corev1.PodRunning,
+			ready:     true,
+			wantReady: true,
+		},
+		{
+			name:  "running pod with unready containers",
+			phase: corev1.PodRunning,
+		},
+		{
+			name:      "succeeded pod",
+			phase:     corev1.PodSucceeded,
+			wantReady: true,
+		},
+		{
+			name:       "failed pod",
+			phase:      corev1.PodFailed,
+			wantFailed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := corev1.ContainerStatus{Name: "foo", Ready: tt.ready}
+			if tt.ready {
+				status.State.Running = &corev1.ContainerStateRunning{}
+			}
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "foo"}},
+				},
+				Status: corev1.PodStatus{
+					Phase:             tt.phase,
+					ContainerStatuses: []corev1.ContainerStatus{status},
+				},
+			}
+			_, ready := printPod(pod)
+			assert.Equal(t, tt.wantReady, ready)
+			assert.Equal(t, tt.wantFailed, Failed(pod))
+		})
+	}
+}
+
+func TestPrintJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []batchv1.JobCondition
+		wantStatus string
+		wantReady  bool
+		wantFailed bool
+	}{
+		{
+			name:       "running job",
+			wantStatus: "Status: Running",
+		},
+		{
+			name: "complete job",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+			wantStatus: "Status: Complete",
+			wantReady:  true,
+		},
+		{
+			name: "failed job",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+			},
+			wantStatus: "Status: Failed",
+			wantFailed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completions := int32(1)
+			job := &batchv1.Job{
+				Spec: batchv1.JobSpec{
+					Completions: &completions,
+				},
+				Status: batchv1.JobStatus{
+					Conditions: tt.conditions,
+				},
+			}
+			detail, ready := printJob(job)
+			assert.Contains(t, detail, tt.wantStatus)
+			assert.Equal(t, tt.wantReady, ready)
+			assert.Equal(t, tt.wantFailed, Failed(job))
+		})
+	}
+}
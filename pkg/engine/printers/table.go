@@ -34,7 +34,10 @@ func NewRow(t k8swatch.EventType, kind, name, detail string) *Row {
 	}
 }
 
-const READY k8swatch.EventType = "READY"
+const (
+	READY  k8swatch.EventType = "READY"
+	FAILED k8swatch.EventType = "FAILED"
+)
 
 func (t *Table) Update(id string, row *Row) {
 	t.Rows[id] = row
@@ -45,13 +48,22 @@ func (t *Table) AllCompleted() bool {
 		return false
 	}
 	for _, row := range t.Rows {
-		if row.Type != READY {
+		if row.Type != READY && row.Type != FAILED {
 			return false
 		}
 	}
 	return true
 }
 
+// RootFailed returns true if the root resource, which is the first one watched, is failed.
+func (t *Table) RootFailed() bool {
+	if len(t.IDs) == 0 {
+		return false
+	}
+	row, ok := t.Rows[t.IDs[0]]
+	return ok && row.Type == FAILED
+}
+
 func (t *Table) Print() [][]string {
 	data := [][]string{{"Type", "Kind", "Name", "Detail"}}
 	for _, id := range t.IDs {
@@ -67,7 +79,7 @@ func (t *Table) Print() [][]string {
 			eventTypeS = pretty.Red(string(eventType))
 		case k8swatch.Modified:
 			eventTypeS = pretty.Yellow(string(eventType))
-		case k8swatch.Error:
+		case k8swatch.Error, FAILED:
 			eventTypeS = pretty.Red(string(eventType))
 		default:
 			eventTypeS = pretty.Green(string(eventType))
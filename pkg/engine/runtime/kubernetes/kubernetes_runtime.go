@@ -9,7 +9,6 @@ import (
 	jsonpatch "github.com/evanphx/json-patch"
 	yamlv2 "gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
-	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -608,22 +607,11 @@ func getDependentGVK(gvk schema.GroupVersionKind) schema.GroupVersionKind {
 			Version: appsv1.SchemeGroupVersion.Version,
 			Kind:    convertor.ReplicaSet,
 		}
-	// DaemonSet and StatefulSet generate ControllerRevision
-	case convertor.DaemonSet, convertor.StatefulSet:
-		return schema.GroupVersionKind{
-			Group:   appsv1.SchemeGroupVersion.Group,
-			Version: appsv1.SchemeGroupVersion.Version,
-			Kind:    convertor.ControllerRevision,
-		}
-	// CronJob generates Job
+	// CronJob generates Job, which is not watched as it is not created until the scheduled time
 	case convertor.CronJob:
-		return schema.GroupVersionKind{
-			Group:   batchv1.SchemeGroupVersion.Group,
-			Version: batchv1.SchemeGroupVersion.Version,
-			Kind:    convertor.Job,
-		}
-	// ReplicaSet, ReplicationController, ControllerRevision and job generate Pod
-	case convertor.ReplicaSet, convertor.Job, convertor.ReplicationController, convertor.ControllerRevision:
+		return schema.GroupVersionKind{}
+	// ReplicaSet, ReplicationController, DaemonSet, StatefulSet and Job generate Pod
+	case convertor.ReplicaSet, convertor.Job, convertor.ReplicationController, convertor.DaemonSet, convertor.StatefulSet:
 		return schema.GroupVersionKind{
 			Group:   corev1.SchemeGroupVersion.Group,
 			Version: corev1.SchemeGroupVersion.Version,
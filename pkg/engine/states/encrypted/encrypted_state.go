@@ -0,0 +1,193 @@
+package encrypted
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/engine/states"
+)
+
+var _ states.HistoryStateStorage = &EncryptedState{}
+
+// EncryptedState wraps a StateStorage and stores the State with envelope encryption. Each time a State
+// is applied, its Resources are sealed by a fresh data key, and the data key is wrapped by the key
+// encryption key, both recorded in State.Encryption. The other fields of the State are kept in plaintext,
+// so that the wrapped StateStorage can still query the State.
+type EncryptedState struct {
+	// storage is the wrapped StateStorage
+	storage states.StateStorage
+	// key is used to encrypt and decrypt the State
+	key Key
+	// decryptionKeys are only used to decrypt the States encrypted by previous keys
+	decryptionKeys []Key
+}
+
+// NewEncryptedState news an EncryptedState which encrypts the State using the key.
+func NewEncryptedState(storage states.StateStorage, key Key) *EncryptedState {
+	return &EncryptedState{
+		storage: storage,
+		key:     key,
+	}
+}
+
+// NewStateStorage wraps the storage with an EncryptedState if the encryption config is not empty.
+func NewStateStorage(storage states.StateStorage, encryptionConfig map[string]any) (states.StateStorage, error) {
+	if len(encryptionConfig) == 0 {
+		return storage, nil
+	}
+	key, err := NewKey(encryptionConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncryptedState(storage, key), nil
+}
+
+// Key returns the key used to encrypt the State.
+func (s *EncryptedState) Key() Key {
+	return s.key
+}
+
+// AddDecryptionKeys adds the keys which are only used to decrypt the State, such as the previous keys
+// before rekey.
+func (s *EncryptedState) AddDecryptionKeys(keys ...Key) {
+	s.decryptionKeys = append(s.decryptionKeys, keys...)
+}
+
+// GetLatestState is an implementation of StateStorage.GetLatestState. The State stored in plaintext is
+// returned directly, which makes it possible to turn on encryption for existing States.
+func (s *EncryptedState) GetLatestState(query *states.StateQuery) (*states.State, error) {
+	state, err := s.storage.GetLatestState(query)
+	if err != nil || state == nil || state.Encryption == nil {
+		return state, err
+	}
+	return s.decrypt(state)
+}
+
+// GetHistoryStates is an implementation of HistoryStateStorage.GetHistoryStates, which returns
+// states.ErrHistoryNotSupported if the wrapped StateStorage does not keep the history.
+func (s *EncryptedState) GetHistoryStates(query *states.StateQuery) ([]*states.State, error) {
+	historyStorage, ok := s.storage.(states.HistoryStateStorage)
+	if !ok {
+		return nil, states.ErrHistoryNotSupported
+	}
+	historyStates, err := historyStorage.GetHistoryStates(query)
+	if err != nil {
+		return nil, err
+	}
+	for i, state := range historyStates {
+		if state == nil || state.Encryption == nil {
+			continue
+		}
+		if historyStates[i], err = s.decrypt(state); err != nil {
+			return nil, err
+		}
+	}
+	return historyStates, nil
+}
+
+// Apply is an implementation of StateStorage.Apply
+func (s *EncryptedState) Apply(state *states.State) error {
+	encryptedState, err := s.encrypt(state)
+	if err != nil {
+		return err
+	}
+	if err = s.storage.Apply(encryptedState); err != nil {
+		return err
+	}
+	// the wrapped storage may set these fields when applying
+	state.ID = encryptedState.ID
+	state.CreateTime = encryptedState.CreateTime
+	state.ModifiedTime = encryptedState.ModifiedTime
+	return nil
+}
+
+// Delete is an implementation of StateStorage.Delete
+func (s *EncryptedState) Delete(id string) error {
+	return s.storage.Delete(id)
+}
+
+func (s *EncryptedState) encrypt(state *states.State) (*states.State, error) {
+	plaintext, err := json.Marshal(state.Resources)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := newDataKey()
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(dataKey, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := s.key.Wrap(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrap data key with %s key %s failed: %w", s.key.Type(), s.key.ID(), err)
+	}
+
+	encryptedState := *state
+	encryptedState.Resources = apiv1.Resources{}
+	encryptedState.Encryption = &states.Encryption{
+		KeyType:    s.key.Type(),
+		KeyID:      s.key.ID(),
+		DataKey:    base64.StdEncoding.EncodeToString(wrappedKey),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+	return &encryptedState, nil
+}
+
+func (s *EncryptedState) decrypt(state *states.State) (*states.State, error) {
+	encryption := state.Encryption
+	wrappedKey, err := base64.StdEncoding.DecodeString(encryption.DataKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode data key failed: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryption.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decode ciphertext failed: %w", err)
+	}
+
+	dataKey, err := s.unwrap(encryption, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dataKey, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt state failed: %w", err)
+	}
+
+	var resources apiv1.Resources
+	// JSON is a subset of YAML. Please check FileSystemState.GetLatestState for detail explanation
+	if err = yaml.Unmarshal(plaintext, &resources); err != nil {
+		return nil, err
+	}
+	state.Resources = resources
+	state.Encryption = nil
+	return state, nil
+}
+
+// unwrap finds the key with the recorded type and id to unwrap the data key. As an age identity may
+// decrypt for the recipients which are not configured anymore, all the age keys are tried if no age
+// key with the same id.
+func (s *EncryptedState) unwrap(encryption *states.Encryption, wrappedKey []byte) ([]byte, error) {
+	keys := append([]Key{s.key}, s.decryptionKeys...)
+	for _, key := range keys {
+		if key.Type() == encryption.KeyType && key.ID() == encryption.KeyID {
+			return key.Unwrap(wrappedKey)
+		}
+	}
+	if encryption.KeyType == KeyTypeAge {
+		for _, key := range keys {
+			if key.Type() != KeyTypeAge {
+				continue
+			}
+			if dataKey, err := key.Unwrap(wrappedKey); err == nil {
+				return dataKey, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no %s key %s configured to decrypt the state", encryption.KeyType, encryption.KeyID)
+}
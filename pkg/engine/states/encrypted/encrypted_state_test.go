@@ -0,0 +1,106 @@
+package encrypted
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/local"
+)
+
+func mockResources() apiv1.Resources {
+	return apiv1.Resources{
+		{
+			ID:   "v1:Secret:default:db-password",
+			Type: apiv1.Kubernetes,
+			Attributes: map[string]interface{}{
+				"data": map[string]interface{}{"password": "c2VjcmV0"},
+			},
+		},
+	}
+}
+
+func mockState() *states.State {
+	state := states.NewState()
+	state.Project = "test_project"
+	state.Stack = "dev"
+	state.Serial = 1
+	state.Resources = mockResources()
+	return state
+}
+
+func newTestStorage(t *testing.T) *local.FileSystemState {
+	return &local.FileSystemState{Path: filepath.Join(t.TempDir(), local.KusionStateFileFile)}
+}
+
+func TestEncryptedState_ApplyAndGetLatestState(t *testing.T) {
+	storage := newTestStorage(t)
+	key, err := NewAESKey([]byte(testAESKeyContent()))
+	assert.NoError(t, err)
+	s := NewEncryptedState(storage, key)
+
+	assert.NoError(t, s.Apply(mockState()))
+
+	// the stored state contains no plaintext resources
+	content, err := os.ReadFile(storage.Path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "db-password")
+	stored, err := storage.GetLatestState(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, stored.Resources)
+	assert.Equal(t, KeyTypeAES, stored.Encryption.KeyType)
+	assert.Equal(t, key.ID(), stored.Encryption.KeyID)
+	assert.Equal(t, uint64(1), stored.Serial)
+
+	got, err := s.GetLatestState(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, got.Encryption)
+	assert.Equal(t, mockResources(), got.Resources)
+}
+
+func TestEncryptedState_GetLatestStatePlaintext(t *testing.T) {
+	storage := newTestStorage(t)
+	assert.NoError(t, storage.Apply(mockState()))
+	key, err := NewAESKey([]byte(testAESKeyContent()))
+	assert.NoError(t, err)
+
+	got, err := NewEncryptedState(storage, key).GetLatestState(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, mockResources(), got.Resources)
+}
+
+func TestEncryptedState_Rekey(t *testing.T) {
+	storage := newTestStorage(t)
+	oldKey, err := NewAESKey([]byte(testAESKeyContent()))
+	assert.NoError(t, err)
+	newKey, err := NewAESKey([]byte("fedcba9876543210fedcba9876543210"))
+	assert.NoError(t, err)
+	assert.NoError(t, NewEncryptedState(storage, oldKey).Apply(mockState()))
+
+	s := NewEncryptedState(storage, newKey)
+	_, err = s.GetLatestState(nil)
+	assert.Error(t, err)
+
+	s.AddDecryptionKeys(oldKey)
+	state, err := s.GetLatestState(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Apply(state))
+
+	stored, err := storage.GetLatestState(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, newKey.ID(), stored.Encryption.KeyID)
+	got, err := NewEncryptedState(storage, newKey).GetLatestState(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, mockResources(), got.Resources)
+}
+
+func TestEncryptedState_GetHistoryStates(t *testing.T) {
+	key, err := NewAESKey([]byte(testAESKeyContent()))
+	assert.NoError(t, err)
+	_, err = NewEncryptedState(newTestStorage(t), key).GetHistoryStates(nil)
+	assert.ErrorIs(t, err, states.ErrHistoryNotSupported)
+}
@@ -0,0 +1,289 @@
+package encrypted
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"filippo.io/age"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+const (
+	KeyTypeAES = "aes"
+	KeyTypeAge = "age"
+
+	// aesKeySize is the size of the AES-256 key, used both for the data keys and the aes key encryption keys.
+	aesKeySize = 32
+)
+
+var (
+	ErrMultipleKeySources = errors.New("only one of encryptionKeyFile, encryptionKeyEnv and encryptionAgeRecipients can be configured")
+	ErrEmptyKeySource     = errors.New("no encryption key configured, please specify encryptionKeyFile, encryptionKeyEnv or encryptionAgeRecipients")
+	ErrInvalidAESKey      = errors.New("encryption key must be 32 bytes, or base64 encoded 32 bytes")
+	ErrMissingAgeIdentity = errors.New("encryptionAgeIdentityFile is required to decrypt the state")
+)
+
+// configKeys are the backend config items which configure the state encryption.
+var configKeys = []string{
+	v1.BackendEncryptionKeyFile,
+	v1.BackendEncryptionKeyEnv,
+	v1.BackendEncryptionAgeRecipients,
+	v1.BackendEncryptionAgeIdentityFile,
+}
+
+// Key is the key encryption key which wraps the data key of an encrypted State.
+type Key interface {
+	// Type returns the key type, KeyTypeAES or KeyTypeAge.
+	Type() string
+
+	// ID identifies the key, which is recorded in the encrypted State.
+	ID() string
+
+	// Wrap encrypts the data key.
+	Wrap(dataKey []byte) ([]byte, error)
+
+	// Unwrap decrypts the wrapped data key.
+	Unwrap(wrapped []byte) ([]byte, error)
+}
+
+// IsConfigKey returns the backend config item is an encryption config item or not.
+func IsConfigKey(key string) bool {
+	for _, k := range configKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitConfig splits the backend config into the config of the backend itself and the encryption config.
+func SplitConfig(config map[string]any) (backendConfig, encryptionConfig map[string]any) {
+	backendConfig = make(map[string]any)
+	encryptionConfig = make(map[string]any)
+	for k, v := range config {
+		if IsConfigKey(k) {
+			encryptionConfig[k] = v
+		} else {
+			backendConfig[k] = v
+		}
+	}
+	return backendConfig, encryptionConfig
+}
+
+// NewKey news a Key from the encryption config, where one and only one of the key file, the environment
+// variable and the age recipients should be configured. The age identity file can be used alone to
+// decrypt, or to encrypt to the recipients of the identities.
+func NewKey(config map[string]any) (Key, error) {
+	keyFile, _ := config[v1.BackendEncryptionKeyFile].(string)
+	keyEnv, _ := config[v1.BackendEncryptionKeyEnv].(string)
+	recipients, _ := config[v1.BackendEncryptionAgeRecipients].(string)
+	identityFile, _ := config[v1.BackendEncryptionAgeIdentityFile].(string)
+
+	var count int
+	for _, s := range []string{keyFile, keyEnv, recipients} {
+		if s != "" {
+			count++
+		}
+	}
+	if count > 1 {
+		return nil, ErrMultipleKeySources
+	}
+
+	switch {
+	case keyFile != "":
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read encryption key file %s failed: %w", keyFile, err)
+		}
+		return NewAESKey(content)
+	case keyEnv != "":
+		content := os.Getenv(keyEnv)
+		if content == "" {
+			return nil, fmt.Errorf("empty encryption key in environment variable %s", keyEnv)
+		}
+		return NewAESKey([]byte(content))
+	case recipients != "" || identityFile != "":
+		return NewAgeKey(recipients, identityFile)
+	default:
+		return nil, ErrEmptyKeySource
+	}
+}
+
+// aesKey is a Key using AES-256-GCM to wrap the data key.
+type aesKey struct {
+	key []byte
+}
+
+// NewAESKey news an aes Key, the content should be 32 bytes, or the base64 encoding of 32 bytes.
+func NewAESKey(content []byte) (Key, error) {
+	key := content
+	if len(key) != aesKeySize {
+		trimmed := bytes.TrimSpace(content)
+		decoded := make([]byte, base64.StdEncoding.DecodedLen(len(trimmed)))
+		n, err := base64.StdEncoding.Decode(decoded, trimmed)
+		if err != nil || n != aesKeySize {
+			return nil, ErrInvalidAESKey
+		}
+		key = decoded[:n]
+	}
+	return &aesKey{key: key}, nil
+}
+
+func (k *aesKey) Type() string {
+	return KeyTypeAES
+}
+
+// ID is the hex encoded prefix of the key's sha256 digest, which does not leak the key.
+func (k *aesKey) ID() string {
+	sum := sha256.Sum256(k.key)
+	return hex.EncodeToString(sum[:8])
+}
+
+func (k *aesKey) Wrap(dataKey []byte) ([]byte, error) {
+	return seal(k.key, dataKey)
+}
+
+func (k *aesKey) Unwrap(wrapped []byte) ([]byte, error) {
+	return open(k.key, wrapped)
+}
+
+// ageKey is a Key using age to wrap the data key.
+type ageKey struct {
+	id         string
+	recipients []age.Recipient
+	identities []age.Identity
+}
+
+// NewAgeKey news an age Key. The recipients are separated by comma, and if empty, the recipients of the
+// X25519 identities in the identity file are used.
+func NewAgeKey(recipients, identityFile string) (Key, error) {
+	k := &ageKey{}
+	if identityFile != "" {
+		content, err := os.ReadFile(identityFile)
+		if err != nil {
+			return nil, fmt.Errorf("read age identity file %s failed: %w", identityFile, err)
+		}
+		if k.identities, err = age.ParseIdentities(bytes.NewReader(content)); err != nil {
+			return nil, fmt.Errorf("parse age identity file %s failed: %w", identityFile, err)
+		}
+	}
+
+	var names []string
+	if recipients != "" {
+		for _, r := range strings.Split(recipients, ",") {
+			r = strings.TrimSpace(r)
+			if r == "" {
+				continue
+			}
+			recipient, err := age.ParseX25519Recipient(r)
+			if err != nil {
+				return nil, fmt.Errorf("parse age recipient %s failed: %w", r, err)
+			}
+			k.recipients = append(k.recipients, recipient)
+			names = append(names, recipient.String())
+		}
+	} else {
+		for _, identity := range k.identities {
+			if x, ok := identity.(*age.X25519Identity); ok {
+				k.recipients = append(k.recipients, x.Recipient())
+				names = append(names, x.Recipient().String())
+			}
+		}
+	}
+	if len(k.recipients) == 0 {
+		return nil, errors.New("no valid age recipient configured")
+	}
+	sort.Strings(names)
+	k.id = strings.Join(names, ",")
+	return k, nil
+}
+
+func (k *ageKey) Type() string {
+	return KeyTypeAge
+}
+
+// ID is the sorted recipients joined by comma.
+func (k *ageKey) ID() string {
+	return k.id
+}
+
+func (k *ageKey) Wrap(dataKey []byte) ([]byte, error) {
+	out := &bytes.Buffer{}
+	w, err := age.Encrypt(out, k.recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(dataKey); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func (k *ageKey) Unwrap(wrapped []byte) ([]byte, error) {
+	if len(k.identities) == 0 {
+		return nil, ErrMissingAgeIdentity
+	}
+	r, err := age.Decrypt(bytes.NewReader(wrapped), k.identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// newDataKey generates a random data key.
+func newDataKey() ([]byte, error) {
+	dataKey := make([]byte, aesKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	return dataKey, nil
+}
+
+// seal encrypts the plaintext with AES-256-GCM, and the random nonce is prepended to the ciphertext.
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts the ciphertext sealed by seal.
+func open(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
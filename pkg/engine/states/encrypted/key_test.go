@@ -0,0 +1,163 @@
+package encrypted
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+const testKeyEnv = "KUSION_TEST_STATE_ENCRYPTION_KEY"
+
+func testAESKeyContent() string {
+	return base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestSplitConfig(t *testing.T) {
+	config := map[string]any{
+		"path":                              "/tmp/kusion_state.yaml",
+		v1.BackendEncryptionKeyFile:         "/tmp/state.key",
+		v1.BackendEncryptionAgeIdentityFile: "/tmp/age.key",
+	}
+	backendConfig, encryptionConfig := SplitConfig(config)
+	assert.Equal(t, map[string]any{"path": "/tmp/kusion_state.yaml"}, backendConfig)
+	assert.Equal(t, map[string]any{
+		v1.BackendEncryptionKeyFile:         "/tmp/state.key",
+		v1.BackendEncryptionAgeIdentityFile: "/tmp/age.key",
+	}, encryptionConfig)
+}
+
+func TestNewKey(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	assert.NoError(t, err)
+	keyFile := writeTestFile(t, "state.key", testAESKeyContent()+"\n")
+	invalidKeyFile := writeTestFile(t, "invalid.key", "too-short")
+	identityFile := writeTestFile(t, "age.key", identity.String()+"\n")
+
+	testcases := []struct {
+		name     string
+		success  bool
+		config   map[string]any
+		keyType  string
+		setEnv   bool
+		expectID string
+	}{
+		{
+			name:    "aes key from file",
+			success: true,
+			config:  map[string]any{v1.BackendEncryptionKeyFile: keyFile},
+			keyType: KeyTypeAES,
+		},
+		{
+			name:    "aes key from environment variable",
+			success: true,
+			config:  map[string]any{v1.BackendEncryptionKeyEnv: testKeyEnv},
+			keyType: KeyTypeAES,
+			setEnv:  true,
+		},
+		{
+			name:     "age key from recipients",
+			success:  true,
+			config:   map[string]any{v1.BackendEncryptionAgeRecipients: identity.Recipient().String()},
+			keyType:  KeyTypeAge,
+			expectID: identity.Recipient().String(),
+		},
+		{
+			name:     "age key from identity file",
+			success:  true,
+			config:   map[string]any{v1.BackendEncryptionAgeIdentityFile: identityFile},
+			keyType:  KeyTypeAge,
+			expectID: identity.Recipient().String(),
+		},
+		{
+			name:    "invalid multiple key sources",
+			success: false,
+			config: map[string]any{
+				v1.BackendEncryptionKeyFile: keyFile,
+				v1.BackendEncryptionKeyEnv:  testKeyEnv,
+			},
+		},
+		{
+			name:    "invalid empty key source",
+			success: false,
+			config:  map[string]any{},
+		},
+		{
+			name:    "invalid aes key",
+			success: false,
+			config:  map[string]any{v1.BackendEncryptionKeyFile: invalidKeyFile},
+		},
+		{
+			name:    "invalid empty environment variable",
+			success: false,
+			config:  map[string]any{v1.BackendEncryptionKeyEnv: testKeyEnv},
+		},
+		{
+			name:    "invalid age recipient",
+			success: false,
+			config:  map[string]any{v1.BackendEncryptionAgeRecipients: "age1invalid"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setEnv {
+				t.Setenv(testKeyEnv, testAESKeyContent())
+			}
+			key, err := NewKey(tc.config)
+			assert.Equal(t, tc.success, err == nil)
+			if tc.success {
+				assert.Equal(t, tc.keyType, key.Type())
+				if tc.expectID != "" {
+					assert.Equal(t, tc.expectID, key.ID())
+				}
+			}
+		})
+	}
+}
+
+func TestKey_WrapUnwrap(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	assert.NoError(t, err)
+	identityFile := writeTestFile(t, "age.key", identity.String())
+	aesKey, err := NewAESKey([]byte(testAESKeyContent()))
+	assert.NoError(t, err)
+	ageKey, err := NewAgeKey("", identityFile)
+	assert.NoError(t, err)
+	recipientOnlyKey, err := NewAgeKey(identity.Recipient().String(), "")
+	assert.NoError(t, err)
+
+	dataKey, err := newDataKey()
+	assert.NoError(t, err)
+	for _, key := range []Key{aesKey, ageKey} {
+		t.Run(key.Type(), func(t *testing.T) {
+			wrapped, err := key.Wrap(dataKey)
+			assert.NoError(t, err)
+			assert.NotEqual(t, dataKey, wrapped)
+			unwrapped, err := key.Unwrap(wrapped)
+			assert.NoError(t, err)
+			assert.Equal(t, dataKey, unwrapped)
+		})
+	}
+
+	t.Run("age recipient without identity", func(t *testing.T) {
+		wrapped, err := recipientOnlyKey.Wrap(dataKey)
+		assert.NoError(t, err)
+		_, err = recipientOnlyKey.Unwrap(wrapped)
+		assert.ErrorIs(t, err, ErrMissingAgeIdentity)
+		unwrapped, err := ageKey.Unwrap(wrapped)
+		assert.NoError(t, err)
+		assert.Equal(t, dataKey, unwrapped)
+	})
+}
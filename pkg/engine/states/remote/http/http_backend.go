@@ -2,10 +2,15 @@ package http
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
+
 	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/util/kfile"
 )
 
 type HTTPBackend struct {
@@ -22,44 +27,60 @@ func (b *HTTPBackend) ConfigSchema() cty.Type {
 		"urlPrefix":          cty.String,
 		"applyURLFormat":     cty.String,
 		"getLatestURLFormat": cty.String,
+		"deleteURLFormat":    cty.String,
+		"historyURLFormat":   cty.String,
+		"listURLFormat":      cty.String,
+		"lockURLFormat":      cty.String,
+		// token is the bearer token, and if useCredentialsToken is "true", the token in the kusion
+		// credentials file is used when token is not set
+		"token":               cty.String,
+		"useCredentialsToken": cty.String,
+		// headers are with the format "Name1:Value1,Name2:Value2"
+		"headers":            cty.String,
+		"caFile":             cty.String,
+		"clientCertFile":     cty.String,
+		"clientKeyFile":      cty.String,
+		"insecureSkipVerify": cty.String,
+		// timeout, retryWaitMin and retryWaitMax are durations such as "30s"
+		"timeout":      cty.String,
+		"retryMax":     cty.String,
+		"retryWaitMin": cty.String,
+		"retryWaitMax": cty.String,
 	}
 	return cty.Object(config)
 }
 
 // Configure is an implementation of StateStorage.Configure
 func (b *HTTPBackend) Configure(obj cty.Value) error {
-	var url cty.Value
-
-	if url = obj.GetAttr("urlPrefix"); url.IsNull() || url.AsString() == "" {
+	if b.urlPrefix = getString(obj, "urlPrefix"); b.urlPrefix == "" {
 		return errors.New("urlPrefix can not be empty")
-	} else {
-		b.urlPrefix = url.AsString()
 	}
-
-	if applyFormat := obj.GetAttr("applyURLFormat"); applyFormat.IsNull() || url.AsString() == "" {
-		return errors.New("applyURLFormat can not be empty")
-	} else {
-		asString := applyFormat.AsString()
-		count := strings.Count(asString, "%s")
-		if count != ParamsCounts {
-			return errors.New("applyURLFormat must contains 4 \"%s\" placeholders for tenant, project, " +
-				"stack and cluster. Current format:" + asString)
-		}
-		b.applyURLFormat = asString
+	var err error
+	if b.applyURLFormat, err = getURLFormat(obj, "applyURLFormat", ParamsCounts, true); err != nil {
+		return err
 	}
-
-	if getLatest := obj.GetAttr("getLatestURLFormat"); getLatest.IsNull() && getLatest.AsString() == "" {
-		return errors.New("getLatestURLFormat can not be empty")
-	} else {
-		asString := getLatest.AsString()
-		count := strings.Count(asString, "%s")
-		if count != ParamsCounts {
-			return errors.New("getLatestURLFormat must contains 4 \"%s\" placeholders for tenant, project, " +
-				"stack or cluster. Current format:" + asString)
-		}
-		b.getLatestURLFormat = asString
+	if b.getLatestURLFormat, err = getURLFormat(obj, "getLatestURLFormat", ParamsCounts, true); err != nil {
+		return err
+	}
+	if b.deleteURLFormat, err = getURLFormat(obj, "deleteURLFormat", 1, false); err != nil {
+		return err
+	}
+	if b.historyURLFormat, err = getURLFormat(obj, "historyURLFormat", ParamsCounts, false); err != nil {
+		return err
+	}
+	if b.listURLFormat, err = getURLFormat(obj, "listURLFormat", 2, false); err != nil {
+		return err
+	}
+	if b.lockURLFormat, err = getURLFormat(obj, "lockURLFormat", ParamsCounts, false); err != nil {
+		return err
 	}
 
+	if b.clientConfig, err = newClientConfig(obj); err != nil {
+		return err
+	}
+	if b.client, err = b.clientConfig.NewClient(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -69,5 +90,91 @@ func (b *HTTPBackend) StateStorage() states.StateStorage {
 		urlPrefix:          b.urlPrefix,
 		applyURLFormat:     b.applyURLFormat,
 		getLatestURLFormat: b.getLatestURLFormat,
+		deleteURLFormat:    b.deleteURLFormat,
+		historyURLFormat:   b.historyURLFormat,
+		listURLFormat:      b.listURLFormat,
+		lockURLFormat:      b.lockURLFormat,
+		clientConfig:       b.clientConfig,
+		client:             b.client,
+	}
+}
+
+// newClientConfig parses the ClientConfig from the backend config, and the unset items use the default value.
+func newClientConfig(obj cty.Value) (*ClientConfig, error) {
+	config := NewDefaultClientConfig()
+	config.Token = getString(obj, "token")
+	useCredentialsToken, err := getBool(obj, "useCredentialsToken")
+	if err != nil {
+		return nil, err
+	}
+	if config.Token == "" && useCredentialsToken {
+		config.Token = kfile.GetCredentialsToken()
+	}
+	if headers := getString(obj, "headers"); headers != "" {
+		if config.Headers, err = ParseHeaders(headers); err != nil {
+			return nil, err
+		}
+	}
+	config.CAFile = getString(obj, "caFile")
+	config.ClientCertFile = getString(obj, "clientCertFile")
+	config.ClientKeyFile = getString(obj, "clientKeyFile")
+	if config.InsecureSkipVerify, err = getBool(obj, "insecureSkipVerify"); err != nil {
+		return nil, err
+	}
+
+	durations := map[string]*time.Duration{
+		"timeout":      &config.Timeout,
+		"retryWaitMin": &config.RetryWaitMin,
+		"retryWaitMax": &config.RetryWaitMax,
+	}
+	for key, d := range durations {
+		if s := getString(obj, key); s != "" {
+			if *d, err = time.ParseDuration(s); err != nil || *d < 0 {
+				return nil, fmt.Errorf("invalid %s %s, should be a non-negative duration such as 30s", key, s)
+			}
+		}
+	}
+	if config.RetryWaitMin > config.RetryWaitMax {
+		return nil, errors.New("retryWaitMin can not be greater than retryWaitMax")
+	}
+	if s := getString(obj, "retryMax"); s != "" {
+		if config.RetryMax, err = strconv.Atoi(s); err != nil || config.RetryMax < 0 {
+			return nil, fmt.Errorf("invalid retryMax %s, should be a non-negative integer", s)
+		}
+	}
+	return config, nil
+}
+
+// getURLFormat gets the url format and checks the count of its "%s" placeholders.
+func getURLFormat(obj cty.Value, key string, paramsCount int, required bool) (string, error) {
+	format := getString(obj, key)
+	if format == "" {
+		if required {
+			return "", fmt.Errorf("%s can not be empty", key)
+		}
+		return "", nil
+	}
+	if count := strings.Count(format, "%s"); count != paramsCount {
+		return "", fmt.Errorf("%s must contains %d \"%%s\" placeholders. Current format:%s", key, paramsCount, format)
+	}
+	return format, nil
+}
+
+func getString(obj cty.Value, key string) string {
+	if v := obj.GetAttr(key); !v.IsNull() {
+		return v.AsString()
+	}
+	return ""
+}
+
+func getBool(obj cty.Value, key string) (bool, error) {
+	s := getString(obj, key)
+	if s == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %s, should be true or false", key, s)
 	}
+	return b, nil
 }
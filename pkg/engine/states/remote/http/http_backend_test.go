@@ -4,8 +4,12 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
+
+	"kusionstack.io/kusion/pkg/util/kfile"
 )
 
 func TestHttpBackend_ConfigSchema(t *testing.T) {
@@ -16,9 +20,24 @@ func TestHttpBackend_ConfigSchema(t *testing.T) {
 		{
 			name: "t1",
 			want: cty.Object(map[string]cty.Type{
-				"urlPrefix":          cty.String,
-				"applyURLFormat":     cty.String,
-				"getLatestURLFormat": cty.String,
+				"urlPrefix":           cty.String,
+				"applyURLFormat":      cty.String,
+				"getLatestURLFormat":  cty.String,
+				"deleteURLFormat":     cty.String,
+				"historyURLFormat":    cty.String,
+				"listURLFormat":       cty.String,
+				"lockURLFormat":       cty.String,
+				"token":               cty.String,
+				"useCredentialsToken": cty.String,
+				"headers":             cty.String,
+				"caFile":              cty.String,
+				"clientCertFile":      cty.String,
+				"clientKeyFile":       cty.String,
+				"insecureSkipVerify":  cty.String,
+				"timeout":             cty.String,
+				"retryMax":            cty.String,
+				"retryWaitMin":        cty.String,
+				"retryWaitMax":        cty.String,
 			}),
 		},
 	}
@@ -52,6 +71,87 @@ func TestHttpBackend_Configure(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "full config",
+			args: args{
+				config: map[string]interface{}{
+					"urlPrefix":          "kusion-url",
+					"applyURLFormat":     DefaultApplyURLFormat,
+					"getLatestURLFormat": DefaultGetLatestURLFormat,
+					"deleteURLFormat":    DefaultDeleteURLFormat,
+					"historyURLFormat":   DefaultHistoryURLFormat,
+					"listURLFormat":      DefaultListURLFormat,
+					"lockURLFormat":      DefaultLockURLFormat,
+					"token":              "kusion-token",
+					"headers":            "X-Kusion-Tenant:t,X-Kusion-Source:cli",
+					"insecureSkipVerify": "true",
+					"timeout":            "10s",
+					"retryMax":           "5",
+					"retryWaitMin":       "100ms",
+					"retryWaitMax":       "2s",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty urlPrefix",
+			args: args{
+				config: map[string]interface{}{
+					"applyURLFormat":     DefaultApplyURLFormat,
+					"getLatestURLFormat": DefaultGetLatestURLFormat,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid deleteURLFormat",
+			args: args{
+				config: map[string]interface{}{
+					"urlPrefix":          "kusion-url",
+					"applyURLFormat":     DefaultApplyURLFormat,
+					"getLatestURLFormat": DefaultGetLatestURLFormat,
+					"deleteURLFormat":    DefaultApplyURLFormat,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			args: args{
+				config: map[string]interface{}{
+					"urlPrefix":          "kusion-url",
+					"applyURLFormat":     DefaultApplyURLFormat,
+					"getLatestURLFormat": DefaultGetLatestURLFormat,
+					"timeout":            "10",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid retry wait",
+			args: args{
+				config: map[string]interface{}{
+					"urlPrefix":          "kusion-url",
+					"applyURLFormat":     DefaultApplyURLFormat,
+					"getLatestURLFormat": DefaultGetLatestURLFormat,
+					"retryWaitMin":       "10s",
+					"retryWaitMax":       "1s",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not existed ca file",
+			args: args{
+				config: map[string]interface{}{
+					"urlPrefix":          "kusion-url",
+					"applyURLFormat":     DefaultApplyURLFormat,
+					"getLatestURLFormat": DefaultGetLatestURLFormat,
+					"caFile":             "not_exist_ca.pem",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,3 +163,21 @@ func TestHttpBackend_Configure(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpBackend_ConfigureCredentialsToken(t *testing.T) {
+	mockey.PatchConvey("use credentials token", t, func() {
+		mockey.Mock(kfile.GetCredentialsToken).Return("credentials-token").Build()
+		b := &HTTPBackend{}
+		obj, err := gocty.ToCtyValue(map[string]interface{}{
+			"urlPrefix":           "kusion-url",
+			"applyURLFormat":      DefaultApplyURLFormat,
+			"getLatestURLFormat":  DefaultGetLatestURLFormat,
+			"useCredentialsToken": "true",
+		}, b.ConfigSchema())
+		assert.NoError(t, err)
+		assert.NoError(t, b.Configure(obj))
+		assert.Equal(t, "credentials-token", b.clientConfig.Token)
+		assert.Equal(t, DefaultRetryMax, b.clientConfig.RetryMax)
+		assert.Equal(t, DefaultTimeout, b.client.Timeout)
+	})
+}
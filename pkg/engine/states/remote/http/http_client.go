@@ -0,0 +1,124 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultRetryMax     = 3
+	DefaultRetryWaitMin = time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// ClientConfig contains the configs of the http client to request the state service.
+type ClientConfig struct {
+	// Token is the bearer token set in the Authorization header
+	Token string
+	// Headers are the custom headers set in every request
+	Headers map[string]string
+	// CAFile is the PEM encoded CA bundle to verify the server certificate
+	CAFile string
+	// ClientCertFile and ClientKeyFile are the PEM encoded client certificate and key for mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+	// InsecureSkipVerify disables the verification of the server certificate
+	InsecureSkipVerify bool
+	// Timeout is the time limit of each request, including the retried ones
+	Timeout time.Duration
+	// RetryMax is the max retry times when the idempotent request failed by network error, 429 or 5xx status code,
+	// and applying the state is never retried
+	RetryMax int
+	// RetryWaitMin and RetryWaitMax are the min and max wait time between retries, where the wait time
+	// is doubled each retry
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+}
+
+// NewDefaultClientConfig returns the ClientConfig with the default timeout and retry policy.
+func NewDefaultClientConfig() *ClientConfig {
+	return &ClientConfig{
+		Timeout:      DefaultTimeout,
+		RetryMax:     DefaultRetryMax,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
+	}
+}
+
+// NewClient news a http client with the TLS config and timeout.
+func (c *ClientConfig) NewClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec
+	}
+	if c.CAFile != "" {
+		ca, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca file %s failed: %w", c.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificate in ca file %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		if c.ClientCertFile == "" || c.ClientKeyFile == "" {
+			return nil, errors.New("clientCertFile and clientKeyFile must be configured together")
+		}
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate failed: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{
+		Transport: transport,
+		Timeout:   c.Timeout,
+	}, nil
+}
+
+// ParseHeaders parses the headers with the format "Name1:Value1,Name2:Value2".
+func ParseHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		kv := strings.SplitN(item, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid header %s, should be with format Name:Value", item)
+		}
+		headers[http.CanonicalHeaderKey(strings.TrimSpace(kv[0]))] = strings.TrimSpace(kv[1])
+	}
+	return headers, nil
+}
+
+// backoff returns the wait time before the attempt-th (starting from 1) retry.
+func (c *ClientConfig) backoff(attempt int) time.Duration {
+	wait := c.RetryWaitMin
+	for i := 1; i < attempt && wait < c.RetryWaitMax; i++ {
+		wait *= 2
+	}
+	if wait > c.RetryWaitMax {
+		wait = c.RetryWaitMax
+	}
+	return wait
+}
+
+// shouldRetry returns whether to retry the request by the response status code or the error.
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
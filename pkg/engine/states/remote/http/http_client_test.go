@@ -0,0 +1,57 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders("x-kusion-tenant: t, X-Kusion-Source:cli,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"X-Kusion-Tenant": "t", "X-Kusion-Source": "cli"}, headers)
+
+	_, err = ParseHeaders("X-Kusion-Tenant")
+	assert.Error(t, err)
+	_, err = ParseHeaders(":t")
+	assert.Error(t, err)
+}
+
+func TestClientConfig_backoff(t *testing.T) {
+	config := &ClientConfig{RetryWaitMin: time.Second, RetryWaitMax: 5 * time.Second}
+	assert.Equal(t, time.Second, config.backoff(1))
+	assert.Equal(t, 2*time.Second, config.backoff(2))
+	assert.Equal(t, 4*time.Second, config.backoff(3))
+	assert.Equal(t, 5*time.Second, config.backoff(4))
+	assert.Equal(t, 5*time.Second, config.backoff(10))
+}
+
+func TestShouldRetry(t *testing.T) {
+	assert.True(t, shouldRetry(nil, errors.New("connection refused")))
+	assert.True(t, shouldRetry(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.True(t, shouldRetry(&http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.False(t, shouldRetry(&http.Response{StatusCode: http.StatusNotFound}, nil))
+}
+
+func TestClientConfig_NewClient(t *testing.T) {
+	config := NewDefaultClientConfig()
+	client, err := config.NewClient()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultTimeout, client.Timeout)
+
+	invalidCA := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(invalidCA, []byte("invalid"), 0o600))
+	config.CAFile = invalidCA
+	_, err = config.NewClient()
+	assert.Error(t, err)
+
+	config.CAFile = ""
+	config.ClientCertFile = "client.pem"
+	_, err = config.NewClient()
+	assert.Error(t, err)
+}
@@ -1,17 +1,25 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"kusionstack.io/kusion/pkg/engine/states"
 	"kusionstack.io/kusion/pkg/log"
 )
 
+var (
+	_ states.HistoryStateStorage = &HTTPState{}
+
+	ErrNotSupported = errors.New("not supported")
+)
+
 // HTTPState represent a remote state that can be requested by HTTP.
 // This state is designed to provide a generic way to manipulate State in third-party services
 //
@@ -28,49 +36,109 @@ import (
 //		stack = "s"
 //	 cluster = "c"
 //		the final request URL = "http://kusionstack.io/apis/v1/tenants/t/projects/p/stacks/s/clusters/c/states"
+//
+// Except the deleteURLFormat with 1 "%s" placeholder for the state id, and the listURLFormat with 2 "%s"
+// placeholders for tenant and project. The optional endpoints return ErrNotSupported if their url formats
+// are not configured. Please check openapi.yaml in this package for the contract of the endpoints.
 type HTTPState struct {
 	// urlPrefix is the prefix added in front of all request URLs. e.g. "http://kusionstack.io/"
 	urlPrefix string
-
 	// applyURLFormat is the suffix url format to apply a state
 	applyURLFormat string
-
 	// getLatestURLFormat is the suffix url format to get the latest state
 	getLatestURLFormat string
+	// deleteURLFormat is the optional suffix url format to delete a state by id
+	deleteURLFormat string
+	// historyURLFormat is the optional suffix url format to get the history states
+	historyURLFormat string
+	// listURLFormat is the optional suffix url format to list the latest states of a project
+	listURLFormat string
+	// lockURLFormat is the optional suffix url format to lock and unlock the state
+	lockURLFormat string
+
+	// clientConfig contains the auth, headers and retry policy of the requests
+	clientConfig *ClientConfig
+	// client is the http client with the TLS config, use http.DefaultClient if nil
+	client *http.Client
 }
 
 const ParamsCounts = 4
 
+// The url formats of the endpoints implemented by the reference server in package server.
+const (
+	DefaultApplyURLFormat     = "/apis/v1/tenants/%s/projects/%s/stacks/%s/clusters/%s/states/"
+	DefaultGetLatestURLFormat = "/apis/v1/tenants/%s/projects/%s/stacks/%s/clusters/%s/states/"
+	DefaultHistoryURLFormat   = "/apis/v1/tenants/%s/projects/%s/stacks/%s/clusters/%s/states/history"
+	DefaultLockURLFormat      = "/apis/v1/tenants/%s/projects/%s/stacks/%s/clusters/%s/lock"
+	DefaultListURLFormat      = "/apis/v1/tenants/%s/projects/%s/states"
+	DefaultDeleteURLFormat    = "/apis/v1/states/%s"
+)
+
+// LockInfo describes the lock of a state.
+type LockInfo struct {
+	// ID identifies the lock, which is used to unlock
+	ID string `json:"id"`
+	// Operator is the person who holds the lock
+	Operator string `json:"operator,omitempty"`
+	// Info is the additional information of the lock, such as the operation
+	Info string `json:"info,omitempty"`
+	// Created is the time the lock is created
+	Created time.Time `json:"created"`
+}
+
+// LockError is returned when the state is locked by another lock.
+type LockError struct {
+	// Holder is the lock held, nil if the server does not return it
+	Holder *LockInfo
+}
+
+func (e *LockError) Error() string {
+	if e.Holder == nil {
+		return "state is locked"
+	}
+	return fmt.Sprintf("state is locked by lock %s of operator %s since %s", e.Holder.ID, e.Holder.Operator, e.Holder.Created.Format(time.RFC3339))
+}
+
 // GetLatestState is an implementation of StateStorage.GetLatestState
 func (s *HTTPState) GetLatestState(query *states.StateQuery) (*states.State, error) {
 	url := fmt.Sprintf("%s"+s.getLatestURLFormat, s.urlPrefix, query.Tenant, query.Project, query.Stack, query.Cluster)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	res, err := http.DefaultClient.Do(req)
+	res, err := s.do(http.MethodGet, url, nil, true)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
-
-	if res.StatusCode == 404 {
-		log.Info("Can't find the latest state by request:%s", url)
+	if res.StatusCode == http.StatusNotFound {
+		log.Infof("Can't find the latest state by request:%s", url)
 		return nil, nil
 	}
-	if res.StatusCode != 200 {
+	if res.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("get the latest state failed. StatusCode:%v, Status:%s", res.StatusCode, res.Status)
 	}
-
 	state := &states.State{}
-	resBody, _ := io.ReadAll(res.Body)
-	err = json.Unmarshal(resBody, state)
-	if err != nil {
+	if err = decodeBody(res, state); err != nil {
 		return nil, err
 	}
 	return state, nil
 }
 
+// GetHistoryStates is an implementation of HistoryStateStorage.GetHistoryStates
+func (s *HTTPState) GetHistoryStates(query *states.StateQuery) ([]*states.State, error) {
+	if s.historyURLFormat == "" {
+		return nil, states.ErrHistoryNotSupported
+	}
+	url := fmt.Sprintf("%s"+s.historyURLFormat, s.urlPrefix, query.Tenant, query.Project, query.Stack, query.Cluster)
+	return s.getStates(url, "get the history states")
+}
+
+// ListStates lists the latest states of all the stacks and clusters in the project.
+func (s *HTTPState) ListStates(tenant, project string) ([]*states.State, error) {
+	if s.listURLFormat == "" {
+		return nil, ErrNotSupported
+	}
+	url := fmt.Sprintf("%s"+s.listURLFormat, s.urlPrefix, tenant, project)
+	return s.getStates(url, "list states")
+}
+
 // Apply is an implementation of StateStorage.Apply
 func (s *HTTPState) Apply(state *states.State) error {
 	jsonState, err := json.Marshal(state)
@@ -78,25 +146,187 @@ func (s *HTTPState) Apply(state *states.State) error {
 		return err
 	}
 	url := fmt.Sprintf("%s"+s.applyURLFormat, s.urlPrefix, state.Tenant, state.Project, state.Stack, state.Cluster)
+	// applying is not idempotent, for the state is appended to the history by each request
+	res, err := s.do(http.MethodPost, url, jsonState, false)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("apply state failed. StatusCode:%v, Status:%s", res.StatusCode, res.Status)
+	}
+	return nil
+}
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(string(jsonState)))
+// Delete is an implementation of StateStorage.Delete
+func (s *HTTPState) Delete(id string) error {
+	if s.deleteURLFormat == "" {
+		return ErrNotSupported
+	}
+	url := fmt.Sprintf("%s"+s.deleteURLFormat, s.urlPrefix, id)
+	res, err := s.do(http.MethodDelete, url, nil, true)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	res, err := http.DefaultClient.Do(req)
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete state failed. StatusCode:%v, Status:%s", res.StatusCode, res.Status)
+	}
+	return nil
+}
+
+// Lock locks the state of the query, and returns LockError if the state is locked by another lock.
+func (s *HTTPState) Lock(query *states.StateQuery, lock *LockInfo) error {
+	res, err := s.doLock(http.MethodPost, query, lock)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode != 200 {
-		return fmt.Errorf("apply state failed. StatusCode:%v, Status:%s", res.StatusCode, res.Status)
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusConflict, http.StatusLocked:
+		holder := &LockInfo{}
+		if err = decodeBody(res, holder); err != nil || holder.ID == "" {
+			return &LockError{}
+		}
+		// the lock is acquired by the previous request whose response is lost
+		if holder.ID == lock.ID {
+			return nil
+		}
+		return &LockError{Holder: holder}
+	default:
+		return fmt.Errorf("lock state failed. StatusCode:%v, Status:%s", res.StatusCode, res.Status)
+	}
+}
+
+// Unlock unlocks the state of the query, the lock id must be the same as the one used to lock.
+func (s *HTTPState) Unlock(query *states.StateQuery, lock *LockInfo) error {
+	res, err := s.doLock(http.MethodDelete, query, lock)
+	if err != nil {
+		return err
 	}
 	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	case http.StatusConflict, http.StatusLocked:
+		holder := &LockInfo{}
+		if err = decodeBody(res, holder); err != nil || holder.ID == "" {
+			return &LockError{}
+		}
+		return &LockError{Holder: holder}
+	default:
+		return fmt.Errorf("unlock state failed. StatusCode:%v, Status:%s", res.StatusCode, res.Status)
+	}
+}
 
-	return nil
+func (s *HTTPState) doLock(method string, query *states.StateQuery, lock *LockInfo) (*http.Response, error) {
+	if s.lockURLFormat == "" {
+		return nil, ErrNotSupported
+	}
+	body, err := json.Marshal(lock)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s"+s.lockURLFormat, s.urlPrefix, query.Tenant, query.Project, query.Stack, query.Cluster)
+	// both locking and unlocking are idempotent for the lock id, where the conflict with the same lock id is
+	// regarded as acquired
+	return s.do(method, url, body, true)
 }
 
-// Delete is not support now
-func (s *HTTPState) Delete(id string) error {
-	return errors.New("not supported")
+func (s *HTTPState) getStates(url, action string) ([]*states.State, error) {
+	res, err := s.do(http.MethodGet, url, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s failed. StatusCode:%v, Status:%s", action, res.StatusCode, res.Status)
+	}
+	var result []*states.State
+	if err = decodeBody(res, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// do sends the request with the auth and custom headers. The idempotent request is retried with exponential
+// backoff when it failed by network error, 429 or 5xx status code, while the others are sent only once, for the
+// failed one may have been handled by the server.
+func (s *HTTPState) do(method, url string, body []byte, idempotent bool) (*http.Response, error) {
+	config := s.clientConfig
+	if config == nil {
+		config = &ClientConfig{}
+	}
+	client := s.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var res *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(config.backoff(attempt))
+		}
+		var req *http.Request
+		if req, err = newRequest(method, url, body, config); err != nil {
+			return nil, err
+		}
+		res, err = client.Do(req)
+		if !idempotent || attempt >= config.RetryMax || !shouldRetry(res, err) {
+			break
+		}
+		if err == nil {
+			// drain the body to reuse the connection
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			log.Infof("request %s %s failed with status %s, retrying", method, url, res.Status)
+		} else {
+			log.Infof("request %s %s failed: %v, retrying", method, url, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res.Body == nil {
+		res.Body = http.NoBody
+	}
+	return res, nil
+}
+
+func newRequest(method, url string, body []byte, config *ClientConfig) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range config.Headers {
+		req.Header.Set(k, v)
+	}
+	if config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+config.Token)
+	}
+	return req, nil
+}
+
+func decodeBody(res *http.Response, v any) error {
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(resBody))) == 0 {
+		return errors.New("empty response body")
+	}
+	return json.Unmarshal(resBody, v)
 }
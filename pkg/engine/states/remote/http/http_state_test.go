@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bytedance/mockey"
 	"github.com/stretchr/testify/assert"
@@ -186,3 +188,55 @@ func TestHTTPState_GetLatestState(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPState_NotSupported(t *testing.T) {
+	s := &HTTPState{urlPrefix: prefix, applyURLFormat: format, getLatestURLFormat: format}
+	assert.ErrorIs(t, s.Delete("1"), ErrNotSupported)
+	_, err := s.GetHistoryStates(&states.StateQuery{})
+	assert.ErrorIs(t, err, states.ErrHistoryNotSupported)
+	_, err = s.ListStates("t", "p")
+	assert.ErrorIs(t, err, ErrNotSupported)
+	assert.ErrorIs(t, s.Lock(&states.StateQuery{}, &LockInfo{ID: "1"}), ErrNotSupported)
+}
+
+func TestHTTPState_Retry(t *testing.T) {
+	var count int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		assert.Equal(t, "cli", r.Header.Get("X-Kusion-Source"))
+		if count < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	s := &HTTPState{
+		urlPrefix:          ts.URL,
+		applyURLFormat:     format,
+		getLatestURLFormat: format,
+		clientConfig: &ClientConfig{
+			Headers:      map[string]string{"X-Kusion-Source": "cli"},
+			RetryMax:     2,
+			RetryWaitMin: time.Millisecond,
+			RetryWaitMax: time.Millisecond,
+		},
+	}
+	_, err := s.GetLatestState(&states.StateQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count = 0
+	s.clientConfig.RetryMax = 1
+	_, err = s.GetLatestState(&states.StateQuery{})
+	assert.Error(t, err)
+	assert.Equal(t, 2, count)
+
+	// applying the state is not idempotent and never retried
+	count = 0
+	s.clientConfig.RetryMax = 2
+	assert.Error(t, s.Apply(states.NewState()))
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,220 @@
+// Package server provides a reference implementation of the http state service, which stores the states
+// in memory. It follows the contract in openapi.yaml of package http, and is intended for local testing.
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kusionstack.io/kusion/pkg/engine/states"
+	httpstate "kusionstack.io/kusion/pkg/engine/states/remote/http"
+)
+
+const apiPrefix = "/apis/v1/"
+
+// Server is an in-memory http state service.
+type Server struct {
+	// token is the required bearer token, no auth if empty
+	token string
+
+	mu     sync.Mutex
+	nextID int64
+	// history contains the states of each stack and cluster in ascending order of serial
+	history map[stateKey][]*states.State
+	locks   map[stateKey]*httpstate.LockInfo
+}
+
+type stateKey struct {
+	tenant, project, stack, cluster string
+}
+
+// NewServer news a Server, which requires the bearer token if not empty.
+func NewServer(token string) *Server {
+	return &Server{
+		token:   token,
+		nextID:  1,
+		history: make(map[stateKey][]*states.State),
+		locks:   make(map[stateKey]*httpstate.LockInfo),
+	}
+}
+
+// ServeHTTP routes the requests to the endpoints.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+
+	switch {
+	// states/{id}
+	case len(segments) == 2 && segments[0] == "states":
+		s.handleDelete(w, r, segments[1])
+	// tenants/{tenant}/projects/{project}/states
+	case len(segments) == 5 && segments[0] == "tenants" && segments[2] == "projects" && segments[4] == "states":
+		s.handleList(w, r, segments[1], segments[3])
+	// tenants/{tenant}/projects/{project}/stacks/{stack}/clusters/{cluster}/...
+	case len(segments) >= 9 && segments[0] == "tenants" && segments[2] == "projects" && segments[4] == "stacks" && segments[6] == "clusters":
+		key := stateKey{tenant: segments[1], project: segments[3], stack: segments[5], cluster: segments[7]}
+		switch strings.Join(segments[8:], "/") {
+		case "states":
+			s.handleStates(w, r, key)
+		case "states/history":
+			s.handleHistory(w, r, key)
+		case "lock":
+			s.handleLock(w, r, key)
+		default:
+			http.NotFound(w, r)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleStates(w http.ResponseWriter, r *http.Request, key stateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		history := s.history[key]
+		if len(history) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, history[len(history)-1])
+	case http.MethodPost:
+		state := &states.State{}
+		if err := json.NewDecoder(r.Body).Decode(state); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		now := time.Now().UTC()
+		state.ID = s.nextID
+		s.nextID++
+		if state.CreateTime.IsZero() {
+			state.CreateTime = now
+		}
+		state.ModifiedTime = now
+		history := append(s.history[key], state)
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].Serial < history[j].Serial
+		})
+		s.history[key] = history
+		writeJSON(w, http.StatusOK, state)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request, key stateKey) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history := s.history[key]
+	if history == nil {
+		history = []*states.State{}
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request, tenant, project string) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []*states.State{}
+	for key, history := range s.history {
+		if key.tenant == tenant && key.project == project && len(history) != 0 {
+			result = append(result, history[len(history)-1])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Stack != result[j].Stack {
+			return result[i].Stack < result[j].Stack
+		}
+		return result[i].Cluster < result[j].Cluster
+	})
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	stateID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid state id", http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, history := range s.history {
+		for i, state := range history {
+			if state.ID == stateID {
+				s.history[key] = append(history[:i], history[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (s *Server) handleLock(w http.ResponseWriter, r *http.Request, key stateKey) {
+	lock := &httpstate.LockInfo{}
+	if err := json.NewDecoder(r.Body).Decode(lock); err != nil || lock.ID == "" {
+		http.Error(w, "invalid lock", http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	holder := s.locks[key]
+	switch r.Method {
+	case http.MethodPost:
+		if holder != nil {
+			writeJSON(w, http.StatusConflict, holder)
+			return
+		}
+		if lock.Created.IsZero() {
+			lock.Created = time.Now().UTC()
+		}
+		s.locks[key] = lock
+		writeJSON(w, http.StatusOK, lock)
+	case http.MethodDelete:
+		if holder == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if holder.ID != lock.ID {
+			writeJSON(w, http.StatusConflict, holder)
+			return
+		}
+		delete(s.locks, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"kusionstack.io/kusion/pkg/engine/states"
+	httpstate "kusionstack.io/kusion/pkg/engine/states/remote/http"
+)
+
+func newTestState(t *testing.T, url, token string) *httpstate.HTTPState {
+	b := httpstate.NewHTTPBackend()
+	obj, err := gocty.ToCtyValue(map[string]interface{}{
+		"urlPrefix":          url,
+		"applyURLFormat":     httpstate.DefaultApplyURLFormat,
+		"getLatestURLFormat": httpstate.DefaultGetLatestURLFormat,
+		"deleteURLFormat":    httpstate.DefaultDeleteURLFormat,
+		"historyURLFormat":   httpstate.DefaultHistoryURLFormat,
+		"listURLFormat":      httpstate.DefaultListURLFormat,
+		"lockURLFormat":      httpstate.DefaultLockURLFormat,
+		"token":              token,
+		"retryMax":           "0",
+	}, b.ConfigSchema())
+	assert.NoError(t, err)
+	assert.NoError(t, b.Configure(obj))
+	return b.StateStorage().(*httpstate.HTTPState)
+}
+
+func TestServer(t *testing.T) {
+	ts := httptest.NewServer(NewServer("kusion-token"))
+	defer ts.Close()
+	s := newTestState(t, ts.URL, "kusion-token")
+	query := &states.StateQuery{Project: "p", Stack: "s"}
+
+	latest, err := s.GetLatestState(query)
+	assert.NoError(t, err)
+	assert.Nil(t, latest)
+
+	for _, serial := range []uint64{1, 2} {
+		state := states.NewState()
+		state.Project = "p"
+		state.Stack = "s"
+		state.Serial = serial
+		assert.NoError(t, s.Apply(state))
+	}
+	latest, err = s.GetLatestState(query)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), latest.Serial)
+
+	history, err := s.GetHistoryStates(query)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	list, err := s.ListStates("", "p")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	assert.NoError(t, s.Delete(fmt.Sprint(latest.ID)))
+	// deleting a state that does not exist is idempotent
+	assert.NoError(t, s.Delete(fmt.Sprint(latest.ID)))
+	latest, err = s.GetLatestState(query)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), latest.Serial)
+
+	lock := &httpstate.LockInfo{ID: "lock-1", Operator: "kusion"}
+	assert.NoError(t, s.Lock(query, lock))
+	// lock again with the same id is regarded as acquired
+	assert.NoError(t, s.Lock(query, lock))
+	err = s.Lock(query, &httpstate.LockInfo{ID: "lock-2"})
+	var lockErr *httpstate.LockError
+	assert.ErrorAs(t, err, &lockErr)
+	assert.Equal(t, "lock-1", lockErr.Holder.ID)
+	assert.Error(t, s.Unlock(query, &httpstate.LockInfo{ID: "lock-2"}))
+	assert.NoError(t, s.Unlock(query, lock))
+	assert.NoError(t, s.Lock(query, &httpstate.LockInfo{ID: "lock-2"}))
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	ts := httptest.NewServer(NewServer("kusion-token"))
+	defer ts.Close()
+
+	s := newTestState(t, ts.URL, "invalid")
+	_, err := s.GetLatestState(&states.StateQuery{Project: "p", Stack: "s"})
+	assert.Error(t, err)
+
+	res, err := http.Get(ts.URL + "/apis/v1/unknown")
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
@@ -10,6 +10,8 @@ import (
 	"kusionstack.io/kusion/pkg/engine/states"
 )
 
+// MysqlBackend stores the states in the table state of the MySQL database. To store the encrypted states in the
+// table created before, EncryptionColumnMigration must be applied first.
 type MysqlBackend struct {
 	MysqlState
 }
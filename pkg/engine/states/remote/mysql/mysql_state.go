@@ -8,7 +8,7 @@ import (
 	"sort"
 
 	"github.com/didi/gendry/scanner"
-	_ "github.com/go-sql-driver/mysql"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/copier"
 	"gopkg.in/yaml.v3"
 
@@ -20,7 +20,17 @@ import (
 	jsonutil "kusionstack.io/kusion/pkg/util/json"
 )
 
-var _ states.StateStorage = &MysqlState{}
+var _ states.HistoryStateStorage = &MysqlState{}
+
+// EncryptionColumnMigration adds the column encryption into the table state created before the encryption at
+// rest is supported, which must be applied before storing the encrypted states. The column is nullable, so the
+// states which are not encrypted are inserted without it as before.
+const EncryptionColumnMigration = "ALTER TABLE state ADD COLUMN encryption LONGTEXT NULL"
+
+// errUnknownColumn is the error number of MySQL when inserting into an unknown column.
+const errUnknownColumn = 1054
+
+var ErrMissingEncryptionColumn = fmt.Errorf("column encryption is missing in table state to store the encrypted state, please apply the migration: %s", EncryptionColumnMigration)
 
 func NewDBState() states.StateStorage {
 	result := &MysqlState{}
@@ -40,6 +50,10 @@ func (s *MysqlState) Apply(state *states.State) error {
 	err = json.Unmarshal(marshal, &m)
 	util.CheckNotError(err, fmt.Sprintf("unmarshal state failed:%+v", marshal))
 	m["resources"] = jsonutil.MustMarshal2String(m["resources"])
+	// the encryption column is only required when the state is encrypted
+	if state.Encryption != nil {
+		m["encryption"] = jsonutil.MustMarshal2String(m["encryption"])
+	}
 	// convert the camel case formatted key to underscore formatted key
 	// before we insert the state data into the database
 	m["kusion_version"] = m["kusionVersion"]
@@ -50,15 +64,60 @@ func (s *MysqlState) Apply(state *states.State) error {
 	// id should be an auto-increment key, we also ignore here
 	delete(m, "id")
 	id, err := mapper.Insert(s.DB, []map[string]interface{}{m})
+	if state.Encryption != nil && isUnknownColumnError(err) {
+		return ErrMissingEncryptionColumn
+	}
 	state.ID = id
 	return err
 }
 
+func isUnknownColumnError(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errUnknownColumn
+}
+
 func (s *MysqlState) Delete(id string) error {
 	panic("implement me")
 }
 
 func (s *MysqlState) GetLatestState(q *states.StateQuery) (*states.State, error) {
+	where, err := queryCondition(q)
+	if err != nil {
+		return nil, err
+	}
+	where["_orderby"] = "serial desc"
+
+	stateDO, err := mapper.GetOne(s.DB, where)
+	if errors.Is(err, scanner.ErrEmptyResult) {
+		return nil, nil
+	}
+	res := do2Bo(stateDO)
+	return res, err
+}
+
+// GetHistoryStates returns all the states stored by add-only strategy in ascending order of serial.
+func (s *MysqlState) GetHistoryStates(q *states.StateQuery) ([]*states.State, error) {
+	where, err := queryCondition(q)
+	if err != nil {
+		return nil, err
+	}
+	where["_orderby"] = "serial asc"
+
+	stateDOs, err := mapper.GetAll(s.DB, where)
+	if errors.Is(err, scanner.ErrEmptyResult) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*states.State, 0, len(stateDOs))
+	for _, stateDO := range stateDOs {
+		res = append(res, do2Bo(stateDO))
+	}
+	return res, nil
+}
+
+func queryCondition(q *states.StateQuery) (map[string]interface{}, error) {
 	where := make(map[string]interface{})
 
 	if len(q.Project) == 0 {
@@ -79,14 +138,7 @@ func (s *MysqlState) GetLatestState(q *states.StateQuery) (*states.State, error)
 	if len(q.Cluster) != 0 {
 		where["cluster"] = q.Cluster
 	}
-	where["_orderby"] = "serial desc"
-
-	stateDO, err := mapper.GetOne(s.DB, where)
-	if errors.Is(err, scanner.ErrEmptyResult) {
-		return nil, nil
-	}
-	res := do2Bo(stateDO)
-	return res, err
+	return where, nil
 }
 
 func do2Bo(dbState *mapper.StateDO) *states.State {
@@ -100,5 +152,10 @@ func do2Bo(dbState *mapper.StateDO) *states.State {
 	util.CheckNotError(e,
 		fmt.Sprintf("copy db_state to State failed. db_state:%v", jsonutil.MustMarshal2String(dbState)))
 	res.Resources = resStateList
+	if dbState.EncryptionInfo != "" {
+		res.Encryption = &states.Encryption{}
+		parseErr = yaml.Unmarshal([]byte(dbState.EncryptionInfo), res.Encryption)
+		util.CheckNotError(parseErr, fmt.Sprintf("marshall stateDO.encryption failed:%v", dbState.EncryptionInfo))
+	}
 	return res
 }
@@ -7,7 +7,7 @@ import (
 
 	"github.com/bytedance/mockey"
 	"github.com/didi/gendry/manager"
-	_ "github.com/go-sql-driver/mysql"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 
 	"kusionstack.io/kusion/pkg/engine/dal/mapper"
@@ -44,6 +44,10 @@ func DBStateSetUp(t *testing.T) *MysqlState {
 		return stateDo, nil
 	}).Build()
 
+	mockey.Mock(mapper.GetAll).To(func(db *sql.DB, where map[string]interface{}) ([]*mapper.StateDO, error) {
+		return []*mapper.StateDO{stateDo}, nil
+	}).Build()
+
 	mockey.Mock(mapper.Insert).To(func(db *sql.DB, data []map[string]interface{}) (int64, error) {
 		return 1, nil
 	}).Build()
@@ -58,6 +62,13 @@ func TestDBState(t *testing.T) {
 		_, err := dbState.GetLatestState(&states.StateQuery{Tenant: "test_global_tenant", Stack: "test_env", Project: "test_project"})
 		assert.NoError(t, err)
 
+		historyStates, err := dbState.GetHistoryStates(&states.StateQuery{Tenant: "test_global_tenant", Stack: "test_env", Project: "test_project"})
+		assert.NoError(t, err)
+		assert.Len(t, historyStates, 1)
+
+		_, err = dbState.GetHistoryStates(&states.StateQuery{Stack: "test_env"})
+		assert.Error(t, err)
+
 		state := &states.State{Tenant: "test_global_tenant", Project: "test_project", Stack: "test_env", KusionVersion: "1.0.3"}
 		err = dbState.Apply(state)
 		assert.NoError(t, err)
@@ -71,6 +82,20 @@ func TestDBState(t *testing.T) {
 	})
 }
 
+func TestDBState_ApplyWithoutEncryptionColumn(t *testing.T) {
+	mockey.PatchConvey("insert into table state without column encryption", t, func() {
+		unknownColumnErr := &mysqldriver.MySQLError{Number: 1054, Message: "Unknown column 'encryption' in 'field list'"}
+		mockey.Mock(mapper.Insert).Return(int64(0), unknownColumnErr).Build()
+		dbState := &MysqlState{DB: &sql.DB{}}
+
+		state := &states.State{Project: "test_project", Encryption: &states.Encryption{KeyType: "aes"}}
+		assert.ErrorIs(t, dbState.Apply(state), ErrMissingEncryptionColumn)
+
+		state = &states.State{Project: "test_project"}
+		assert.ErrorIs(t, dbState.Apply(state), unknownColumnErr)
+	})
+}
+
 func TestDBState_do2Bo(t *testing.T) {
 	type fields struct {
 		DB *sql.DB
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+
+	_ "github.com/lib/pq"
+	"github.com/zclconf/go-cty/cty"
+
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/remote/sqlstate"
+)
+
+// Dialect is the PostgreSQL dialect to store State.
+var Dialect = &sqlstate.Dialect{
+	Name:        "postgres",
+	Placeholder: sqlstate.DollarPlaceholder,
+	Migrations: []string{
+		`CREATE TABLE IF NOT EXISTS state (
+	id BIGSERIAL PRIMARY KEY,
+	tenant VARCHAR(100) NOT NULL DEFAULT '',
+	project VARCHAR(100) NOT NULL,
+	stack VARCHAR(100) NOT NULL DEFAULT '',
+	cluster VARCHAR(100) NOT NULL DEFAULT '',
+	version INTEGER NOT NULL DEFAULT 0,
+	kusion_version VARCHAR(50) NOT NULL DEFAULT '',
+	serial BIGINT NOT NULL DEFAULT 0,
+	operator VARCHAR(100) NOT NULL DEFAULT '',
+	resources TEXT NOT NULL,
+	encryption TEXT NOT NULL DEFAULT '',
+	create_time TIMESTAMP NOT NULL,
+	modified_time TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_state_project_stack_serial ON state (project, stack, serial);`,
+	},
+}
+
+type PostgresBackend struct {
+	sqlstate.SQLState
+}
+
+func NewPostgresBackend() states.Backend {
+	return &PostgresBackend{}
+}
+
+// ConfigSchema returns a description of the expected configuration
+// structure for the receiving backend.
+func (b *PostgresBackend) ConfigSchema() cty.Type {
+	config := map[string]cty.Type{
+		"dbName":   cty.String,
+		"user":     cty.String,
+		"password": cty.String,
+		"host":     cty.String,
+		"port":     cty.Number,
+		"sslMode":  cty.String,
+	}
+	return cty.Object(config)
+}
+
+// Configure uses the provided configuration to set configuration fields
+// within the PostgresState backend, and migrates the schema.
+func (b *PostgresBackend) Configure(obj cty.Value) error {
+	var dbName, dbUser, dbPassword, dbHost, dbPort, sslMode cty.Value
+	if dbName = obj.GetAttr("dbName"); dbName.IsNull() {
+		return errors.New("dbName must be configure in backend config")
+	}
+	if dbUser = obj.GetAttr("user"); dbUser.IsNull() {
+		return errors.New("user must be configure in backend config")
+	}
+	if dbHost = obj.GetAttr("host"); dbHost.IsNull() {
+		return errors.New("host must be configure in backend config")
+	}
+	if dbPort = obj.GetAttr("port"); dbPort.IsNull() {
+		return errors.New("port must be configure in backend config")
+	}
+
+	port, _ := dbPort.AsBigFloat().Int64()
+	var password, mode string
+	if dbPassword = obj.GetAttr("password"); !dbPassword.IsNull() {
+		password = dbPassword.AsString()
+	}
+	if sslMode = obj.GetAttr("sslMode"); !sslMode.IsNull() {
+		mode = sslMode.AsString()
+	}
+	db, err := sql.Open("postgres", dataSourceName(dbName.AsString(), dbUser.AsString(), password, dbHost.AsString(), int(port), mode))
+	if err != nil {
+		return err
+	}
+	if err = sqlstate.Migrate(db, Dialect); err != nil {
+		_ = db.Close()
+		return err
+	}
+	b.SQLState = *sqlstate.NewSQLState(db, Dialect)
+
+	return nil
+}
+
+// StateStorage return a StateStorage to manage State stored in db
+func (b *PostgresBackend) StateStorage() states.StateStorage {
+	return sqlstate.NewSQLState(b.DB, b.Dialect)
+}
+
+// dataSourceName returns the connection url of the postgres database.
+func dataSourceName(dbName, user, password, host string, port int, sslMode string) string {
+	u := &url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(user, password),
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:   dbName,
+	}
+	if password == "" {
+		u.User = url.User(user)
+	}
+	if sslMode != "" {
+		u.RawQuery = url.Values{"sslmode": []string{sslMode}}.Encode()
+	}
+	return u.String()
+}
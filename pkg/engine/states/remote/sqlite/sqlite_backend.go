@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/zclconf/go-cty/cty"
+	_ "modernc.org/sqlite"
+
+	"kusionstack.io/kusion/pkg/engine/states"
+	"kusionstack.io/kusion/pkg/engine/states/remote/sqlstate"
+)
+
+// KusionStateDBFile is the default SQLite database file name.
+const KusionStateDBFile = "kusion_state.db"
+
+// Dialect is the SQLite dialect to store State.
+var Dialect = &sqlstate.Dialect{
+	Name:        "sqlite",
+	Placeholder: sqlstate.QuestionPlaceholder,
+	Migrations: []string{
+		`CREATE TABLE IF NOT EXISTS state (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	tenant TEXT NOT NULL DEFAULT '',
+	project TEXT NOT NULL,
+	stack TEXT NOT NULL DEFAULT '',
+	cluster TEXT NOT NULL DEFAULT '',
+	version INTEGER NOT NULL DEFAULT 0,
+	kusion_version TEXT NOT NULL DEFAULT '',
+	serial INTEGER NOT NULL DEFAULT 0,
+	operator TEXT NOT NULL DEFAULT '',
+	resources TEXT NOT NULL,
+	encryption TEXT NOT NULL DEFAULT '',
+	create_time DATETIME NOT NULL,
+	modified_time DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_state_project_stack_serial ON state (project, stack, serial);`,
+	},
+}
+
+type SqliteBackend struct {
+	sqlstate.SQLState
+}
+
+func NewSqliteBackend() states.Backend {
+	return &SqliteBackend{}
+}
+
+// ConfigSchema returns a description of the expected configuration
+// structure for the receiving backend.
+func (b *SqliteBackend) ConfigSchema() cty.Type {
+	config := map[string]cty.Type{
+		"path": cty.String,
+	}
+	return cty.Object(config)
+}
+
+// Configure uses the provided configuration to set configuration fields
+// within the SqliteState backend, and migrates the schema.
+func (b *SqliteBackend) Configure(obj cty.Value) error {
+	var path cty.Value
+	if path = obj.GetAttr("path"); path.IsNull() || path.AsString() == "" {
+		return errors.New("path must be configure in backend config")
+	}
+
+	db, err := Open(path.AsString())
+	if err != nil {
+		return err
+	}
+	b.SQLState = *sqlstate.NewSQLState(db, Dialect)
+
+	return nil
+}
+
+// StateStorage return a StateStorage to manage State stored in db
+func (b *SqliteBackend) StateStorage() states.StateStorage {
+	return sqlstate.NewSQLState(b.DB, b.Dialect)
+}
+
+// Open opens the SQLite database file and migrates the schema, the file is created if not exists.
+func Open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite does not support concurrent writes
+	db.SetMaxOpenConns(1)
+	if err = sqlstate.Migrate(db, Dialect); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"kusionstack.io/kusion/pkg/engine/states"
+)
+
+func TestSqliteBackend_ConfigSchema(t *testing.T) {
+	want := cty.Object(map[string]cty.Type{
+		"path": cty.String,
+	})
+	assert.Equal(t, want, NewSqliteBackend().ConfigSchema())
+}
+
+func TestSqliteBackend_Configure(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			config:  map[string]interface{}{"path": filepath.Join(t.TempDir(), KusionStateDBFile)},
+			wantErr: false,
+		},
+		{
+			name:    "empty path",
+			config:  map[string]interface{}{"path": ""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewSqliteBackend()
+			obj, err := gocty.ToCtyValue(tt.config, b.ConfigSchema())
+			assert.NoError(t, err)
+			err = b.Configure(obj)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestSqliteBackend_StateStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), KusionStateDBFile)
+	b := NewSqliteBackend()
+	obj, err := gocty.ToCtyValue(map[string]interface{}{"path": path}, b.ConfigSchema())
+	assert.NoError(t, err)
+	assert.NoError(t, b.Configure(obj))
+
+	storage, ok := b.StateStorage().(states.HistoryStateStorage)
+	assert.True(t, ok)
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = 1
+	assert.NoError(t, storage.Apply(state))
+
+	// reopen the database file, where the migrated schema is kept
+	b = NewSqliteBackend()
+	assert.NoError(t, b.Configure(obj))
+	latest, err := b.StateStorage().GetLatestState(&states.StateQuery{Project: "foo", Stack: "dev"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), latest.Serial)
+}
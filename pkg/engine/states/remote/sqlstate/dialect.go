@@ -0,0 +1,73 @@
+package sqlstate
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Dialect describes the differences among the SQL databases to store State.
+type Dialect struct {
+	// Name of the dialect, used in the error messages
+	Name string
+
+	// Placeholder returns the bind parameter placeholder of the nth (starting from 1) argument
+	Placeholder func(n int) string
+
+	// Migrations are the ordered DDL statements to create and upgrade the schema, where the version of a
+	// migration is its index plus one. The applied migrations must not be modified, and a new migration
+	// should be appended to upgrade the schema.
+	Migrations []string
+}
+
+// QuestionPlaceholder is the placeholder of the databases using "?", such as SQLite.
+func QuestionPlaceholder(int) string {
+	return "?"
+}
+
+// DollarPlaceholder is the placeholder of the databases using "$n", such as PostgreSQL.
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// schemaMigrationsTable records the applied migration versions.
+const schemaMigrationsTable = "kusion_schema_migrations"
+
+// Migrate applies the migrations of the dialect which have not been applied yet, each in a transaction.
+func Migrate(db *sql.DB, dialect *Dialect) error {
+	createTable := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version INTEGER PRIMARY KEY, applied_time TIMESTAMP NOT NULL)", schemaMigrationsTable)
+	if _, err := db.Exec(createTable); err != nil {
+		return fmt.Errorf("create %s schema migrations table failed: %w", dialect.Name, err)
+	}
+
+	var current sql.NullInt64
+	if err := db.QueryRow(fmt.Sprintf("SELECT MAX(version) FROM %s", schemaMigrationsTable)).Scan(&current); err != nil {
+		return fmt.Errorf("get %s schema version failed: %w", dialect.Name, err)
+	}
+	for i := int(current.Int64); i < len(dialect.Migrations); i++ {
+		version := i + 1
+		if err := applyMigration(db, dialect, version, dialect.Migrations[i]); err != nil {
+			return fmt.Errorf("apply %s schema migration %d failed: %w", dialect.Name, version, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(db *sql.DB, dialect *Dialect, version int, migration string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(migration); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (version, applied_time) VALUES (%s, %s)",
+		schemaMigrationsTable, dialect.Placeholder(1), dialect.Placeholder(2))
+	if _, err = tx.Exec(insert, version, time.Now().UTC()); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
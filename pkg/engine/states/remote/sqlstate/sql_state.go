@@ -0,0 +1,165 @@
+package sqlstate
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/engine/states"
+)
+
+var _ states.HistoryStateStorage = &SQLState{}
+
+// stateColumns are the columns of table state except id.
+var stateColumns = []string{
+	"tenant", "project", "stack", "cluster", "version", "kusion_version", "serial", "operator",
+	"resources", "encryption", "create_time", "modified_time",
+}
+
+// SQLState stores State in the table state of a SQL database by add-only strategy, where each applied
+// State is a new record, and the record with the max serial is the latest State.
+type SQLState struct {
+	DB      *sql.DB
+	Dialect *Dialect
+}
+
+// NewSQLState news a SQLState.
+func NewSQLState(db *sql.DB, dialect *Dialect) *SQLState {
+	return &SQLState{
+		DB:      db,
+		Dialect: dialect,
+	}
+}
+
+// GetLatestState is an implementation of StateStorage.GetLatestState
+func (s *SQLState) GetLatestState(query *states.StateQuery) (*states.State, error) {
+	result, err := s.queryStates(query, "serial DESC, id DESC", 1)
+	if err != nil || len(result) == 0 {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+// GetHistoryStates is an implementation of HistoryStateStorage.GetHistoryStates
+func (s *SQLState) GetHistoryStates(query *states.StateQuery) ([]*states.State, error) {
+	return s.queryStates(query, "serial ASC, id ASC", 0)
+}
+
+// Apply is an implementation of StateStorage.Apply
+func (s *SQLState) Apply(state *states.State) error {
+	sort.Stable(state.Resources)
+	resources, err := json.Marshal(state.Resources)
+	if err != nil {
+		return fmt.Errorf("marshal resources failed: %w", err)
+	}
+	var encryption []byte
+	if state.Encryption != nil {
+		if encryption, err = json.Marshal(state.Encryption); err != nil {
+			return fmt.Errorf("marshal encryption failed: %w", err)
+		}
+	}
+	now := time.Now().UTC()
+	createTime := state.CreateTime
+	if createTime.IsZero() {
+		createTime = now
+	}
+
+	placeholders := make([]string, len(stateColumns))
+	for i := range stateColumns {
+		placeholders[i] = s.Dialect.Placeholder(i + 1)
+	}
+	insert := fmt.Sprintf("INSERT INTO state (%s) VALUES (%s) RETURNING id",
+		strings.Join(stateColumns, ", "), strings.Join(placeholders, ", "))
+	var id int64
+	err = s.DB.QueryRow(insert, state.Tenant, state.Project, state.Stack, state.Cluster, state.Version,
+		state.KusionVersion, state.Serial, state.Operator, string(resources), string(encryption), createTime, now).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("insert state into %s failed: %w", s.Dialect.Name, err)
+	}
+	state.ID = id
+	state.CreateTime = createTime
+	state.ModifiedTime = now
+	return nil
+}
+
+// Delete is an implementation of StateStorage.Delete
+func (s *SQLState) Delete(id string) error {
+	stateID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid state id %s: %w", id, err)
+	}
+	_, err = s.DB.Exec(fmt.Sprintf("DELETE FROM state WHERE id = %s", s.Dialect.Placeholder(1)), stateID)
+	return err
+}
+
+func (s *SQLState) queryStates(query *states.StateQuery, orderBy string, limit int) ([]*states.State, error) {
+	if query == nil || query.Project == "" {
+		return nil, errors.New("no Project in query")
+	}
+	var conditions []string
+	var args []any
+	addCondition := func(column, value string) {
+		if value == "" {
+			return
+		}
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = %s", column, s.Dialect.Placeholder(len(args))))
+	}
+	addCondition("project", query.Project)
+	addCondition("tenant", query.Tenant)
+	addCondition("stack", query.Stack)
+	addCondition("cluster", query.Cluster)
+
+	selectSQL := fmt.Sprintf("SELECT id, %s FROM state WHERE %s ORDER BY %s",
+		strings.Join(stateColumns, ", "), strings.Join(conditions, " AND "), orderBy)
+	if limit > 0 {
+		selectSQL = fmt.Sprintf("%s LIMIT %d", selectSQL, limit)
+	}
+	rows, err := s.DB.Query(selectSQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query state from %s failed: %w", s.Dialect.Name, err)
+	}
+	defer rows.Close()
+
+	var result []*states.State
+	for rows.Next() {
+		state, err := scanState(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, state)
+	}
+	return result, rows.Err()
+}
+
+func scanState(rows *sql.Rows) (*states.State, error) {
+	state := states.NewState()
+	var resources, encryption string
+	err := rows.Scan(&state.ID, &state.Tenant, &state.Project, &state.Stack, &state.Cluster, &state.Version,
+		&state.KusionVersion, &state.Serial, &state.Operator, &resources, &encryption, &state.CreateTime, &state.ModifiedTime)
+	if err != nil {
+		return nil, fmt.Errorf("scan state failed: %w", err)
+	}
+
+	var stateResources apiv1.Resources
+	// JSON is a subset of YAML. Please check FileSystemState.GetLatestState for detail explanation
+	if err = yaml.Unmarshal([]byte(resources), &stateResources); err != nil {
+		return nil, fmt.Errorf("unmarshal resources of state %d failed: %w", state.ID, err)
+	}
+	state.Resources = stateResources
+	if encryption != "" {
+		state.Encryption = &states.Encryption{}
+		if err = yaml.Unmarshal([]byte(encryption), state.Encryption); err != nil {
+			return nil, fmt.Errorf("unmarshal encryption of state %d failed: %w", state.ID, err)
+		}
+	}
+	return state, nil
+}
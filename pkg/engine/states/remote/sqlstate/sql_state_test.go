@@ -0,0 +1,112 @@
+package sqlstate
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/engine/states"
+)
+
+var testDialect = &Dialect{
+	Name:        "sqlite",
+	Placeholder: QuestionPlaceholder,
+	Migrations: []string{
+		`CREATE TABLE state (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	tenant TEXT NOT NULL DEFAULT '',
+	project TEXT NOT NULL,
+	stack TEXT NOT NULL DEFAULT '',
+	cluster TEXT NOT NULL DEFAULT '',
+	version INTEGER NOT NULL DEFAULT 0,
+	kusion_version TEXT NOT NULL DEFAULT '',
+	serial INTEGER NOT NULL DEFAULT 0,
+	operator TEXT NOT NULL DEFAULT '',
+	resources TEXT NOT NULL,
+	encryption TEXT NOT NULL DEFAULT '',
+	create_time DATETIME NOT NULL,
+	modified_time DATETIME NOT NULL
+);`,
+	},
+}
+
+func newTestSQLState(t *testing.T) *SQLState {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "kusion_state.db"))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	assert.NoError(t, Migrate(db, testDialect))
+	return NewSQLState(db, testDialect)
+}
+
+func mockState(serial uint64) *states.State {
+	state := states.NewState()
+	state.Project = "foo"
+	state.Stack = "dev"
+	state.Serial = serial
+	state.Resources = apiv1.Resources{
+		{
+			ID:         "v1:Namespace:default",
+			Type:       apiv1.Kubernetes,
+			Attributes: map[string]interface{}{"name": "default"},
+		},
+	}
+	return state
+}
+
+func TestPlaceholder(t *testing.T) {
+	assert.Equal(t, "?", QuestionPlaceholder(1))
+	assert.Equal(t, "$2", DollarPlaceholder(2))
+}
+
+func TestMigrate(t *testing.T) {
+	s := newTestSQLState(t)
+	// the applied migrations are skipped
+	assert.NoError(t, Migrate(s.DB, testDialect))
+
+	var version int
+	assert.NoError(t, s.DB.QueryRow("SELECT MAX(version) FROM "+schemaMigrationsTable).Scan(&version))
+	assert.Equal(t, 1, version)
+}
+
+func TestSQLState(t *testing.T) {
+	s := newTestSQLState(t)
+	query := &states.StateQuery{Project: "foo", Stack: "dev"}
+
+	state, err := s.GetLatestState(query)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+	_, err = s.GetLatestState(&states.StateQuery{Stack: "dev"})
+	assert.Error(t, err)
+
+	for _, serial := range []uint64{1, 2} {
+		state = mockState(serial)
+		assert.NoError(t, s.Apply(state))
+		assert.NotZero(t, state.ID)
+	}
+	encryptedState := mockState(3)
+	encryptedState.Resources = apiv1.Resources{}
+	encryptedState.Encryption = &states.Encryption{KeyType: "aes", KeyID: "id", DataKey: "key", Ciphertext: "text"}
+	assert.NoError(t, s.Apply(encryptedState))
+
+	latest, err := s.GetLatestState(query)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), latest.Serial)
+	assert.Equal(t, encryptedState.Encryption, latest.Encryption)
+	assert.False(t, latest.CreateTime.IsZero())
+
+	history, err := s.GetHistoryStates(query)
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	assert.Equal(t, uint64(1), history[0].Serial)
+	assert.Equal(t, mockState(1).Resources, history[0].Resources)
+
+	assert.NoError(t, s.Delete("3"))
+	latest, err = s.GetLatestState(query)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), latest.Serial)
+	assert.Error(t, s.Delete("invalid"))
+}
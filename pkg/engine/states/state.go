@@ -1,6 +1,7 @@
 package states
 
 import (
+	"errors"
 	"time"
 
 	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
@@ -19,6 +20,19 @@ type StateStorage interface {
 	Delete(id string) error
 }
 
+// ErrHistoryNotSupported is returned by HistoryStateStorage.GetHistoryStates if the history is not kept, which
+// happens when a HistoryStateStorage wraps a StateStorage without history.
+var ErrHistoryNotSupported = errors.New("state history is not supported by the state storage")
+
+// HistoryStateStorage is implemented by the StateStorage which keeps the history of State, such as the
+// one storing each applied State as a new record.
+type HistoryStateStorage interface {
+	StateStorage
+
+	// GetHistoryStates returns all the States matching the query in ascending order of Serial
+	GetHistoryStates(query *StateQuery) ([]*State, error)
+}
+
 type StateQuery struct {
 	// Tenant name
 	Tenant string `json:"tenant"`
@@ -71,6 +85,26 @@ type State struct {
 
 	// ModifiedTime is the time State is modified each time
 	ModifiedTime time.Time `json:"modifiedTime,omitempty" yaml:"modifiedTime"`
+
+	// Encryption is set only when the State is stored encrypted, in which case Resources is empty and
+	// the sealed resources are recorded in Encryption.Ciphertext
+	Encryption *Encryption `json:"encryption,omitempty" yaml:"encryption,omitempty"`
+}
+
+// Encryption records how the Resources of an encrypted State are sealed. The Resources are encrypted
+// with a random data key, and the data key is wrapped by the key encryption key identified by KeyID.
+type Encryption struct {
+	// KeyType is the type of the key encryption key, such as "aes" or "age"
+	KeyType string `json:"keyType" yaml:"keyType"`
+
+	// KeyID identifies the key encryption key used to wrap the data key
+	KeyID string `json:"keyID" yaml:"keyID"`
+
+	// DataKey is the base64 encoded data key wrapped by the key encryption key
+	DataKey string `json:"dataKey" yaml:"dataKey"`
+
+	// Ciphertext is the base64 encoded Resources sealed by the data key
+	Ciphertext string `json:"ciphertext" yaml:"ciphertext"`
 }
 
 func NewState() *State {
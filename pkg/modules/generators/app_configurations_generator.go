@@ -1,12 +1,19 @@
 package generators
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/log"
 	"kusionstack.io/kusion/pkg/modules"
 	"kusionstack.io/kusion/pkg/modules/generators/workload"
+	"kusionstack.io/kusion/pkg/modules/generators/workload/secret"
+	"kusionstack.io/kusion/pkg/modules/proto"
+	"kusionstack.io/kusion/pkg/modules/schemas"
 	"kusionstack.io/kusion/pkg/workspace"
 )
 
@@ -16,6 +23,8 @@ type appConfigurationGenerator struct {
 	appName string
 	app     *apiv1.AppConfiguration
 	ws      *apiv1.Workspace
+	// secretResolver retrieves the external secrets, which is shared by all the apps during a build
+	secretResolver *secret.ExternalSecretResolver
 }
 
 func NewAppConfigurationGenerator(
@@ -24,6 +33,7 @@ func NewAppConfigurationGenerator(
 	appName string,
 	app *apiv1.AppConfiguration,
 	ws *apiv1.Workspace,
+	secretResolver *secret.ExternalSecretResolver,
 ) (modules.Generator, error) {
 	if len(project.Name) == 0 {
 		return nil, fmt.Errorf("project name must not be empty")
@@ -44,13 +54,21 @@ func NewAppConfigurationGenerator(
 	if err := workspace.ValidateWorkspace(ws); err != nil {
 		return nil, fmt.Errorf("invalid config of workspace %s, %w", stack.Name, err)
 	}
+	if err := schemas.ValidateModuleConfigs(ws.Modules); err != nil {
+		return nil, fmt.Errorf("invalid module configs of workspace %s, %w", stack.Name, err)
+	}
+
+	if secretResolver == nil {
+		secretResolver = secret.NewExternalSecretResolver(workspace.GetSecretStoreSpec(ws))
+	}
 
 	return &appConfigurationGenerator{
-		project: project,
-		stack:   stack,
-		appName: appName,
-		app:     app,
-		ws:      ws,
+		project:        project,
+		stack:          stack,
+		appName:        appName,
+		app:            app,
+		ws:             ws,
+		secretResolver: secretResolver,
 	}, nil
 }
 
@@ -60,9 +78,10 @@ func NewAppConfigurationGeneratorFunc(
 	appName string,
 	app *apiv1.AppConfiguration,
 	ws *apiv1.Workspace,
+	secretResolver *secret.ExternalSecretResolver,
 ) modules.NewGeneratorFunc {
 	return func() (modules.Generator, error) {
-		return NewAppConfigurationGenerator(project, stack, appName, app, ws)
+		return NewAppConfigurationGenerator(project, stack, appName, app, ws, secretResolver)
 	}
 }
 
@@ -81,25 +100,25 @@ func (g *appConfigurationGenerator) Generate(i *apiv1.Intent) error {
 	// todo: is namespace a module? how to retrieve it? Currently, it is configured in the workspace file.
 	namespace := g.getNamespaceName(platformConfigs)
 
-	// Generate built-in resources
-	gfs := []modules.NewGeneratorFunc{
-		NewNamespaceGeneratorFunc(namespace),
-		workload.NewWorkloadGeneratorFunc(&workload.Generator{
-			Project:         g.project.Name,
-			Stack:           g.stack.Name,
-			App:             g.appName,
-			Namespace:       namespace,
-			Workload:        g.app.Workload,
-			PlatformConfigs: platformConfigs,
-		}),
-	}
-	if err = modules.CallGenerators(i, gfs...); err != nil {
+	// Generate the namespace, the customized module resources and the built-in workload resources in order, for
+	// the magic environment variables of the workload may refer to the Secrets generated by the modules
+	if err = modules.CallGenerators(i, NewNamespaceGeneratorFunc(namespace)); err != nil {
 		return err
 	}
-
-	// Generate customized module resources
-	for range platformConfigs {
-		// todo: invoke kusion module generators for each module
+	if err = g.callModules(i, platformConfigs); err != nil {
+		return err
+	}
+	if err = modules.CallGenerators(i, workload.NewWorkloadGeneratorFunc(&workload.Generator{
+		Project:         g.project.Name,
+		Stack:           g.stack.Name,
+		App:             g.appName,
+		Namespace:       namespace,
+		Workload:        g.app.Workload,
+		PlatformConfigs: platformConfigs,
+		SecretStoreSpec: workspace.GetSecretStoreSpec(g.ws),
+		SecretResolver:  g.secretResolver,
+	})); err != nil {
+		return err
 	}
 
 	// The OrderedResourcesGenerator should be executed after all resources are generated.
@@ -113,6 +132,91 @@ func (g *appConfigurationGenerator) Generate(i *apiv1.Intent) error {
 	return nil
 }
 
+// callModules calls the module plugins of the accessories in the order of the module keys, which appends the
+// generated resources and registers the magic environment variable schemes returned by the modules. The
+// accessories without an installed module plugin are skipped.
+func (g *appConfigurationGenerator) callModules(i *apiv1.Intent, platformConfigs map[string]apiv1.GenericConfig) error {
+	keys := make([]string, 0, len(g.app.Accessories))
+	for key := range g.app.Accessories {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := g.callModule(i, key, platformConfigs); err != nil {
+			return fmt.Errorf("call module %s failed: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (g *appConfigurationGenerator) callModule(i *apiv1.Intent, key string, platformConfigs map[string]apiv1.GenericConfig) error {
+	plugin, err := modules.NewPlugin(key)
+	if errors.Is(err, modules.ErrInvalidModuleKey) || errors.Is(err, modules.ErrModuleNotFound) {
+		log.Infof("skip the accessory %s without an installed module plugin", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer plugin.KillPluginClient()
+
+	req, err := g.buildModuleRequest(key, platformConfigs)
+	if err != nil {
+		return err
+	}
+	res, err := plugin.Module.Generate(req)
+	if err != nil {
+		return err
+	}
+
+	if err = workload.RegisterMagicEnvSchemes(res.GetMagicEnvSchemes()...); err != nil {
+		return err
+	}
+	for _, content := range res.GetResources() {
+		resource := apiv1.Resource{}
+		if err = json.Unmarshal(content, &resource); err != nil {
+			return fmt.Errorf("json unmarshal resource failed: %w", err)
+		}
+		i.Resources = append(i.Resources, resource)
+	}
+	return nil
+}
+
+// buildModuleRequest builds the request of the module, whose platform config is the module config of the workspace
+// named by the module name in the key, such as mysql of kusionstack/mysql@v0.1.
+func (g *appConfigurationGenerator) buildModuleRequest(
+	key string,
+	platformConfigs map[string]apiv1.GenericConfig,
+) (*proto.GeneratorRequest, error) {
+	source, _, _ := strings.Cut(key, "@")
+	moduleName := source[strings.LastIndex(source, "/")+1:]
+
+	req := &proto.GeneratorRequest{
+		Project: g.project.Name,
+		Stack:   g.stack.Name,
+		App:     g.appName,
+	}
+	var err error
+	if req.Workload, err = json.Marshal(g.app.Workload); err != nil {
+		return nil, fmt.Errorf("json marshal workload failed: %w", err)
+	}
+	if req.DevModuleConfig, err = json.Marshal(g.app.Accessories[key]); err != nil {
+		return nil, fmt.Errorf("json marshal accessory failed: %w", err)
+	}
+	if config, ok := platformConfigs[moduleName]; ok {
+		if req.PlatformModuleConfig, err = json.Marshal(config); err != nil {
+			return nil, fmt.Errorf("json marshal module config failed: %w", err)
+		}
+	}
+	if g.ws.Runtimes != nil {
+		if req.RuntimeConfig, err = json.Marshal(g.ws.Runtimes); err != nil {
+			return nil, fmt.Errorf("json marshal runtime configs failed: %w", err)
+		}
+	}
+	return req, nil
+}
+
 // getNamespaceName obtains the final namespace name using the following precedence
 // (from lower to higher):
 // - Project name
@@ -1,15 +1,26 @@
 package generators
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/bytedance/mockey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload/network"
+	"kusionstack.io/kusion/pkg/modules"
+	"kusionstack.io/kusion/pkg/modules/generators/workload/secret"
+	"kusionstack.io/kusion/pkg/modules/proto"
+	"kusionstack.io/kusion/pkg/secrets"
+	"kusionstack.io/kusion/pkg/secrets/providers/fake"
 
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
 )
 
 func TestAppConfigurationGenerator_Generate(t *testing.T) {
@@ -47,6 +58,106 @@ func TestAppConfigurationGenerator_Generate(t *testing.T) {
 	}
 }
 
+func TestAppConfigurationGenerator_Generate_ExternalSecret(t *testing.T) {
+	project, stack := buildMockProjectAndStack()
+	appName, app := buildMockApp()
+	app.Workload.Service.Secrets = map[string]workload.Secret{
+		"api-auth": {
+			Type: "external",
+			Data: map[string]string{"accessKey": "ref://api-auth-info/accessKey"},
+		},
+	}
+	ws := buildMockWorkspace("")
+	ws.SecretStore = &v1.SecretStoreSpec{
+		Provider: &v1.ProviderSpec{
+			Fake: &v1.FakeProvider{
+				Data: []v1.FakeProviderData{{Key: "api-auth-info", Value: `{"accessKey":"some sensitive info"}`}},
+			},
+		},
+	}
+
+	g := &appConfigurationGenerator{
+		project: project,
+		stack:   stack,
+		appName: appName,
+		app:     app,
+		ws:      ws,
+	}
+
+	spec := &v1.Intent{
+		Resources: []v1.Resource{},
+	}
+	err := g.Generate(spec)
+	assert.NoError(t, err)
+
+	var found bool
+	for _, res := range spec.Resources {
+		actual := mapToUnstructured(res.Attributes)
+		if actual.GetKind() == "Secret" && actual.GetName() == "api-auth" {
+			found = true
+		}
+	}
+	assert.True(t, found, "external secret should be generated")
+}
+
+type countingSecretStoreProvider struct {
+	store *countingSecretStore
+}
+
+func (p *countingSecretStoreProvider) NewSecretStore(_ v1.SecretStoreSpec) (secrets.SecretStore, error) {
+	return p.store, nil
+}
+
+type countingSecretStore struct {
+	count int
+}
+
+func (s *countingSecretStore) GetSecret(_ context.Context, _ v1.ExternalSecretRef) ([]byte, error) {
+	s.count++
+	return []byte(`{"accessKey":"some sensitive info"}`), nil
+}
+
+func TestAppConfigurationGenerator_Generate_SharedSecretResolver(t *testing.T) {
+	store := &countingSecretStore{}
+	secrets.Register(&countingSecretStoreProvider{store: store}, &v1.ProviderSpec{Fake: &v1.FakeProvider{}})
+	t.Cleanup(func() {
+		secrets.Register(&fake.DefaultSecretStoreProvider{}, &v1.ProviderSpec{Fake: &v1.FakeProvider{}})
+	})
+
+	project, stack := buildMockProjectAndStack()
+	ws := buildMockWorkspace("")
+	ws.SecretStore = &v1.SecretStoreSpec{
+		Provider: &v1.ProviderSpec{
+			Fake: &v1.FakeProvider{
+				Data: []v1.FakeProviderData{{Key: "api-auth-info", Value: `{"accessKey":"some sensitive info"}`}},
+			},
+		},
+	}
+	resolver := secret.NewExternalSecretResolver(ws.SecretStore)
+
+	for _, appName := range []string{"app1", "app2"} {
+		_, app := buildMockApp()
+		app.Workload.Service.Secrets = map[string]workload.Secret{
+			"api-auth": {
+				Type: "external",
+				Data: map[string]string{"accessKey": "ref://api-auth-info/accessKey"},
+			},
+		}
+		g := &appConfigurationGenerator{
+			project:        project,
+			stack:          stack,
+			appName:        appName,
+			app:            app,
+			ws:             ws,
+			secretResolver: resolver,
+		}
+		require.NoError(t, g.Generate(&v1.Intent{}))
+	}
+
+	// the secret retrieved by the first app is cached for the second one
+	assert.Equal(t, 1, store.count)
+}
+
 func TestAppConfigurationGenerator_Generate_CustomNamespace(t *testing.T) {
 	project, stack := buildMockProjectAndStack()
 	appName, app := buildMockApp()
@@ -82,38 +193,151 @@ func TestAppConfigurationGenerator_Generate_CustomNamespace(t *testing.T) {
 	}
 }
 
+type mockModule struct {
+	req *proto.GeneratorRequest
+	res *proto.GeneratorResponse
+}
+
+func (m *mockModule) Generate(req *proto.GeneratorRequest) (*proto.GeneratorResponse, error) {
+	m.req = req
+	return m.res, nil
+}
+
+func TestAppConfigurationGenerator_Generate_Modules(t *testing.T) {
+	project, stack := buildMockProjectAndStack()
+	appName, app := buildMockApp()
+	app.Workload.Service.Containers = map[string]container.Container{
+		"main": {
+			Image: "nginx:v1",
+			Env:   yaml.MapSlice{{Key: "DB_PASSWORD", Value: "mysql://db/password"}},
+		},
+	}
+	app.Accessories = map[string]*v1.Accessory{
+		"kusionstack/mysql@v0.1": {"databaseName": "db"},
+	}
+	ws := buildMockWorkspace("")
+	ws.Modules["mysql"] = &v1.ModuleConfig{Default: v1.GenericConfig{"size": 20}}
+
+	secret, err := json.Marshal(v1.Resource{
+		ID:   "v1:Secret:testproject:db-mysql",
+		Type: v1.Kubernetes,
+		Attributes: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "db-mysql", "namespace": "testproject"},
+		},
+	})
+	require.NoError(t, err)
+	module := &mockModule{res: &proto.GeneratorResponse{
+		Resources:       [][]byte{secret},
+		MagicEnvSchemes: []*proto.MagicEnvScheme{{Scheme: "mysql", SecretNameSuffix: "-mysql"}},
+	}}
+
+	mockey.PatchConvey("call module plugins", t, func() {
+		mockey.Mock(modules.NewPlugin).To(func(key string) (*modules.Plugin, error) {
+			assert.Equal(t, "kusionstack/mysql@v0.1", key)
+			return &modules.Plugin{Module: module}, nil
+		}).Build()
+
+		g := &appConfigurationGenerator{
+			project: project,
+			stack:   stack,
+			appName: appName,
+			app:     app,
+			ws:      ws,
+		}
+		spec := &v1.Intent{}
+		require.NoError(t, g.Generate(spec))
+
+		assert.Equal(t, "app1", module.req.App)
+		assert.JSONEq(t, `{"databaseName": "db"}`, string(module.req.DevModuleConfig))
+		assert.JSONEq(t, `{"size": 20}`, string(module.req.PlatformModuleConfig))
+
+		var ids []string
+		for _, res := range spec.Resources {
+			ids = append(ids, res.ID)
+			if res.ID != "apps/v1:Deployment:testproject:testproject-test-app1" {
+				continue
+			}
+			deployment := mapToUnstructured(res.Attributes)
+			containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+			require.Len(t, containers, 1)
+			env, _, _ := unstructured.NestedSlice(containers[0].(map[string]interface{}), "env")
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{
+					"name": "DB_PASSWORD",
+					"valueFrom": map[string]interface{}{
+						"secretKeyRef": map[string]interface{}{"name": "db-mysql", "key": "password"},
+					},
+				},
+			}, env)
+		}
+		assert.Contains(t, ids, "v1:Secret:testproject:db-mysql")
+		assert.Contains(t, ids, "apps/v1:Deployment:testproject:testproject-test-app1")
+	})
+}
+
+func TestAppConfigurationGenerator_Generate_SkipModules(t *testing.T) {
+	t.Setenv(modules.DefaultModulePathEnv, t.TempDir())
+	project, stack := buildMockProjectAndStack()
+	appName, app := buildMockApp()
+	app.Accessories = map[string]*v1.Accessory{
+		"database":               {"type": "aws"},
+		"kusionstack/mysql@v0.1": {"databaseName": "db"},
+	}
+
+	g := &appConfigurationGenerator{
+		project: project,
+		stack:   stack,
+		appName: appName,
+		app:     app,
+		ws:      buildMockWorkspace(""),
+	}
+	spec := &v1.Intent{}
+	require.NoError(t, g.Generate(spec))
+	assert.NotEmpty(t, spec.Resources)
+}
+
 func TestNewAppConfigurationGeneratorFunc(t *testing.T) {
 	project, stack := buildMockProjectAndStack()
 	appName, app := buildMockApp()
 	ws := buildMockWorkspace("")
 
 	t.Run("Valid app configuration generator func", func(t *testing.T) {
-		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, app, ws)()
+		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, app, ws, nil)()
 		assert.NoError(t, err)
 		assert.NotNil(t, g)
 	})
 
+	t.Run("Invalid module config", func(t *testing.T) {
+		invalidWs := buildMockWorkspace("")
+		invalidWs.Modules["service"] = &v1.ModuleConfig{Default: v1.GenericConfig{"replica": 3}}
+		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, app, invalidWs, nil)()
+		assert.ErrorContains(t, err, "modules.service.default: additionalProperties 'replica' not allowed")
+		assert.Nil(t, g)
+	})
+
 	t.Run("Empty app name", func(t *testing.T) {
-		g, err := NewAppConfigurationGeneratorFunc(project, stack, "", app, ws)()
+		g, err := NewAppConfigurationGeneratorFunc(project, stack, "", app, ws, nil)()
 		assert.EqualError(t, err, "app name must not be empty")
 		assert.Nil(t, g)
 	})
 
 	t.Run("Nil app", func(t *testing.T) {
-		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, nil, ws)()
+		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, nil, ws, nil)()
 		assert.EqualError(t, err, "can not find app configuration when generating the Intent")
 		assert.Nil(t, g)
 	})
 
 	t.Run("Empty project name", func(t *testing.T) {
 		project.Name = ""
-		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, app, ws)()
+		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, app, ws, nil)()
 		assert.EqualError(t, err, "project name must not be empty")
 		assert.Nil(t, g)
 	})
 
 	t.Run("Empty workspace", func(t *testing.T) {
-		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, app, nil)()
+		g, err := NewAppConfigurationGeneratorFunc(project, stack, appName, app, nil, nil)()
 		assert.EqualError(t, err, "project name must not be empty")
 		assert.Nil(t, g)
 	})
@@ -30,7 +30,9 @@ var defaultOrderedKinds = []string{
 	"PersistentVolumeClaim",
 	"Deployment",
 	"StatefulSet",
+	"DaemonSet",
 	"CronJob",
+	"HorizontalPodAutoscaler",
 	"PodDisruptionBudget",
 	"MutatingWebhookConfiguration",
 	"ValidatingWebhookConfiguration",
@@ -0,0 +1,148 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+)
+
+var (
+	ErrInvalidMaxReplicas          = errors.New("maxReplicas of autoscaling must be positive")
+	ErrInvalidMinReplicas          = errors.New("minReplicas of autoscaling must be positive and not greater than maxReplicas")
+	ErrInvalidUtilization          = errors.New("utilization of autoscaling must be positive")
+	ErrInvalidAutoscalingMetric    = errors.New("metric of autoscaling must have name, valid type and valid averageValue")
+	ErrAutoscalingNotSupportedType = errors.New("autoscaling is not supported by DaemonSet")
+)
+
+// getAutoscaling returns the autoscaling config of the workspace module config, and nil if not set.
+func getAutoscaling(config apiv1.GenericConfig) (*workload.Autoscaling, error) {
+	value, ok := config[workload.FieldAutoscaling]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	content, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("yaml marshal autoscaling config failed, %w", err)
+	}
+	autoscaling := &workload.Autoscaling{}
+	if err = yaml.Unmarshal(content, autoscaling); err != nil {
+		return nil, fmt.Errorf("invalid autoscaling config, %w", err)
+	}
+	if err = validateAutoscaling(autoscaling); err != nil {
+		return nil, err
+	}
+	return autoscaling, nil
+}
+
+func validateAutoscaling(autoscaling *workload.Autoscaling) error {
+	if autoscaling.MaxReplicas < 1 {
+		return ErrInvalidMaxReplicas
+	}
+	if autoscaling.MinReplicas != nil && (*autoscaling.MinReplicas < 1 || *autoscaling.MinReplicas > autoscaling.MaxReplicas) {
+		return ErrInvalidMinReplicas
+	}
+	for _, utilization := range []*int32{autoscaling.CPUUtilization, autoscaling.MemoryUtilization} {
+		if utilization != nil && *utilization < 1 {
+			return ErrInvalidUtilization
+		}
+	}
+	for _, metric := range autoscaling.Metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("%w: %+v", ErrInvalidAutoscalingMetric, metric)
+		}
+		if metric.Type != "" && metric.Type != workload.AutoscalingMetricPods && metric.Type != workload.AutoscalingMetricExternal {
+			return fmt.Errorf("%w: %+v", ErrInvalidAutoscalingMetric, metric)
+		}
+		if _, err := resource.ParseQuantity(metric.AverageValue); err != nil {
+			return fmt.Errorf("%w: %+v", ErrInvalidAutoscalingMetric, metric)
+		}
+	}
+	return nil
+}
+
+// toHorizontalPodAutoscaler returns the autoscaling/v2 HorizontalPodAutoscaler scaling the target workload, should
+// be called after validateAutoscaling.
+func toHorizontalPodAutoscaler(
+	autoscaling *workload.Autoscaling,
+	scaleTarget metav1.TypeMeta,
+	objectMeta metav1.ObjectMeta,
+) *autoscalingv2.HorizontalPodAutoscaler {
+	var metrics []autoscalingv2.MetricSpec
+	resourceMetrics := []struct {
+		name        v1.ResourceName
+		utilization *int32
+	}{
+		{v1.ResourceCPU, autoscaling.CPUUtilization},
+		{v1.ResourceMemory, autoscaling.MemoryUtilization},
+	}
+	for _, m := range resourceMetrics {
+		if m.utilization == nil {
+			continue
+		}
+		utilization := *m.utilization
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: m.name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		})
+	}
+	for _, m := range autoscaling.Metrics {
+		averageValue := resource.MustParse(m.AverageValue)
+		target := autoscalingv2.MetricTarget{
+			Type:         autoscalingv2.AverageValueMetricType,
+			AverageValue: &averageValue,
+		}
+		if m.Type == workload.AutoscalingMetricExternal {
+			metrics = append(metrics, autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{Name: m.Name},
+					Target: target,
+				},
+			})
+			continue
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: m.Name},
+				Target: target,
+			},
+		})
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objectMeta.Name,
+			Namespace: objectMeta.Namespace,
+			Labels:    objectMeta.Labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: scaleTarget.APIVersion,
+				Kind:       scaleTarget.Kind,
+				Name:       objectMeta.Name,
+			},
+			MinReplicas: autoscaling.MinReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
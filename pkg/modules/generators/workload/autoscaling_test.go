@@ -0,0 +1,168 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
+)
+
+func TestGetAutoscaling(t *testing.T) {
+	r1, r2 := int32(1), int32(2)
+	testcases := []struct {
+		name     string
+		config   apiv1.GenericConfig
+		expected *workload.Autoscaling
+		wantErr  error
+	}{
+		{
+			name:   "not set",
+			config: apiv1.GenericConfig{"replicas": 2},
+		},
+		{
+			name: "valid autoscaling",
+			config: apiv1.GenericConfig{
+				"autoscaling": map[string]any{
+					"minReplicas":    2,
+					"maxReplicas":    10,
+					"cpuUtilization": 1,
+					"metrics": []any{
+						map[string]any{"name": "requests_per_second", "averageValue": 100},
+					},
+				},
+			},
+			expected: &workload.Autoscaling{
+				MinReplicas:    &r2,
+				MaxReplicas:    10,
+				CPUUtilization: &r1,
+				Metrics: []workload.AutoscalingMetric{
+					{Name: "requests_per_second", AverageValue: "100"},
+				},
+			},
+		},
+		{
+			name:    "invalid maxReplicas",
+			config:  apiv1.GenericConfig{"autoscaling": map[string]any{"minReplicas": 2}},
+			wantErr: ErrInvalidMaxReplicas,
+		},
+		{
+			name:    "minReplicas greater than maxReplicas",
+			config:  apiv1.GenericConfig{"autoscaling": map[string]any{"minReplicas": 4, "maxReplicas": 2}},
+			wantErr: ErrInvalidMinReplicas,
+		},
+		{
+			name:    "invalid utilization",
+			config:  apiv1.GenericConfig{"autoscaling": map[string]any{"maxReplicas": 2, "memoryUtilization": 0}},
+			wantErr: ErrInvalidUtilization,
+		},
+		{
+			name: "invalid metric",
+			config: apiv1.GenericConfig{"autoscaling": map[string]any{
+				"maxReplicas": 2,
+				"metrics":     []any{map[string]any{"name": "qps", "type": "Object", "averageValue": "1"}},
+			}},
+			wantErr: ErrInvalidAutoscalingMetric,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			autoscaling, err := getAutoscaling(tc.config)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, autoscaling)
+		})
+	}
+}
+
+func TestServiceGenerator_GenerateWithAutoscaling(t *testing.T) {
+	hpa := `apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+    creationTimestamp: null
+    labels:
+        app.kubernetes.io/name: foo
+        app.kubernetes.io/part-of: default
+    name: default-dev-foo
+    namespace: default
+spec:
+    maxReplicas: 10
+    metrics:
+        - resource:
+            name: cpu
+            target:
+                averageUtilization: 80
+                type: Utilization
+          type: Resource
+        - pods:
+            metric:
+                name: requests_per_second
+            target:
+                averageValue: 500m
+                type: AverageValue
+          type: Pods
+        - external:
+            metric:
+                name: queue_length
+            target:
+                averageValue: "30"
+                type: AverageValue
+          type: External
+    minReplicas: 2
+    scaleTargetRef:
+        apiVersion: apps/v1
+        kind: Deployment
+        name: default-dev-foo
+status:
+    currentMetrics: null
+    desiredReplicas: 0
+`
+	r4 := int32(4)
+	config := apiv1.GenericConfig{
+		"autoscaling": apiv1.GenericConfig{
+			"minReplicas":    2,
+			"maxReplicas":    10,
+			"cpuUtilization": 80,
+			"metrics": []any{
+				apiv1.GenericConfig{"name": "requests_per_second", "averageValue": "500m"},
+				apiv1.GenericConfig{"name": "queue_length", "type": "External", "averageValue": 30},
+			},
+		},
+	}
+	g := &ServiceGenerator{
+		Project: "default",
+		Stack:   "dev",
+		App:     "foo",
+		Service: &workload.Service{
+			Base: workload.Base{
+				Containers: map[string]container.Container{"nginx": {Image: "nginx:v1"}},
+				Replicas:   &r4,
+			},
+		},
+		Config:    config,
+		Namespace: "default",
+	}
+	spec := &apiv1.Intent{}
+	require.NoError(t, g.Generate(spec))
+	require.Len(t, spec.Resources, 2)
+	deploy, ok := spec.Resources[0].Attributes["spec"].(map[string]any)
+	require.True(t, ok)
+	assert.NotContains(t, deploy, "replicas", "replicas should be managed by the HorizontalPodAutoscaler")
+	b, err := yaml.Marshal(spec.Resources[1].Attributes)
+	require.NoError(t, err)
+	assert.Equal(t, hpa, string(b))
+
+	// DaemonSet can't be scaled
+	g.Service = &workload.Service{
+		Base: workload.Base{Containers: map[string]container.Container{"nginx": {Image: "nginx:v1"}}},
+		Type: workload.DaemonSet,
+	}
+	assert.ErrorIs(t, g.Generate(&apiv1.Intent{}), ErrAutoscalingNotSupportedType)
+}
@@ -1,17 +1,28 @@
 package workload
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 
+	"golang.org/x/exp/slices"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
 	"kusionstack.io/kusion/pkg/modules"
 )
 
+var (
+	ErrInvalidJobRestartPolicy     = errors.New("restartPolicy of job must be one of Never and OnFailure")
+	ErrInvalidJobConcurrencyPolicy = errors.New("concurrencyPolicy of job must be one of Allow, Forbid and Replace")
+	ErrCronJobOnlyFields           = errors.New("concurrencyPolicy, startingDeadlineSeconds and history limits are only supported by the job with schedule")
+)
+
 type jobGenerator struct {
 	project   string
 	stack     string
@@ -51,6 +62,17 @@ func (g *jobGenerator) Generate(spec *apiv1.Intent) error {
 	if err := completeBaseWorkload(&g.job.Base, g.jobConfig); err != nil {
 		return fmt.Errorf("complete job input by workspace config failed, %w", err)
 	}
+	// validate the options set by the application before completing them with the workspace defaults, where the
+	// defaults of the scheduled job options don't apply to the one-off job
+	if err := validateJobOptions(g.job); err != nil {
+		return err
+	}
+	if err := completeJobOptions(g.job, g.jobConfig); err != nil {
+		return fmt.Errorf("complete job input by workspace config failed, %w", err)
+	}
+	if err := validateJobOptions(g.job); err != nil {
+		return err
+	}
 
 	uniqueAppName := modules.UniqueAppName(g.project, g.stack, g.appName)
 
@@ -66,12 +88,16 @@ func (g *jobGenerator) Generate(spec *apiv1.Intent) error {
 		),
 	}
 
-	containers, volumes, configMaps, err := toOrderedContainers(job.Containers, uniqueAppName)
+	nativeSidecar, err := isNativeSidecar(g.jobConfig)
+	if err != nil {
+		return err
+	}
+	podContainers, err := toPodContainers(&job.Base, uniqueAppName, nativeSidecar)
 	if err != nil {
 		return err
 	}
 
-	for _, cm := range configMaps {
+	for _, cm := range podContainers.configMaps {
 		cmObj := cm
 		cmObj.Namespace = g.namespace
 		if err = modules.AppendToIntent(
@@ -84,7 +110,16 @@ func (g *jobGenerator) Generate(spec *apiv1.Intent) error {
 		}
 	}
 
+	restartPolicy := corev1.RestartPolicyNever
+	if job.RestartPolicy != "" {
+		restartPolicy = corev1.RestartPolicy(job.RestartPolicy)
+	}
 	jobSpec := batchv1.JobSpec{
+		BackoffLimit:            job.BackoffLimit,
+		ActiveDeadlineSeconds:   job.ActiveDeadlineSeconds,
+		TTLSecondsAfterFinished: job.TTLSecondsAfterFinished,
+		Completions:             job.Completions,
+		Parallelism:             job.Parallelism,
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
 				Labels: modules.MergeMaps(
@@ -96,15 +131,25 @@ func (g *jobGenerator) Generate(spec *apiv1.Intent) error {
 				),
 			},
 			Spec: corev1.PodSpec{
-				Containers:    containers,
-				RestartPolicy: corev1.RestartPolicyNever,
-				Volumes:       volumes,
+				InitContainers: podContainers.initContainers,
+				Containers:     podContainers.containers,
+				RestartPolicy:  restartPolicy,
+				Volumes:        podContainers.volumes,
 			},
 		},
 	}
+	if err = completePodScheduling(&jobSpec.Template.Spec, g.jobConfig, modules.UniqueAppLabels(g.project, g.appName)); err != nil {
+		return err
+	}
+	if err = completeSecurityContext(&jobSpec.Template.Spec, g.jobConfig); err != nil {
+		return err
+	}
 
+	var resource runtime.Object
+	var id string
+	podSpecFields := []string{"spec", "template", "spec"}
 	if job.Schedule == "" {
-		resource := &batchv1.Job{
+		jobResource := &batchv1.Job{
 			ObjectMeta: meta,
 			TypeMeta: metav1.TypeMeta{
 				Kind:       "Job",
@@ -112,21 +157,99 @@ func (g *jobGenerator) Generate(spec *apiv1.Intent) error {
 			},
 			Spec: jobSpec,
 		}
-		return modules.AppendToIntent(apiv1.Kubernetes, modules.KubernetesResourceID(resource.TypeMeta, resource.ObjectMeta), spec, resource)
+		// suspend the one-off job, while the scheduled job suspends the subsequent executions by the CronJob
+		jobResource.Spec.Suspend = job.Suspend
+		resource, id = jobResource, modules.KubernetesResourceID(jobResource.TypeMeta, jobResource.ObjectMeta)
+	} else {
+		cronJobResource := &batchv1.CronJob{
+			ObjectMeta: meta,
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CronJob",
+				APIVersion: batchv1.SchemeGroupVersion.String(),
+			},
+			Spec: batchv1.CronJobSpec{
+				JobTemplate: batchv1.JobTemplateSpec{
+					Spec: jobSpec,
+				},
+				Schedule:                   job.Schedule,
+				ConcurrencyPolicy:          batchv1.ConcurrencyPolicy(job.ConcurrencyPolicy),
+				StartingDeadlineSeconds:    job.StartingDeadlineSeconds,
+				Suspend:                    job.Suspend,
+				SuccessfulJobsHistoryLimit: job.SuccessfulJobsHistoryLimit,
+				FailedJobsHistoryLimit:     job.FailedJobsHistoryLimit,
+			},
+		}
+		resource, id = cronJobResource, modules.KubernetesResourceID(cronJobResource.TypeMeta, cronJobResource.ObjectMeta)
+		podSpecFields = append([]string{"spec", "jobTemplate"}, podSpecFields...)
+	}
+	if err = modules.AppendToIntent(apiv1.Kubernetes, id, spec, resource); err != nil {
+		return err
 	}
+	return setNativeSidecars(spec.Resources[len(spec.Resources)-1].Attributes, podSpecFields, podContainers.nativeSidecars)
+}
 
-	resource := &batchv1.CronJob{
-		ObjectMeta: meta,
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "CronJob",
-			APIVersion: batchv1.SchemeGroupVersion.String(),
-		},
-		Spec: batchv1.CronJobSpec{
-			JobTemplate: batchv1.JobTemplateSpec{
-				Spec: jobSpec,
-			},
-			Schedule: job.Schedule,
-		},
+// cronJobOptionFields are the fields of the job options only supported by the scheduled job.
+var cronJobOptionFields = []string{
+	workload.FieldConcurrencyPolicy,
+	workload.FieldStartingDeadlineSeconds,
+	workload.FieldSuccessfulJobsHistoryLimit,
+	workload.FieldFailedJobsHistoryLimit,
+}
+
+// jobOptionFields are the fields of the job options, which are also the fields of the workspace job module config.
+var jobOptionFields = []string{
+	workload.FieldBackoffLimit,
+	workload.FieldActiveDeadlineSeconds,
+	workload.FieldTTLSecondsAfterFinished,
+	workload.FieldCompletions,
+	workload.FieldParallelism,
+	workload.FieldRestartPolicy,
+	workload.FieldConcurrencyPolicy,
+	workload.FieldStartingDeadlineSeconds,
+	workload.FieldSuspend,
+	workload.FieldSuccessfulJobsHistoryLimit,
+	workload.FieldFailedJobsHistoryLimit,
+}
+
+// completeJobOptions uses the workspace job module config as the defaults of the job options unset by the
+// application. The explicitly set zero values are kept, e.g. backoffLimit 0. The defaults of the options only
+// supported by the scheduled job are ignored if the job has no schedule.
+func completeJobOptions(job *workload.Job, config apiv1.GenericConfig) error {
+	defaults := &workload.Job{}
+	if err := decodeConfigFields(config, defaults, jobOptionFields...); err != nil {
+		return err
+	}
+
+	dst, src := reflect.ValueOf(job).Elem(), reflect.ValueOf(defaults).Elem()
+	for i := 0; i < dst.NumField(); i++ {
+		name, _, _ := strings.Cut(dst.Type().Field(i).Tag.Get("json"), ",")
+		if !slices.Contains(jobOptionFields, name) || !dst.Field(i).IsZero() {
+			continue
+		}
+		if job.Schedule == "" && slices.Contains(cronJobOptionFields, name) {
+			continue
+		}
+		dst.Field(i).Set(src.Field(i))
+	}
+	return nil
+}
+
+// validateJobOptions validates the job options, where the options of the scheduled job are only allowed if the
+// schedule is set.
+func validateJobOptions(job *workload.Job) error {
+	switch job.RestartPolicy {
+	case "", workload.JobRestartPolicyNever, workload.JobRestartPolicyOnFailure:
+	default:
+		return ErrInvalidJobRestartPolicy
+	}
+	switch job.ConcurrencyPolicy {
+	case "", workload.JobConcurrencyPolicyAllow, workload.JobConcurrencyPolicyForbid, workload.JobConcurrencyPolicyReplace:
+	default:
+		return ErrInvalidJobConcurrencyPolicy
+	}
+	if job.Schedule == "" && (job.ConcurrencyPolicy != "" || job.StartingDeadlineSeconds != nil ||
+		job.SuccessfulJobsHistoryLimit != nil || job.FailedJobsHistoryLimit != nil) {
+		return ErrCronJobOnlyFields
 	}
-	return modules.AppendToIntent(apiv1.Kubernetes, modules.KubernetesResourceID(resource.TypeMeta, resource.ObjectMeta), spec, resource)
+	return nil
 }
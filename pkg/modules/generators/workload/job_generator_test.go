@@ -8,6 +8,7 @@ import (
 
 	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
 	"kusionstack.io/kusion/pkg/modules"
 )
 
@@ -147,3 +148,189 @@ func mapToUnstructured(data map[string]interface{}) *unstructured.Unstructured {
 	unstructuredObj.SetUnstructuredContent(data)
 	return unstructuredObj
 }
+
+func TestJobGenerator_GenerateWithSidecars(t *testing.T) {
+	generator, err := NewJobGenerator(&Generator{
+		Project:   "default",
+		Stack:     "dev",
+		App:       "foo",
+		Namespace: "default",
+		Workload: &workload.Workload{
+			Job: &workload.Job{
+				Base: workload.Base{
+					Containers:     map[string]container.Container{"main": {Image: "busybox:1.28"}},
+					InitContainers: map[string]container.Container{"init": {Image: "busybox:1.28"}},
+					Sidecars:       map[string]container.Container{"proxy": {Image: "envoy:v1"}},
+				},
+				Schedule: "* * * * *",
+			},
+		},
+		PlatformConfigs: map[string]apiv1.GenericConfig{
+			workload.ModuleJob: {"nativeSidecar": true},
+		},
+	})
+	assert.NoError(t, err)
+	spec := &apiv1.Intent{}
+	assert.NoError(t, generator.Generate(spec))
+	assert.Len(t, spec.Resources, 1)
+
+	podSpec, _, _ := unstructured.NestedMap(spec.Resources[0].Attributes, "spec", "jobTemplate", "spec", "template", "spec")
+	assert.Equal(t, []any{map[string]any{"name": "main", "image": "busybox:1.28", "resources": map[string]any{}}}, podSpec["containers"])
+	assert.Equal(t, []any{
+		map[string]any{"name": "init", "image": "busybox:1.28", "resources": map[string]any{}},
+		map[string]any{"name": "proxy", "image": "envoy:v1", "resources": map[string]any{}, "restartPolicy": "Always"},
+	}, podSpec["initContainers"])
+}
+
+func TestCompleteJobOptions(t *testing.T) {
+	zero, three := int32(0), int32(3)
+	suspend := true
+	job := &workload.Job{
+		BackoffLimit:  &zero,
+		RestartPolicy: workload.JobRestartPolicyOnFailure,
+	}
+	config := apiv1.GenericConfig{
+		"labels":                  apiv1.GenericConfig{"workload-type": "Job"},
+		"backoffLimit":            6,
+		"ttlSecondsAfterFinished": 3,
+		"restartPolicy":           "Never",
+		"suspend":                 true,
+	}
+	assert.NoError(t, completeJobOptions(job, config))
+	assert.Equal(t, &workload.Job{
+		BackoffLimit:            &zero,
+		TTLSecondsAfterFinished: &three,
+		RestartPolicy:           workload.JobRestartPolicyOnFailure,
+		Suspend:                 &suspend,
+	}, job, "the options set by the application should take precedence")
+
+	assert.Error(t, completeJobOptions(&workload.Job{}, apiv1.GenericConfig{"backoffLimit": "6"}))
+
+	// the defaults of the scheduled job options only apply to the job with schedule
+	cronConfig := apiv1.GenericConfig{"concurrencyPolicy": "Forbid", "failedJobsHistoryLimit": 1}
+	oneOff, scheduled := &workload.Job{}, &workload.Job{Schedule: "0 * * * *"}
+	assert.NoError(t, completeJobOptions(oneOff, cronConfig))
+	assert.Equal(t, &workload.Job{}, oneOff)
+	assert.NoError(t, completeJobOptions(scheduled, cronConfig))
+	assert.Equal(t, workload.JobConcurrencyPolicyForbid, scheduled.ConcurrencyPolicy)
+	assert.Equal(t, int32(1), *scheduled.FailedJobsHistoryLimit)
+}
+
+func TestJobGenerator_GenerateWithCronJobDefaults(t *testing.T) {
+	generator, _ := NewJobGenerator(&Generator{
+		Project:   "default",
+		Stack:     "dev",
+		App:       "foo",
+		Namespace: "default",
+		Workload: &workload.Workload{
+			Job: &workload.Job{
+				Base: workload.Base{Containers: map[string]container.Container{"main": {Image: "busybox:1.28"}}},
+			},
+		},
+		PlatformConfigs: map[string]apiv1.GenericConfig{
+			workload.ModuleJob: {"concurrencyPolicy": "Forbid", "startingDeadlineSeconds": 60},
+		},
+	})
+	spec := &apiv1.Intent{}
+	assert.NoError(t, generator.Generate(spec), "the workspace defaults of the scheduled job should not fail the one-off job")
+	assert.Equal(t, "Job", spec.Resources[0].Attributes["kind"])
+}
+
+func TestValidateJobOptions(t *testing.T) {
+	limit := int32(1)
+	testCases := []struct {
+		name    string
+		job     *workload.Job
+		wantErr error
+	}{
+		{
+			name: "valid job",
+			job:  &workload.Job{RestartPolicy: workload.JobRestartPolicyOnFailure},
+		},
+		{
+			name: "valid cron job",
+			job: &workload.Job{
+				Schedule:               "0 * * * *",
+				ConcurrencyPolicy:      workload.JobConcurrencyPolicyForbid,
+				FailedJobsHistoryLimit: &limit,
+			},
+		},
+		{
+			name:    "invalid restart policy",
+			job:     &workload.Job{RestartPolicy: "Always"},
+			wantErr: ErrInvalidJobRestartPolicy,
+		},
+		{
+			name:    "invalid concurrency policy",
+			job:     &workload.Job{Schedule: "0 * * * *", ConcurrencyPolicy: "Skip"},
+			wantErr: ErrInvalidJobConcurrencyPolicy,
+		},
+		{
+			name:    "cron job only fields",
+			job:     &workload.Job{SuccessfulJobsHistoryLimit: &limit},
+			wantErr: ErrCronJobOnlyFields,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, validateJobOptions(tc.job))
+		})
+	}
+}
+
+func TestJobGenerator_GenerateWithOptions(t *testing.T) {
+	backoffLimit, deadline, completions := int32(2), int64(600), int32(3)
+	newGenerator := func(job *workload.Job) modules.Generator {
+		job.Containers = map[string]container.Container{"main": {Image: "busybox:1.28"}}
+		generator, _ := NewJobGenerator(&Generator{
+			Project:   "default",
+			Stack:     "dev",
+			App:       "foo",
+			Namespace: "default",
+			Workload:  &workload.Workload{Job: job},
+			PlatformConfigs: map[string]apiv1.GenericConfig{
+				workload.ModuleJob: {"ttlSecondsAfterFinished": 300, "suspend": true},
+			},
+		})
+		return generator
+	}
+
+	t.Run("one-off job", func(t *testing.T) {
+		spec := &apiv1.Intent{}
+		assert.NoError(t, newGenerator(&workload.Job{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Completions:           &completions,
+			RestartPolicy:         workload.JobRestartPolicyOnFailure,
+		}).Generate(spec))
+		jobSpec, _, _ := unstructured.NestedMap(spec.Resources[0].Attributes, "spec")
+		assert.Equal(t, int64(2), jobSpec["backoffLimit"])
+		assert.Equal(t, int64(600), jobSpec["activeDeadlineSeconds"])
+		assert.Equal(t, int64(300), jobSpec["ttlSecondsAfterFinished"])
+		assert.Equal(t, int64(3), jobSpec["completions"])
+		assert.Equal(t, true, jobSpec["suspend"])
+		restartPolicy, _, _ := unstructured.NestedString(jobSpec, "template", "spec", "restartPolicy")
+		assert.Equal(t, "OnFailure", restartPolicy)
+	})
+
+	t.Run("scheduled job", func(t *testing.T) {
+		spec := &apiv1.Intent{}
+		assert.NoError(t, newGenerator(&workload.Job{
+			Schedule:          "0 * * * *",
+			BackoffLimit:      &backoffLimit,
+			ConcurrencyPolicy: workload.JobConcurrencyPolicyReplace,
+		}).Generate(spec))
+		cronJobSpec, _, _ := unstructured.NestedMap(spec.Resources[0].Attributes, "spec")
+		assert.Equal(t, "Replace", cronJobSpec["concurrencyPolicy"])
+		assert.Equal(t, true, cronJobSpec["suspend"])
+		jobSpec, _, _ := unstructured.NestedMap(cronJobSpec, "jobTemplate", "spec")
+		assert.Equal(t, int64(2), jobSpec["backoffLimit"])
+		assert.Equal(t, int64(300), jobSpec["ttlSecondsAfterFinished"])
+		assert.NotContains(t, jobSpec, "suspend", "the jobs of the scheduled job should not be suspended")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		err := newGenerator(&workload.Job{ConcurrencyPolicy: workload.JobConcurrencyPolicyForbid}).Generate(&apiv1.Intent{})
+		assert.ErrorIs(t, err, ErrCronJobOnlyFields)
+	})
+}
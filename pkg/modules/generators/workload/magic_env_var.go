@@ -1,35 +1,150 @@
 package workload
 
 import (
+	"errors"
+	"fmt"
 	"strings"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"kusionstack.io/kusion/pkg/modules/proto"
 )
 
 var (
-	SecretEnvParser = NewSecretEnvParser()
-	RawEnvParser    = NewRawEnvParser()
+	SecretEnvParser      = NewSecretEnvParser()
+	ConfigMapEnvParser   = NewConfigMapEnvParser()
+	FieldRefEnvParser    = NewFieldRefEnvParser()
+	ResourceRefEnvParser = NewResourceRefEnvParser()
+	RawEnvParser         = NewRawEnvParser()
 
+	parsersLock      sync.RWMutex
 	supportedParsers = []MagicEnvParser{
 		SecretEnvParser,
+		ConfigMapEnvParser,
+		FieldRefEnvParser,
+		ResourceRefEnvParser,
 		// As the default parser, the RawEnvParser should be placed at
 		// the end.
 		RawEnvParser,
 	}
+	// registeredSchemes records the schemes returned by the module plugins
+	registeredSchemes = map[string]*proto.MagicEnvScheme{}
+
+	ErrEmptyMagicEnvScheme    = errors.New("empty scheme of the magic environment variables")
+	ErrConflictMagicEnvScheme = errors.New("conflicting scheme of the magic environment variables")
 )
 
+// RegisterMagicEnvParser registers the parsers, which are matched before the
+// default RawEnvParser in the order of registration. It is expected to be
+// called during the startup by the built-in generators, e.g. registers a
+// scheme referring to the Secrets it generates, while the module plugins
+// return the schemes in the GeneratorResponse instead:
+//
+//	RegisterMagicEnvParser(NewSchemeSecretEnvParser("mysql", func(instance string) string {
+//		return instance + "-mysql"
+//	}))
+func RegisterMagicEnvParser(parsers ...MagicEnvParser) {
+	parsersLock.Lock()
+	defer parsersLock.Unlock()
+	registerMagicEnvParsers(parsers...)
+}
+
+// RegisterMagicEnvSchemes registers the schemes returned by the module plugins,
+// which refer to the Secrets generated by the modules. The same scheme is
+// skipped when registered again, for the same module is called for each
+// application, while it returns ErrConflictMagicEnvScheme if the scheme is
+// registered with another Secret name or used by a built-in parser.
+func RegisterMagicEnvSchemes(schemes ...*proto.MagicEnvScheme) error {
+	parsersLock.Lock()
+	defer parsersLock.Unlock()
+	for _, scheme := range schemes {
+		name := scheme.GetScheme()
+		if name == "" {
+			return ErrEmptyMagicEnvScheme
+		}
+		prefix, suffix := scheme.GetSecretNamePrefix(), scheme.GetSecretNameSuffix()
+		if registered, ok := registeredSchemes[name]; ok {
+			if registered.GetSecretNamePrefix() == prefix && registered.GetSecretNameSuffix() == suffix {
+				continue
+			}
+			return fmt.Errorf("%w: %s", ErrConflictMagicEnvScheme, name)
+		}
+		if matchSchemeParser(name) {
+			return fmt.Errorf("%w: %s", ErrConflictMagicEnvScheme, name)
+		}
+		registeredSchemes[name] = scheme
+		registerMagicEnvParsers(NewSchemeSecretEnvParser(name, func(instance string) string {
+			return prefix + instance + suffix
+		}))
+	}
+	return nil
+}
+
+// registerMagicEnvParsers inserts the parsers before the default RawEnvParser,
+// and the caller must hold the parsersLock.
+func registerMagicEnvParsers(parsers ...MagicEnvParser) {
+	last := len(supportedParsers) - 1
+	registered := make([]MagicEnvParser, 0, len(supportedParsers)+len(parsers))
+	registered = append(registered, supportedParsers[:last]...)
+	registered = append(registered, parsers...)
+	supportedParsers = append(registered, supportedParsers[last])
+}
+
+// matchSchemeParser returns true if the scheme is matched by a parser other
+// than the default RawEnvParser, and the caller must hold the parsersLock.
+func matchSchemeParser(scheme string) bool {
+	for _, p := range supportedParsers[:len(supportedParsers)-1] {
+		if p.Match("", scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMagicEnvParser returns the first parser matching the key and value.
+func matchMagicEnvParser(k, v string) MagicEnvParser {
+	parsersLock.RLock()
+	defer parsersLock.RUnlock()
+	for _, p := range supportedParsers {
+		if p.Match(k, v) {
+			return p
+		}
+	}
+	return nil
+}
+
 // MagicEnvVar generates a specialized EnvVar based on the key and
-// value of environment.
+// value of environment. It returns nil if the value is invalid for
+// the matched parser.
 //
 // Examples:
 //
 //	MagicEnvVar("secret_key", "secret://my_secret/my_key")
+//	MagicEnvVar("config_key", "configmap://my_config/my_key")
+//	MagicEnvVar("pod_name", "fieldref://metadata.name")
+//	MagicEnvVar("cpu_limit", "resourceref://limits.cpu")
 //	MagicEnvVar("key", "value")
 func MagicEnvVar(k, v string) *corev1.EnvVar {
-	for _, p := range supportedParsers {
-		if p.Match(k, v) {
-			return p.Gen(k, v)
-		}
+	if p := matchMagicEnvParser(k, v); p != nil {
+		return p.Gen(k, v)
+	}
+	return nil
+}
+
+// MagicEnvFrom generates an EnvFromSource if the value refers to a whole
+// Secret or ConfigMap, whose variables are imported in bulk with the key
+// as the prefix, where the trailing "*" of the key is trimmed. It returns
+// nil if the value doesn't refer to a whole Secret or ConfigMap.
+//
+// Examples:
+//
+//	MagicEnvFrom("DB_*", "secret://my_secret")
+//	MagicEnvFrom("*", "configmap://my_config")
+func MagicEnvFrom(k, v string) *corev1.EnvFromSource {
+	if p, ok := matchMagicEnvParser(k, v).(MagicEnvFromParser); ok {
+		return p.GenEnvFrom(k, v)
 	}
 	return nil
 }
@@ -40,6 +155,13 @@ type MagicEnvParser interface {
 	Gen(k, v string) *corev1.EnvVar
 }
 
+// MagicEnvFromParser is an interface for parsers which support importing
+// a whole Secret or ConfigMap in bulk.
+type MagicEnvFromParser interface {
+	MagicEnvParser
+	GenEnvFrom(k, v string) *corev1.EnvFromSource
+}
+
 // rawEnvParser is a parser for raw environment variables.
 type rawEnvParser struct{}
 
@@ -63,13 +185,22 @@ func (*rawEnvParser) Gen(k string, v string) *corev1.EnvVar {
 
 // secretEnvParser is a parser for secret-based environment variables.
 type secretEnvParser struct {
-	prefix string
+	prefix     string
+	secretName func(name string) string
 }
 
 // NewSecretEnvParser creates a new instance of SecretEnvParser.
 func NewSecretEnvParser() MagicEnvParser {
+	return NewSchemeSecretEnvParser("secret", nil)
+}
+
+// NewSchemeSecretEnvParser creates a parser for the values in the format of
+// <scheme>://<name>/<key>, which refer to the key of the Secret named by
+// secretName(name). The name is used as the Secret name if secretName is nil.
+func NewSchemeSecretEnvParser(scheme string, secretName func(name string) string) MagicEnvParser {
 	return &secretEnvParser{
-		prefix: "secret://",
+		prefix:     scheme + "://",
+		secretName: secretName,
 	}
 }
 
@@ -80,9 +211,8 @@ func (p *secretEnvParser) Match(_ string, v string) bool {
 
 // Gen generates a secret-based environment variable.
 func (p *secretEnvParser) Gen(k string, v string) *corev1.EnvVar {
-	vv := strings.TrimPrefix(v, p.prefix)
-	vs := strings.Split(vv, "/")
-	if len(vs) != 2 {
+	vs := splitReference(v, p.prefix, 2)
+	if vs == nil {
 		return nil
 	}
 
@@ -90,6 +220,67 @@ func (p *secretEnvParser) Gen(k string, v string) *corev1.EnvVar {
 		Name: k,
 		ValueFrom: &corev1.EnvVarSource{
 			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: p.name(vs[0]),
+				},
+				Key: vs[1],
+			},
+		},
+	}
+}
+
+// GenEnvFrom generates an EnvFromSource importing the whole Secret.
+func (p *secretEnvParser) GenEnvFrom(k string, v string) *corev1.EnvFromSource {
+	vs := splitReference(v, p.prefix, 1)
+	if vs == nil {
+		return nil
+	}
+
+	return &corev1.EnvFromSource{
+		Prefix: strings.TrimSuffix(k, "*"),
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: p.name(vs[0]),
+			},
+		},
+	}
+}
+
+func (p *secretEnvParser) name(name string) string {
+	if p.secretName == nil {
+		return name
+	}
+	return p.secretName(name)
+}
+
+// configMapEnvParser is a parser for configMap-based environment variables.
+type configMapEnvParser struct {
+	prefix string
+}
+
+// NewConfigMapEnvParser creates a new instance of ConfigMapEnvParser.
+func NewConfigMapEnvParser() MagicEnvParser {
+	return &configMapEnvParser{
+		prefix: "configmap://",
+	}
+}
+
+// Match checks if the value matches the configMap parser.
+func (p *configMapEnvParser) Match(_ string, v string) bool {
+	return strings.HasPrefix(v, p.prefix)
+}
+
+// Gen generates a configMap-based environment variable.
+func (p *configMapEnvParser) Gen(k string, v string) *corev1.EnvVar {
+	vs := splitReference(v, p.prefix, 2)
+	if vs == nil {
+		return nil
+	}
+
+	return &corev1.EnvVar{
+		Name: k,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{
 					Name: vs[0],
 				},
@@ -98,3 +289,118 @@ func (p *secretEnvParser) Gen(k string, v string) *corev1.EnvVar {
 		},
 	}
 }
+
+// GenEnvFrom generates an EnvFromSource importing the whole ConfigMap.
+func (p *configMapEnvParser) GenEnvFrom(k string, v string) *corev1.EnvFromSource {
+	vs := splitReference(v, p.prefix, 1)
+	if vs == nil {
+		return nil
+	}
+
+	return &corev1.EnvFromSource{
+		Prefix: strings.TrimSuffix(k, "*"),
+		ConfigMapRef: &corev1.ConfigMapEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: vs[0],
+			},
+		},
+	}
+}
+
+// fieldRefEnvParser is a parser for environment variables referring to the
+// fields of the pod by the downward API, e.g. fieldref://metadata.name.
+type fieldRefEnvParser struct {
+	prefix string
+}
+
+// NewFieldRefEnvParser creates a new instance of FieldRefEnvParser.
+func NewFieldRefEnvParser() MagicEnvParser {
+	return &fieldRefEnvParser{
+		prefix: "fieldref://",
+	}
+}
+
+// Match checks if the value matches the fieldRef parser.
+func (p *fieldRefEnvParser) Match(_ string, v string) bool {
+	return strings.HasPrefix(v, p.prefix)
+}
+
+// Gen generates a fieldRef-based environment variable.
+func (p *fieldRefEnvParser) Gen(k string, v string) *corev1.EnvVar {
+	vs := splitReference(v, p.prefix, 1)
+	if vs == nil {
+		return nil
+	}
+
+	return &corev1.EnvVar{
+		Name: k,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: vs[0],
+			},
+		},
+	}
+}
+
+// resourceRefEnvParser is a parser for environment variables referring to
+// the resources of the container by the downward API, in the format of
+// resourceref://[container/]resource[?divisor=quantity], e.g.
+// resourceref://limits.cpu or resourceref://main/requests.memory?divisor=1Mi.
+type resourceRefEnvParser struct {
+	prefix string
+}
+
+// NewResourceRefEnvParser creates a new instance of ResourceRefEnvParser.
+func NewResourceRefEnvParser() MagicEnvParser {
+	return &resourceRefEnvParser{
+		prefix: "resourceref://",
+	}
+}
+
+// Match checks if the value matches the resourceRef parser.
+func (p *resourceRefEnvParser) Match(_ string, v string) bool {
+	return strings.HasPrefix(v, p.prefix)
+}
+
+// Gen generates a resourceRef-based environment variable.
+func (p *resourceRefEnvParser) Gen(k string, v string) *corev1.EnvVar {
+	ref, divisor, hasDivisor := strings.Cut(v, "?divisor=")
+	selector := &corev1.ResourceFieldSelector{}
+	if hasDivisor {
+		quantity, err := resource.ParseQuantity(divisor)
+		if err != nil {
+			return nil
+		}
+		selector.Divisor = quantity
+	}
+
+	if vs := splitReference(ref, p.prefix, 2); vs != nil {
+		selector.ContainerName, selector.Resource = vs[0], vs[1]
+	} else if vs = splitReference(ref, p.prefix, 1); vs != nil {
+		selector.Resource = vs[0]
+	} else {
+		return nil
+	}
+
+	return &corev1.EnvVar{
+		Name: k,
+		ValueFrom: &corev1.EnvVarSource{
+			ResourceFieldRef: selector,
+		},
+	}
+}
+
+// splitReference trims the prefix of the value and splits it by "/", and
+// returns nil if the number of the non-empty segments is not n.
+func splitReference(v, prefix string, n int) []string {
+	vs := strings.Split(strings.TrimPrefix(v, prefix), "/")
+	if len(vs) != n {
+		return nil
+	}
+	for _, s := range vs {
+		if s == "" {
+			return nil
+		}
+	}
+	return vs
+}
@@ -4,6 +4,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"kusionstack.io/kusion/pkg/modules/proto"
 )
 
 func TestMagicEnvVar(t *testing.T) {
@@ -20,3 +25,121 @@ func TestMagicEnvVar(t *testing.T) {
 	assert.Equal(t, "my_secret", secretEnv.ValueFrom.SecretKeyRef.LocalObjectReference.Name, "Expected secret name to be 'my_secret'")
 	assert.Equal(t, "my_key", secretEnv.ValueFrom.SecretKeyRef.Key, "Expected secret key to be 'my_key'")
 }
+
+func TestMagicEnvVar_ValueFrom(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected *corev1.EnvVarSource
+	}{
+		"configmap": {
+			value: "configmap://my_config/my_key",
+			expected: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "my_config"},
+					Key:                  "my_key",
+				},
+			},
+		},
+		"fieldref": {
+			value: "fieldref://metadata.name",
+			expected: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			},
+		},
+		"resourceref": {
+			value: "resourceref://limits.cpu",
+			expected: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.cpu"},
+			},
+		},
+		"resourceref with container and divisor": {
+			value: "resourceref://main/requests.memory?divisor=1Mi",
+			expected: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{
+					ContainerName: "main",
+					Resource:      "requests.memory",
+					Divisor:       resource.MustParse("1Mi"),
+				},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := MagicEnvVar("key", tc.value)
+			assert.Equal(t, &corev1.EnvVar{Name: "key", ValueFrom: tc.expected}, env)
+		})
+	}
+
+	// invalid references
+	assert.Nil(t, MagicEnvVar("key", "secret://my_secret"))
+	assert.Nil(t, MagicEnvVar("key", "configmap://my_config/"))
+	assert.Nil(t, MagicEnvVar("key", "resourceref://limits.cpu?divisor=invalid"))
+}
+
+func TestMagicEnvFrom(t *testing.T) {
+	assert.Equal(t, &corev1.EnvFromSource{
+		Prefix: "DB_",
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "my_secret"},
+		},
+	}, MagicEnvFrom("DB_*", "secret://my_secret"))
+	assert.Equal(t, &corev1.EnvFromSource{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "my_config"},
+		},
+	}, MagicEnvFrom("*", "configmap://my_config"))
+
+	assert.Nil(t, MagicEnvFrom("key", "secret://my_secret/my_key"))
+	assert.Nil(t, MagicEnvFrom("key", "fieldref://metadata.name"))
+	assert.Nil(t, MagicEnvFrom("key", "value"))
+}
+
+func TestRegisterMagicEnvParser(t *testing.T) {
+	origin := supportedParsers
+	defer func() {
+		supportedParsers = origin
+	}()
+
+	RegisterMagicEnvParser(NewSchemeSecretEnvParser("mysql", func(instance string) string {
+		return instance + "-mysql"
+	}))
+	assert.Equal(t, RawEnvParser, supportedParsers[len(supportedParsers)-1], "RawEnvParser should be the last parser")
+
+	env := MagicEnvVar("password", "mysql://my_db/password")
+	assert.Equal(t, "my_db-mysql", env.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "password", env.ValueFrom.SecretKeyRef.Key)
+
+	envFrom := MagicEnvFrom("DB_", "mysql://my_db")
+	assert.Equal(t, "DB_", envFrom.Prefix)
+	assert.Equal(t, "my_db-mysql", envFrom.SecretRef.Name)
+}
+
+func TestRegisterMagicEnvSchemes(t *testing.T) {
+	origin, originSchemes := supportedParsers, registeredSchemes
+	defer func() {
+		supportedParsers, registeredSchemes = origin, originSchemes
+	}()
+	registeredSchemes = map[string]*proto.MagicEnvScheme{}
+
+	schemes := []*proto.MagicEnvScheme{
+		{Scheme: "mysql", SecretNameSuffix: "-mysql"},
+	}
+	require.NoError(t, RegisterMagicEnvSchemes(schemes...))
+	// registered again when the module is called for another application
+	require.NoError(t, RegisterMagicEnvSchemes(schemes...))
+	assert.Len(t, supportedParsers, len(origin)+1)
+	assert.Equal(t, RawEnvParser, supportedParsers[len(supportedParsers)-1], "RawEnvParser should be the last parser")
+
+	env := MagicEnvVar("password", "mysql://my_db/password")
+	assert.Equal(t, "my_db-mysql", env.ValueFrom.SecretKeyRef.Name)
+
+	// the scheme referring to another Secret name or used by a built-in parser conflicts
+	err := RegisterMagicEnvSchemes(&proto.MagicEnvScheme{Scheme: "mysql", SecretNamePrefix: "module-"})
+	assert.ErrorIs(t, err, ErrConflictMagicEnvScheme)
+	err = RegisterMagicEnvSchemes(&proto.MagicEnvScheme{Scheme: "secret", SecretNamePrefix: "module-"})
+	assert.ErrorIs(t, err, ErrConflictMagicEnvScheme)
+	env = MagicEnvVar("password", "secret://my_secret/password")
+	assert.Equal(t, "my_secret", env.ValueFrom.SecretKeyRef.Name)
+
+	assert.ErrorIs(t, RegisterMagicEnvSchemes(&proto.MagicEnvScheme{}), ErrEmptyMagicEnvScheme)
+}
@@ -0,0 +1,112 @@
+package workload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+)
+
+var ErrInvalidPodDisruptionBudget = errors.New("podDisruptionBudget must have either minAvailable or maxUnavailable")
+
+// podScheduling is the scheduling fields of the workspace module config merged into the PodSpec.
+type podScheduling struct {
+	NodeSelector              map[string]string                 `json:"nodeSelector,omitempty"`
+	Tolerations               []corev1.Toleration               `json:"tolerations,omitempty"`
+	Affinity                  *corev1.Affinity                  `json:"affinity,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PriorityClassName         string                            `json:"priorityClassName,omitempty"`
+}
+
+// podDisruptionBudget is the podDisruptionBudget of the workspace module config.
+type podDisruptionBudget struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// decodeConfigFields decodes the fields of the workspace module config into the object by the json tags.
+func decodeConfigFields(config apiv1.GenericConfig, obj any, fields ...string) error {
+	m := make(map[string]any)
+	for _, field := range fields {
+		if value, ok := config[field]; ok {
+			m[field] = value
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	content, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, obj)
+}
+
+// completePodScheduling merges the scheduling fields of the workspace module config into the PodSpec. The label
+// selector of the topology spread constraints defaults to the selectors of the workload.
+func completePodScheduling(podSpec *corev1.PodSpec, config apiv1.GenericConfig, selectors map[string]string) error {
+	scheduling := &podScheduling{}
+	if err := decodeConfigFields(config, scheduling,
+		workload.FieldNodeSelector,
+		workload.FieldTolerations,
+		workload.FieldAffinity,
+		workload.FieldTopologySpreadConstraints,
+		workload.FieldPriorityClassName,
+	); err != nil {
+		return fmt.Errorf("invalid scheduling config, %w", err)
+	}
+
+	podSpec.NodeSelector = scheduling.NodeSelector
+	podSpec.Tolerations = scheduling.Tolerations
+	podSpec.Affinity = scheduling.Affinity
+	podSpec.PriorityClassName = scheduling.PriorityClassName
+	for i := range scheduling.TopologySpreadConstraints {
+		if scheduling.TopologySpreadConstraints[i].LabelSelector == nil {
+			scheduling.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{MatchLabels: selectors}
+		}
+	}
+	podSpec.TopologySpreadConstraints = scheduling.TopologySpreadConstraints
+	return nil
+}
+
+// toPodDisruptionBudget returns the PodDisruptionBudget of the workspace module config selecting the pods of the
+// workload, and nil if not set.
+func toPodDisruptionBudget(config apiv1.GenericConfig, objectMeta metav1.ObjectMeta, selectors map[string]string) (*policyv1.PodDisruptionBudget, error) {
+	pdbs := struct {
+		PodDisruptionBudget *podDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	}{}
+	if err := decodeConfigFields(config, &pdbs, workload.FieldPodDisruptionBudget); err != nil {
+		return nil, fmt.Errorf("invalid podDisruptionBudget config, %w", err)
+	}
+	pdb := pdbs.PodDisruptionBudget
+	if pdb == nil {
+		return nil, nil
+	}
+	if (pdb.MinAvailable == nil) == (pdb.MaxUnavailable == nil) {
+		return nil, ErrInvalidPodDisruptionBudget
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objectMeta.Name,
+			Namespace: objectMeta.Namespace,
+			Labels:    objectMeta.Labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   pdb.MinAvailable,
+			MaxUnavailable: pdb.MaxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: selectors},
+		},
+	}, nil
+}
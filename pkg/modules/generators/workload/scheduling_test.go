@@ -0,0 +1,241 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
+)
+
+func TestCompletePodScheduling(t *testing.T) {
+	selectors := map[string]string{"app.kubernetes.io/name": "foo"}
+	testcases := []struct {
+		name     string
+		config   apiv1.GenericConfig
+		expected corev1.PodSpec
+		wantErr  bool
+	}{
+		{
+			name:   "not set",
+			config: apiv1.GenericConfig{"replicas": 2},
+		},
+		{
+			name: "scheduling fields",
+			config: apiv1.GenericConfig{
+				"nodeSelector": apiv1.GenericConfig{"disktype": "ssd"},
+				"tolerations": []any{
+					apiv1.GenericConfig{"key": "dedicated", "operator": "Equal", "value": "prod", "effect": "NoSchedule"},
+				},
+				"affinity": apiv1.GenericConfig{
+					"podAntiAffinity": apiv1.GenericConfig{
+						"requiredDuringSchedulingIgnoredDuringExecution": []any{
+							apiv1.GenericConfig{"topologyKey": "kubernetes.io/hostname"},
+						},
+					},
+				},
+				"topologySpreadConstraints": []any{
+					apiv1.GenericConfig{"maxSkew": 1, "topologyKey": "topology.kubernetes.io/zone", "whenUnsatisfiable": "DoNotSchedule"},
+					apiv1.GenericConfig{
+						"maxSkew":           2,
+						"topologyKey":       "kubernetes.io/hostname",
+						"whenUnsatisfiable": "ScheduleAnyway",
+						"labelSelector":     apiv1.GenericConfig{"matchLabels": apiv1.GenericConfig{"tier": "web"}},
+					},
+				},
+				"priorityClassName": "high-priority",
+			},
+			expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"disktype": "ssd"},
+				Tolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "prod", Effect: corev1.TaintEffectNoSchedule},
+				},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+							{TopologyKey: "kubernetes.io/hostname"},
+						},
+					},
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+						LabelSelector:     &metav1.LabelSelector{MatchLabels: selectors},
+					},
+					{
+						MaxSkew:           2,
+						TopologyKey:       "kubernetes.io/hostname",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+					},
+				},
+				PriorityClassName: "high-priority",
+			},
+		},
+		{
+			name:    "invalid tolerations",
+			config:  apiv1.GenericConfig{"tolerations": apiv1.GenericConfig{"key": "dedicated"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			podSpec := corev1.PodSpec{}
+			err := completePodScheduling(&podSpec, tc.config, selectors)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, podSpec)
+		})
+	}
+}
+
+func TestToPodDisruptionBudget(t *testing.T) {
+	minAvailable := intstr.FromInt(1)
+	maxUnavailable := intstr.FromString("25%")
+	testcases := []struct {
+		name     string
+		config   apiv1.GenericConfig
+		expected []*intstr.IntOrString
+		wantErr  error
+	}{
+		{
+			name:   "not set",
+			config: apiv1.GenericConfig{"replicas": 2},
+		},
+		{
+			name:     "minAvailable",
+			config:   apiv1.GenericConfig{"podDisruptionBudget": apiv1.GenericConfig{"minAvailable": 1}},
+			expected: []*intstr.IntOrString{&minAvailable, nil},
+		},
+		{
+			name:     "maxUnavailable",
+			config:   apiv1.GenericConfig{"podDisruptionBudget": apiv1.GenericConfig{"maxUnavailable": "25%"}},
+			expected: []*intstr.IntOrString{nil, &maxUnavailable},
+		},
+		{
+			name:    "neither minAvailable nor maxUnavailable",
+			config:  apiv1.GenericConfig{"podDisruptionBudget": apiv1.GenericConfig{}},
+			wantErr: ErrInvalidPodDisruptionBudget,
+		},
+		{
+			name: "both minAvailable and maxUnavailable",
+			config: apiv1.GenericConfig{
+				"podDisruptionBudget": apiv1.GenericConfig{"minAvailable": 1, "maxUnavailable": 1},
+			},
+			wantErr: ErrInvalidPodDisruptionBudget,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			pdb, err := toPodDisruptionBudget(tc.config, metav1.ObjectMeta{Name: "foo"}, nil)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expected == nil {
+				assert.Nil(t, pdb)
+				return
+			}
+			assert.Equal(t, tc.expected[0], pdb.Spec.MinAvailable)
+			assert.Equal(t, tc.expected[1], pdb.Spec.MaxUnavailable)
+		})
+	}
+}
+
+func TestServiceGenerator_GenerateWithScheduling(t *testing.T) {
+	pdb := `apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+    creationTimestamp: null
+    labels:
+        app.kubernetes.io/name: foo
+        app.kubernetes.io/part-of: default
+    name: default-dev-foo
+    namespace: default
+spec:
+    minAvailable: 50%
+    selector:
+        matchLabels:
+            app.kubernetes.io/name: foo
+            app.kubernetes.io/part-of: default
+status:
+    currentHealthy: 0
+    desiredHealthy: 0
+    disruptionsAllowed: 0
+    expectedPods: 0
+`
+	g := &ServiceGenerator{
+		Project: "default",
+		Stack:   "dev",
+		App:     "foo",
+		Service: &workload.Service{
+			Base: workload.Base{
+				Containers: map[string]container.Container{"nginx": {Image: "nginx:v1"}},
+			},
+		},
+		Config: apiv1.GenericConfig{
+			"nodeSelector":        apiv1.GenericConfig{"disktype": "ssd"},
+			"priorityClassName":   "high-priority",
+			"podDisruptionBudget": apiv1.GenericConfig{"minAvailable": "50%"},
+		},
+		Namespace: "default",
+	}
+	spec := &apiv1.Intent{}
+	require.NoError(t, g.Generate(spec))
+	require.Len(t, spec.Resources, 2)
+	assert.Equal(t, "policy/v1:PodDisruptionBudget:default:default-dev-foo", spec.Resources[1].ID)
+	b, err := yaml.Marshal(spec.Resources[1].Attributes)
+	require.NoError(t, err)
+	assert.Equal(t, pdb, string(b))
+
+	podSpec := mapToUnstructured(spec.Resources[0].Attributes)
+	priorityClassName, _, _ := unstructured.NestedString(podSpec.Object, "spec", "template", "spec", "priorityClassName")
+	assert.Equal(t, "high-priority", priorityClassName)
+}
+
+func TestJobGenerator_GenerateWithScheduling(t *testing.T) {
+	g, err := NewJobGenerator(&Generator{
+		Project:   "default",
+		Stack:     "dev",
+		App:       "foo",
+		Namespace: "default",
+		Workload: &workload.Workload{
+			Job: &workload.Job{
+				Base: workload.Base{
+					Containers: map[string]container.Container{"busybox": {Image: "busybox:1.28"}},
+				},
+			},
+		},
+		PlatformConfigs: map[string]apiv1.GenericConfig{
+			workload.ModuleJob: {
+				"tolerations": []any{
+					apiv1.GenericConfig{"key": "batch", "operator": "Exists"},
+				},
+				"podDisruptionBudget": apiv1.GenericConfig{"minAvailable": 1},
+			},
+		},
+	})
+	require.NoError(t, err)
+	spec := &apiv1.Intent{}
+	require.NoError(t, g.Generate(spec))
+	require.Len(t, spec.Resources, 1, "PodDisruptionBudget should not be generated for the job")
+	tolerations, _, _ := unstructured.NestedSlice(mapToUnstructured(spec.Resources[0].Attributes).Object,
+		"spec", "template", "spec", "tolerations")
+	assert.Equal(t, []any{map[string]any{"key": "batch", "operator": "Exists"}}, tolerations)
+}
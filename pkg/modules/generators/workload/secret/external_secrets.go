@@ -0,0 +1,260 @@
+package secret
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/modules"
+)
+
+const (
+	// ExternalSecretsAPIVersion is the api version of the resources of the external-secrets operator.
+	ExternalSecretsAPIVersion = "external-secrets.io/v1beta1"
+	// DefaultExternalSecretStoreName is the name of the generated store if not specified, which is prefixed with the
+	// project name for the ClusterSecretStore.
+	DefaultExternalSecretStoreName = "kusion-secret-store"
+
+	kindExternalSecret     = "ExternalSecret"
+	kindSecretStore        = "SecretStore"
+	kindClusterSecretStore = "ClusterSecretStore"
+)
+
+var ErrExternalSecretsProviderNotSupported = errors.New("secret store provider is not supported by external secrets")
+
+// externalSecretsEnabled returns true if the external secrets are synced by the external-secrets operator.
+func (g *secretGenerator) externalSecretsEnabled() bool {
+	return g.secretStoreSpec != nil && g.secretStoreSpec.ExternalSecrets != nil
+}
+
+// appendExternalSecret appends the ExternalSecret of the secret and the store it refers to. The store is shared by
+// all the ExternalSecrets and only appended once. The ClusterSecretStore has a stable ID without the namespace, so it
+// is shared by all the applications of the project as well, and labeled with the project which owns it.
+func (g *secretGenerator) appendExternalSecret(spec *apiv1.Intent, secretName string, secretRef workload.Secret) error {
+	if g.secretStoreSpec.Provider == nil {
+		return ErrMissingSecretStore
+	}
+	config := g.secretStoreSpec.ExternalSecrets
+
+	store, err := g.generateSecretStore(config)
+	if err != nil {
+		return err
+	}
+	storeID := resourceIDOf(store)
+	if !containsResource(spec, storeID) {
+		if err = modules.AppendToIntent(apiv1.Kubernetes, storeID, spec, store); err != nil {
+			return err
+		}
+	}
+
+	externalSecret, err := g.generateExternalSecret(config, store, secretName, secretRef)
+	if err != nil {
+		return err
+	}
+	return modules.AppendToIntent(apiv1.Kubernetes, resourceIDOf(externalSecret), spec, externalSecret)
+}
+
+// generateSecretStore generates the SecretStore or ClusterSecretStore mapped from the secret store spec.
+func (g *secretGenerator) generateSecretStore(config *apiv1.ExternalSecretsConfig) (*unstructured.Unstructured, error) {
+	provider, err := externalSecretsProvider(g.secretStoreSpec.Provider)
+	if err != nil {
+		return nil, err
+	}
+	for _, providerConfig := range provider {
+		for k, v := range config.ProviderConfig {
+			providerConfig.(map[string]any)[k] = v
+		}
+	}
+
+	name, kind := config.StoreName, kindSecretStore
+	metadata := map[string]any{}
+	if config.ClusterScoped {
+		kind = kindClusterSecretStore
+		if name == "" {
+			name = g.project + "-" + DefaultExternalSecretStoreName
+		}
+		metadata["labels"] = map[string]any{"app.kubernetes.io/part-of": g.project}
+	} else {
+		if name == "" {
+			name = DefaultExternalSecretStoreName
+		}
+		metadata["namespace"] = g.namespace
+	}
+	metadata["name"] = name
+
+	return toUnstructured(map[string]any{
+		"apiVersion": ExternalSecretsAPIVersion,
+		"kind":       kind,
+		"metadata":   metadata,
+		"spec": map[string]any{
+			"provider": provider,
+		},
+	})
+}
+
+// generateExternalSecret generates the ExternalSecret which syncs the secret data from the referred store into the
+// target Kubernetes Secret with the name of the secret.
+func (g *secretGenerator) generateExternalSecret(
+	config *apiv1.ExternalSecretsConfig,
+	store *unstructured.Unstructured,
+	secretName string,
+	secretRef workload.Secret,
+) (*unstructured.Unstructured, error) {
+	keys := make([]string, 0, len(secretRef.Data))
+	for key := range secretRef.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var allErrs []error
+	data := make([]any, 0, len(keys))
+	for _, key := range keys {
+		ref, err := parseExternalSecretDataRef(secretRef.Data[key])
+		if err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+		remoteRef := map[string]any{"key": ref.Name}
+		if ref.Property != "" {
+			remoteRef["property"] = ref.Property
+		}
+		if ref.Version != "" {
+			remoteRef["version"] = ref.Version
+		}
+		data = append(data, map[string]any{
+			"secretKey": key,
+			"remoteRef": remoteRef,
+		})
+	}
+	if allErrs != nil {
+		return nil, utilerrors.NewAggregate(allErrs)
+	}
+
+	target := map[string]any{
+		"name":           secretName,
+		"creationPolicy": "Owner",
+	}
+	if secretRef.Immutable {
+		target["immutable"] = true
+	}
+	externalSecretSpec := map[string]any{
+		"secretStoreRef": map[string]any{
+			"name": store.GetName(),
+			"kind": store.GetKind(),
+		},
+		"target": target,
+		"data":   data,
+	}
+	if config.RefreshInterval != "" {
+		externalSecretSpec["refreshInterval"] = config.RefreshInterval
+	}
+
+	return toUnstructured(map[string]any{
+		"apiVersion": ExternalSecretsAPIVersion,
+		"kind":       kindExternalSecret,
+		"metadata": map[string]any{
+			"name":      secretName,
+			"namespace": g.namespace,
+		},
+		"spec": externalSecretSpec,
+	})
+}
+
+// externalSecretsProvider maps the provider spec to the provider of the store of the external-secrets operator,
+// which is keyed by the provider name.
+func externalSecretsProvider(spec *apiv1.ProviderSpec) (map[string]any, error) {
+	switch {
+	case spec.AWS != nil:
+		return map[string]any{
+			"aws": map[string]any{
+				"service": "SecretsManager",
+				"region":  spec.AWS.Region,
+			},
+		}, nil
+	case spec.Vault != nil:
+		vault := map[string]any{
+			"server":  spec.Vault.Server,
+			"version": string(spec.Vault.Version),
+		}
+		if spec.Vault.Version == "" {
+			vault["version"] = string(apiv1.VaultKVStoreV2)
+		}
+		if spec.Vault.Path != nil {
+			vault["path"] = *spec.Vault.Path
+		}
+		if spec.Vault.Namespace != "" {
+			vault["namespace"] = spec.Vault.Namespace
+		}
+		return map[string]any{"vault": vault}, nil
+	case spec.Azure != nil:
+		azure := map[string]any{}
+		if spec.Azure.VaultURL != nil {
+			azure["vaultUrl"] = *spec.Azure.VaultURL
+		}
+		if spec.Azure.TenantID != nil {
+			azure["tenantId"] = *spec.Azure.TenantID
+		}
+		if spec.Azure.EnvironmentType != "" {
+			azure["environmentType"] = string(spec.Azure.EnvironmentType)
+		}
+		return map[string]any{"azurekv": azure}, nil
+	case spec.Alicloud != nil:
+		return map[string]any{
+			"alibaba": map[string]any{
+				"regionID": spec.Alicloud.Region,
+			},
+		}, nil
+	case spec.Kubernetes != nil:
+		return map[string]any{
+			"kubernetes": map[string]any{
+				"remoteNamespace": spec.Kubernetes.Namespace,
+			},
+		}, nil
+	case spec.Fake != nil:
+		data := make([]any, 0, len(spec.Fake.Data))
+		for _, d := range spec.Fake.Data {
+			data = append(data, d)
+		}
+		return map[string]any{
+			"fake": map[string]any{"data": data},
+		}, nil
+	default:
+		return nil, ErrExternalSecretsProviderNotSupported
+	}
+}
+
+// toUnstructured converts the object to the unstructured whose values are all of the json types.
+func toUnstructured(obj map[string]any) (*unstructured.Unstructured, error) {
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal %s failed: %w", obj["kind"], err)
+	}
+	u := &unstructured.Unstructured{}
+	if err = json.Unmarshal(content, &u.Object); err != nil {
+		return nil, fmt.Errorf("json unmarshal %s failed: %w", obj["kind"], err)
+	}
+	return u, nil
+}
+
+func resourceIDOf(obj *unstructured.Unstructured) string {
+	return modules.KubernetesResourceID(
+		metav1.TypeMeta{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind()},
+		metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()},
+	)
+}
+
+func containsResource(spec *apiv1.Intent, id string) bool {
+	for _, r := range spec.Resources {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+)
+
+func TestGenerateExternalSecrets(t *testing.T) {
+	vaultPath := "secret"
+	secrets := map[string]workload.Secret{
+		"api-auth": {
+			Type: "external",
+			Data: map[string]string{
+				"accessKey": "ref://api-auth-info/accessKey?version=1",
+				"secretKey": "ref://api-auth-info/secretKey",
+			},
+			Immutable: true,
+		},
+		"access-token": {
+			Type: "external",
+			Data: map[string]string{
+				"token": "ref://token",
+			},
+		},
+	}
+	secretStoreSpec := &apiv1.SecretStoreSpec{
+		Provider: &apiv1.ProviderSpec{
+			Vault: &apiv1.VaultProvider{
+				Server:    "https://vault.example.com:8200",
+				Path:      &vaultPath,
+				Namespace: "ns1",
+			},
+		},
+		ExternalSecrets: &apiv1.ExternalSecretsConfig{
+			RefreshInterval: "1h",
+			ProviderConfig: apiv1.GenericConfig{
+				"auth": map[string]any{
+					"tokenSecretRef": map[string]any{
+						"name": "vault-token",
+						"key":  "token",
+					},
+				},
+			},
+		},
+	}
+	generator, err := NewSecretGenerator(initGeneratorRequest(testProject, secrets, secretStoreSpec))
+	require.NoError(t, err)
+
+	spec := &apiv1.Intent{}
+	require.NoError(t, generator.Generate(spec))
+	require.Len(t, spec.Resources, 3)
+
+	resources := map[string]apiv1.Resource{}
+	for _, r := range spec.Resources {
+		resources[r.ID] = r
+	}
+	store, ok := resources["external-secrets.io/v1beta1:SecretStore:helloworld:kusion-secret-store"]
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{
+		"vault": map[string]any{
+			"server":    "https://vault.example.com:8200",
+			"path":      "secret",
+			"version":   "v2",
+			"namespace": "ns1",
+			"auth": map[string]any{
+				"tokenSecretRef": map[string]any{
+					"name": "vault-token",
+					"key":  "token",
+				},
+			},
+		},
+	}, store.Attributes["spec"].(map[string]any)["provider"])
+
+	externalSecret, ok := resources["external-secrets.io/v1beta1:ExternalSecret:helloworld:api-auth"]
+	require.True(t, ok)
+	assert.Equal(t, "external-secrets.io/v1beta1, Kind=ExternalSecret", externalSecret.Extensions[apiv1.ResourceExtensionGVK])
+	assert.Equal(t, map[string]any{
+		"refreshInterval": "1h",
+		"secretStoreRef": map[string]any{
+			"name": "kusion-secret-store",
+			"kind": "SecretStore",
+		},
+		"target": map[string]any{
+			"name":           "api-auth",
+			"creationPolicy": "Owner",
+			"immutable":      true,
+		},
+		"data": []any{
+			map[string]any{
+				"secretKey": "accessKey",
+				"remoteRef": map[string]any{"key": "api-auth-info", "property": "accessKey", "version": "1"},
+			},
+			map[string]any{
+				"secretKey": "secretKey",
+				"remoteRef": map[string]any{"key": "api-auth-info", "property": "secretKey"},
+			},
+		},
+	}, externalSecret.Attributes["spec"])
+
+	_, ok = resources["external-secrets.io/v1beta1:ExternalSecret:helloworld:access-token"]
+	assert.True(t, ok)
+}
+
+func TestGenerateExternalSecrets_ClusterSecretStore(t *testing.T) {
+	secrets := map[string]workload.Secret{
+		"access-token": {
+			Type: "external",
+			Data: map[string]string{
+				"token": "ref://token",
+			},
+		},
+		"secret-token": {
+			Type: "token",
+			Data: map[string]string{
+				"token": "YmFyCg==",
+			},
+		},
+	}
+	secretStoreSpec := &apiv1.SecretStoreSpec{
+		Provider: &apiv1.ProviderSpec{
+			AWS: &apiv1.AWSProvider{
+				Region: "us-east-1",
+			},
+		},
+		ExternalSecrets: &apiv1.ExternalSecretsConfig{
+			ClusterScoped: true,
+			StoreName:     "aws-store",
+		},
+	}
+	spec := &apiv1.Intent{}
+	// the ClusterSecretStore is shared by the applications of the project and only appended once
+	for i := 0; i < 2; i++ {
+		generator, err := NewSecretGenerator(initGeneratorRequest(testProject, secrets, secretStoreSpec))
+		require.NoError(t, err)
+		require.NoError(t, generator.Generate(spec))
+	}
+
+	resources := map[string]apiv1.Resource{}
+	var ids []string
+	for _, r := range spec.Resources {
+		resources[r.ID] = r
+		ids = append(ids, r.ID)
+	}
+	assert.ElementsMatch(t, []string{
+		"external-secrets.io/v1beta1:ClusterSecretStore:aws-store",
+		"external-secrets.io/v1beta1:ExternalSecret:helloworld:access-token",
+		"v1:Secret:helloworld:secret-token",
+		"external-secrets.io/v1beta1:ExternalSecret:helloworld:access-token",
+		"v1:Secret:helloworld:secret-token",
+	}, ids)
+
+	store := resources["external-secrets.io/v1beta1:ClusterSecretStore:aws-store"]
+	assert.Equal(t, map[string]any{
+		"name":   "aws-store",
+		"labels": map[string]any{"app.kubernetes.io/part-of": testProject},
+	}, store.Attributes["metadata"])
+	assert.Equal(t, map[string]any{
+		"aws": map[string]any{
+			"service": "SecretsManager",
+			"region":  "us-east-1",
+		},
+	}, store.Attributes["spec"].(map[string]any)["provider"])
+	assert.Equal(t, map[string]any{
+		"name": "aws-store",
+		"kind": "ClusterSecretStore",
+	}, resources["external-secrets.io/v1beta1:ExternalSecret:helloworld:access-token"].
+		Attributes["spec"].(map[string]any)["secretStoreRef"])
+
+	// the default name of the ClusterSecretStore is prefixed with the project
+	secretStoreSpec.ExternalSecrets.StoreName = ""
+	generator, err := NewSecretGenerator(initGeneratorRequest(testProject, secrets, secretStoreSpec))
+	require.NoError(t, err)
+	spec = &apiv1.Intent{}
+	require.NoError(t, generator.Generate(spec))
+	assert.True(t, containsResource(spec, "external-secrets.io/v1beta1:ClusterSecretStore:helloworld-kusion-secret-store"))
+}
+
+func TestExternalSecretsProvider(t *testing.T) {
+	vaultURL := "https://kv.vault.azure.net"
+	tests := map[string]struct {
+		spec      *apiv1.ProviderSpec
+		want      map[string]any
+		expectErr error
+	}{
+		"aws": {
+			spec: &apiv1.ProviderSpec{AWS: &apiv1.AWSProvider{Region: "us-east-1"}},
+			want: map[string]any{"aws": map[string]any{"service": "SecretsManager", "region": "us-east-1"}},
+		},
+		"azure": {
+			spec: &apiv1.ProviderSpec{Azure: &apiv1.AzureKVProvider{VaultURL: &vaultURL}},
+			want: map[string]any{"azurekv": map[string]any{"vaultUrl": vaultURL}},
+		},
+		"alicloud": {
+			spec: &apiv1.ProviderSpec{Alicloud: &apiv1.AlicloudProvider{Region: "sh"}},
+			want: map[string]any{"alibaba": map[string]any{"regionID": "sh"}},
+		},
+		"kubernetes": {
+			spec: &apiv1.ProviderSpec{Kubernetes: &apiv1.KubernetesProvider{Namespace: "shared-secrets"}},
+			want: map[string]any{"kubernetes": map[string]any{"remoteNamespace": "shared-secrets"}},
+		},
+		"none": {
+			spec:      &apiv1.ProviderSpec{},
+			expectErr: ErrExternalSecretsProviderNotSupported,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := externalSecretsProvider(test.spec)
+			assert.ErrorIs(t, err, test.expectErr)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
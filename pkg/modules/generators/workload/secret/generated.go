@@ -0,0 +1,81 @@
+package secret
+
+import (
+	"fmt"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+// ReuseGeneratedData replaces the data generated in the planned Secrets with the values in the prior resources,
+// so that the generated tokens, passwords and certificates stay the same across builds. The Secrets whose names
+// are in rotateSecrets keep the newly generated data, and an error is returned if any of them is not planned
+// with generated data.
+func ReuseGeneratedData(intent *apiv1.Intent, priorResources apiv1.Resources, rotateSecrets []string) error {
+	if intent == nil {
+		return nil
+	}
+	rotate := make(map[string]bool, len(rotateSecrets))
+	for _, name := range rotateSecrets {
+		rotate[name] = false
+	}
+
+	priorIndex := priorResources.Index()
+	for i := range intent.Resources {
+		planned := &intent.Resources[i]
+		keys := generatedKeys(planned)
+		if len(keys) == 0 {
+			continue
+		}
+		name := secretName(planned)
+		if _, ok := rotate[name]; ok {
+			rotate[name] = true
+			continue
+		}
+
+		prior, ok := priorIndex[planned.ID]
+		if !ok {
+			continue
+		}
+		priorData, _ := prior.Attributes["data"].(map[string]interface{})
+		plannedData, _ := planned.Attributes["data"].(map[string]interface{})
+		if priorData == nil || plannedData == nil {
+			continue
+		}
+		for _, key := range keys {
+			if v, ok := priorData[key]; ok && v != "" {
+				plannedData[key] = v
+			}
+		}
+	}
+
+	for _, name := range rotateSecrets {
+		if !rotate[name] {
+			return fmt.Errorf("secret %s to rotate is not found or has no generated data", name)
+		}
+	}
+	return nil
+}
+
+// generatedKeys returns the keys of the generated data of the resource, which may be decoded from an Intent file.
+func generatedKeys(resource *apiv1.Resource) []string {
+	switch keys := resource.Extensions[apiv1.ResourceExtensionGeneratedKeys].(type) {
+	case []string:
+		return keys
+	case []interface{}:
+		result := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if s, ok := key.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func secretName(resource *apiv1.Resource) string {
+	metadata, _ := resource.Attributes["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
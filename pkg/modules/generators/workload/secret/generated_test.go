@@ -0,0 +1,149 @@
+package secret
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+)
+
+func newSecretResource(name string, data map[string]interface{}, generatedKeys interface{}) apiv1.Resource {
+	r := apiv1.Resource{
+		ID:   "v1:Secret:helloworld:" + name,
+		Type: apiv1.Kubernetes,
+		Attributes: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "helloworld",
+			},
+			"data": data,
+		},
+		Extensions: map[string]interface{}{},
+	}
+	if generatedKeys != nil {
+		r.Extensions[apiv1.ResourceExtensionGeneratedKeys] = generatedKeys
+	}
+	return r
+}
+
+func TestReuseGeneratedData(t *testing.T) {
+	prior := apiv1.Resources{
+		newSecretResource("secret-token", map[string]interface{}{"token": "b2xk"}, nil),
+		newSecretResource("secret-basic", map[string]interface{}{"username": "YWRtaW4=", "password": "b2xk"}, nil),
+	}
+
+	tests := map[string]struct {
+		planned       apiv1.Resources
+		rotateSecrets []string
+		want          []map[string]interface{}
+		expectErr     string
+	}{
+		"reuse generated data": {
+			planned: apiv1.Resources{
+				newSecretResource("secret-token", map[string]interface{}{"token": "bmV3"}, []string{"token"}),
+				newSecretResource("secret-basic", map[string]interface{}{"username": "cm9vdA==", "password": "bmV3"}, []interface{}{"password"}),
+			},
+			want: []map[string]interface{}{
+				{"token": "b2xk"},
+				{"username": "cm9vdA==", "password": "b2xk"},
+			},
+		},
+		"rotate secret": {
+			planned: apiv1.Resources{
+				newSecretResource("secret-token", map[string]interface{}{"token": "bmV3"}, []string{"token"}),
+			},
+			rotateSecrets: []string{"secret-token"},
+			want: []map[string]interface{}{
+				{"token": "bmV3"},
+			},
+		},
+		"no prior resource": {
+			planned: apiv1.Resources{
+				newSecretResource("secret-new", map[string]interface{}{"token": "bmV3"}, []string{"token"}),
+			},
+			want: []map[string]interface{}{
+				{"token": "bmV3"},
+			},
+		},
+		"rotate secret without generated data": {
+			planned: apiv1.Resources{
+				newSecretResource("secret-token", map[string]interface{}{"token": "bmV3"}, nil),
+			},
+			rotateSecrets: []string{"secret-token"},
+			expectErr:     "secret secret-token to rotate is not found or has no generated data",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			intent := &apiv1.Intent{Resources: test.planned}
+			err := ReuseGeneratedData(intent, prior, test.rotateSecrets)
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			for i, want := range test.want {
+				assert.Equal(t, want, intent.Resources[i].Attributes["data"])
+			}
+		})
+	}
+}
+
+func TestGenerateSecret_GeneratedKeys(t *testing.T) {
+	secrets := map[string]workload.Secret{
+		"secret-token": {
+			Type: "token",
+		},
+		"secret-basic": {
+			Type: "basic",
+			Data: map[string]string{"username": "admin"},
+		},
+		"secret-tls": {
+			Type: "certificate",
+		},
+		"secret-opaque": {
+			Type: "opaque",
+			Data: map[string]string{"accessKey": "dHJ1ZQ=="},
+		},
+	}
+	generator, err := NewSecretGenerator(initGeneratorRequest(testProject, secrets, nil))
+	require.NoError(t, err)
+
+	spec := &apiv1.Intent{}
+	require.NoError(t, generator.Generate(spec))
+
+	got := map[string]interface{}{}
+	for _, r := range spec.Resources {
+		got[secretName(&r)] = r.Extensions[apiv1.ResourceExtensionGeneratedKeys]
+	}
+	assert.Equal(t, map[string]interface{}{
+		"secret-token":  []string{"token"},
+		"secret-basic":  []string{"password"},
+		"secret-tls":    []string{"tls.crt", "tls.key"},
+		"secret-opaque": nil,
+	}, got)
+}
+
+func TestGenerateSelfSignedCertificate(t *testing.T) {
+	cert, key, err := GenerateSelfSignedCertificate("secret-tls")
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(cert)
+	require.NotNil(t, block)
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-tls", certificate.Subject.CommonName)
+	assert.NoError(t, certificate.CheckSignature(certificate.SignatureAlgorithm, certificate.RawTBSCertificate, certificate.Signature))
+
+	block, _ = pem.Decode(key)
+	require.NotNil(t, block)
+	_, err = x509.ParseECPrivateKey(block.Bytes)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,119 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/secrets"
+)
+
+// maxConcurrentLookups is the max number of secrets looked up from the secret store at the same time.
+const maxConcurrentLookups = 8
+
+var (
+	ErrMissingSecretStore    = errors.New("secret store is missing, please add valid secret store spec in workspace")
+	ErrSecretStoreNotMatched = errors.New("no matched secret store found, please check workspace yaml")
+)
+
+// ExternalSecretResolver retrieves the secret data from the external secret store during a build. The secret
+// store is constructed from the provider once when first used, and the retrieved secret data are cached by
+// ExternalSecretRef, so that the same secret is looked up only once.
+type ExternalSecretResolver struct {
+	spec *apiv1.SecretStoreSpec
+
+	once     sync.Once
+	store    secrets.SecretStore
+	storeErr error
+
+	mu    sync.Mutex
+	cache map[apiv1.ExternalSecretRef][]byte
+}
+
+// NewExternalSecretResolver returns an ExternalSecretResolver with the secret store spec of the workspace.
+func NewExternalSecretResolver(spec *apiv1.SecretStoreSpec) *ExternalSecretResolver {
+	return &ExternalSecretResolver{
+		spec:  spec,
+		cache: make(map[apiv1.ExternalSecretRef][]byte),
+	}
+}
+
+// secretStore returns the secret store constructed from the provider of the spec.
+func (r *ExternalSecretResolver) secretStore() (secrets.SecretStore, error) {
+	r.once.Do(func() {
+		if r.spec == nil {
+			r.storeErr = ErrMissingSecretStore
+			return
+		}
+		provider, exist := secrets.GetProvider(r.spec.Provider)
+		if !exist {
+			r.storeErr = ErrSecretStoreNotMatched
+			return
+		}
+		r.store, r.storeErr = provider.NewSecretStore(*r.spec)
+	})
+	return r.store, r.storeErr
+}
+
+// Resolve retrieves the secret data of the refs, which are looked up concurrently if not cached. The errors of
+// all the failed lookups are aggregated.
+func (r *ExternalSecretResolver) Resolve(ctx context.Context, refs []apiv1.ExternalSecretRef) (map[apiv1.ExternalSecretRef][]byte, error) {
+	store, err := r.secretStore()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[apiv1.ExternalSecretRef][]byte, len(refs))
+	var missing []apiv1.ExternalSecretRef
+	r.mu.Lock()
+	for _, ref := range refs {
+		if data, ok := r.cache[ref]; ok {
+			result[ref] = data
+		} else if _, ok = result[ref]; !ok {
+			// mark the ref to avoid looking up twice
+			result[ref] = nil
+			missing = append(missing, ref)
+		}
+	}
+	r.mu.Unlock()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allErrs []error
+		sem     = make(chan struct{}, maxConcurrentLookups)
+	)
+	for _, ref := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ref apiv1.ExternalSecretRef) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			data, err := store.GetSecret(ctx, ref)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				allErrs = append(allErrs, err)
+				return
+			}
+			result[ref] = data
+		}(ref)
+	}
+	wg.Wait()
+	if len(allErrs) != 0 {
+		return nil, utilerrors.NewAggregate(allErrs)
+	}
+
+	r.mu.Lock()
+	for _, ref := range missing {
+		r.cache[ref] = result[ref]
+	}
+	r.mu.Unlock()
+	return result, nil
+}
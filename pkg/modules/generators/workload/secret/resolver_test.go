@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/secrets"
+)
+
+type countingSecretStore struct {
+	data  map[string]string
+	count int32
+}
+
+func (s *countingSecretStore) GetSecret(_ context.Context, ref apiv1.ExternalSecretRef) ([]byte, error) {
+	atomic.AddInt32(&s.count, 1)
+	data, ok := s.data[ref.Name]
+	if !ok {
+		return nil, secrets.NoSecretErr
+	}
+	return []byte(data), nil
+}
+
+func newTestResolver(store secrets.SecretStore) *ExternalSecretResolver {
+	r := NewExternalSecretResolver(&apiv1.SecretStoreSpec{})
+	r.once.Do(func() {
+		r.store = store
+	})
+	return r
+}
+
+func TestExternalSecretResolver_Resolve(t *testing.T) {
+	store := &countingSecretStore{data: map[string]string{"a": "1", "b": "2", "c": "3"}}
+	r := newTestResolver(store)
+
+	refs := []apiv1.ExternalSecretRef{{Name: "a"}, {Name: "b"}, {Name: "a"}}
+	data, err := r.Resolve(context.Background(), refs)
+	require.NoError(t, err)
+	require.Equal(t, map[apiv1.ExternalSecretRef][]byte{
+		{Name: "a"}: []byte("1"),
+		{Name: "b"}: []byte("2"),
+	}, data)
+	require.Equal(t, int32(2), store.count)
+
+	// the cached secrets are not looked up again
+	data, err = r.Resolve(context.Background(), []apiv1.ExternalSecretRef{{Name: "b"}, {Name: "c"}})
+	require.NoError(t, err)
+	require.Equal(t, []byte("3"), data[apiv1.ExternalSecretRef{Name: "c"}])
+	require.Equal(t, int32(3), store.count)
+
+	_, err = r.Resolve(context.Background(), []apiv1.ExternalSecretRef{{Name: "d"}})
+	require.EqualError(t, err, "Secret does not exist")
+}
+
+func TestExternalSecretResolver_SecretStore(t *testing.T) {
+	_, err := NewExternalSecretResolver(nil).Resolve(context.Background(), nil)
+	require.ErrorIs(t, err, ErrMissingSecretStore)
+
+	_, err = NewExternalSecretResolver(&apiv1.SecretStoreSpec{Provider: &apiv1.ProviderSpec{}}).
+		Resolve(context.Background(), nil)
+	require.ErrorIs(t, err, ErrSecretStoreNotMatched)
+
+	r := NewExternalSecretResolver(initSecretStoreSpec(nil))
+	store, err := r.secretStore()
+	require.NoError(t, err)
+	again, err := r.secretStore()
+	require.NoError(t, err)
+	require.Same(t, store, again)
+}
@@ -2,9 +2,9 @@ package secret
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
 	"golang.org/x/exp/maps"
@@ -15,7 +15,6 @@ import (
 	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
 	"kusionstack.io/kusion/pkg/modules"
-	"kusionstack.io/kusion/pkg/secrets"
 )
 
 type secretGenerator struct {
@@ -23,6 +22,9 @@ type secretGenerator struct {
 	namespace       string
 	secrets         map[string]workload.Secret
 	secretStoreSpec *apiv1.SecretStoreSpec
+	resolver        *ExternalSecretResolver
+	// generatedKeys records the keys of the generated data of each secret
+	generatedKeys map[string][]string
 }
 
 type GeneratorRequest struct {
@@ -34,6 +36,9 @@ type GeneratorRequest struct {
 	Workload *workload.Workload
 	// SecretStoreSpec contains configuration to describe target secret store.
 	SecretStoreSpec *apiv1.SecretStoreSpec
+	// SecretResolver retrieves the external secrets, which is shared during a build. If not set, a resolver
+	// with SecretStoreSpec is used.
+	SecretResolver *ExternalSecretResolver
 }
 
 func NewSecretGenerator(request *GeneratorRequest) (modules.Generator, error) {
@@ -48,11 +53,17 @@ func NewSecretGenerator(request *GeneratorRequest) (modules.Generator, error) {
 		secretMap = request.Workload.Job.Secrets
 	}
 
+	resolver := request.SecretResolver
+	if resolver == nil {
+		resolver = NewExternalSecretResolver(request.SecretStoreSpec)
+	}
+
 	return &secretGenerator{
 		project:         request.Project,
 		secrets:         secretMap,
 		namespace:       request.Namespace,
 		secretStoreSpec: request.SecretStoreSpec,
+		resolver:        resolver,
 	}, nil
 }
 
@@ -66,8 +77,17 @@ func (g *secretGenerator) Generate(spec *apiv1.Intent) error {
 	if spec.Resources == nil {
 		spec.Resources = make(apiv1.Resources, 0)
 	}
+	g.generatedKeys = map[string][]string{}
 
 	for secretName, secretRef := range g.secrets {
+		// the external secrets are synced by the external-secrets operator rather than resolved at build time
+		if secretRef.Type == "external" && g.externalSecretsEnabled() {
+			if err := g.appendExternalSecret(spec, secretName, secretRef); err != nil {
+				return err
+			}
+			continue
+		}
+
 		secret, err := g.generateSecret(secretName, secretRef)
 		if err != nil {
 			return err
@@ -83,6 +103,10 @@ func (g *secretGenerator) Generate(spec *apiv1.Intent) error {
 		if err != nil {
 			return err
 		}
+		if keys := g.generatedKeys[secretName]; len(keys) > 0 {
+			sort.Strings(keys)
+			spec.Resources[len(spec.Resources)-1].Extensions[apiv1.ResourceExtensionGeneratedKeys] = keys
+		}
 	}
 
 	return nil
@@ -118,6 +142,7 @@ func (g *secretGenerator) generateBasic(secretName string, secretRef workload.Se
 		if len(secret.Data[key]) == 0 {
 			v := GenerateRandomString(54)
 			secret.Data[key] = []byte(v)
+			g.markGenerated(secretName, key)
 		}
 	}
 
@@ -133,6 +158,7 @@ func (g *secretGenerator) generateToken(secretName string, secretRef workload.Se
 	if len(secret.Data["token"]) == 0 {
 		v := GenerateRandomString(54)
 		secret.Data["token"] = []byte(v)
+		g.markGenerated(secretName, "token")
 	}
 
 	return secret, nil
@@ -147,52 +173,59 @@ func (g *secretGenerator) generateOpaque(secretName string, secretRef workload.S
 
 // generateCertificate generates secret used for storing a certificate and its associated key.
 // One common use for TLS Secrets is to configure encryption in transit for an Ingress, but
-// you can also use it with other resources or directly in your workload.
+// you can also use it with other resources or directly in your workload. If neither the
+// certificate nor the key is provided, a self-signed certificate is generated.
 func (g *secretGenerator) generateCertificate(secretName string, secretRef workload.Secret) (*v1.Secret, error) {
 	secret := initBasicSecret(g.namespace, secretName, v1.SecretTypeTLS, secretRef.Immutable)
 	secret.Data = grabData(secretRef.Data, v1.TLSCertKey, v1.TLSPrivateKeyKey)
+
+	if len(secret.Data[v1.TLSCertKey]) == 0 && len(secret.Data[v1.TLSPrivateKeyKey]) == 0 {
+		cert, key, err := GenerateSelfSignedCertificate(secretName)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[v1.TLSCertKey] = cert
+		secret.Data[v1.TLSPrivateKeyKey] = key
+		g.markGenerated(secretName, v1.TLSCertKey, v1.TLSPrivateKeyKey)
+	}
+
 	return secret, nil
 }
 
+// markGenerated records the keys of the data generated for the secret, whose values are reused from the prior
+// state until rotated.
+func (g *secretGenerator) markGenerated(secretName string, keys ...string) {
+	g.generatedKeys[secretName] = append(g.generatedKeys[secretName], keys...)
+}
+
 // generateSecretWithExternalProvider retrieves target sensitive information from external secret provider and
 // generates corresponding Kubernetes Secret object.
 func (g *secretGenerator) generateSecretWithExternalProvider(secretName string, secretRef workload.Secret) (*v1.Secret, error) {
-	if g.secretStoreSpec == nil {
-		return nil, errors.New("secret store is missing, please add valid secret store spec in workspace")
-	}
-
 	secret := initBasicSecret(g.namespace, secretName, v1.SecretTypeOpaque, secretRef.Immutable)
 	secret.Data = make(map[string][]byte)
 
 	var allErrs []error
+	externalSecretRefs := make(map[string]apiv1.ExternalSecretRef, len(secretRef.Data))
 	for key, ref := range secretRef.Data {
 		externalSecretRef, err := parseExternalSecretDataRef(ref)
 		if err != nil {
 			allErrs = append(allErrs, err)
 			continue
 		}
-		provider, exist := secrets.GetProvider(g.secretStoreSpec.Provider)
-		if !exist {
-			allErrs = append(allErrs, errors.New("no matched secret store found, please check workspace yaml"))
-			continue
-		}
-		secretStore, err := provider.NewSecretStore(*g.secretStoreSpec)
-		if err != nil {
-			allErrs = append(allErrs, err)
-			continue
-		}
-		secretData, err := secretStore.GetSecret(context.Background(), *externalSecretRef)
-		if err != nil {
-			allErrs = append(allErrs, err)
-			continue
-		}
-		secret.Data[key] = secretData
+		externalSecretRefs[key] = *externalSecretRef
 	}
-
 	if allErrs != nil {
 		return nil, utilerrors.NewAggregate(allErrs)
 	}
 
+	secretData, err := g.resolver.Resolve(context.Background(), maps.Values(externalSecretRefs))
+	if err != nil {
+		return nil, err
+	}
+	for key, ref := range externalSecretRefs {
+		secret.Data[key] = secretData[ref]
+	}
+
 	return secret, nil
 }
 
@@ -0,0 +1,225 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
+)
+
+// PodSecurityLevel is the level of the Pod Security Standards.
+type PodSecurityLevel string
+
+const (
+	PodSecurityPrivileged PodSecurityLevel = "privileged"
+	PodSecurityBaseline   PodSecurityLevel = "baseline"
+	PodSecurityRestricted PodSecurityLevel = "restricted"
+)
+
+var (
+	ErrInvalidPodSecurityLevel = errors.New("podSecurityLevel must be one of privileged, baseline and restricted")
+	ErrViolatePodSecurityLevel = errors.New("violate the pod security level")
+)
+
+// baselineCapabilities are the capabilities allowed to be added by the baseline level.
+var baselineCapabilities = map[corev1.Capability]bool{
+	"AUDIT_WRITE":      true,
+	"CHOWN":            true,
+	"DAC_OVERRIDE":     true,
+	"FOWNER":           true,
+	"FSETID":           true,
+	"KILL":             true,
+	"MKNOD":            true,
+	"NET_BIND_SERVICE": true,
+	"SETFCAP":          true,
+	"SETGID":           true,
+	"SETPCAP":          true,
+	"SETUID":           true,
+	"SYS_CHROOT":       true,
+}
+
+// convertKusionSecurityContextToV1 converts Kusion SecurityContext to Kubernetes SecurityContext.
+func convertKusionSecurityContextToV1(sc *container.SecurityContext) *corev1.SecurityContext {
+	result := &corev1.SecurityContext{
+		RunAsUser:                sc.RunAsUser,
+		RunAsGroup:               sc.RunAsGroup,
+		RunAsNonRoot:             sc.RunAsNonRoot,
+		Privileged:               sc.Privileged,
+		AllowPrivilegeEscalation: sc.AllowPrivilegeEscalation,
+		ReadOnlyRootFilesystem:   sc.ReadOnlyRootFilesystem,
+	}
+	if sc.Capabilities != nil {
+		result.Capabilities = &corev1.Capabilities{}
+		for _, c := range sc.Capabilities.Add {
+			result.Capabilities.Add = append(result.Capabilities.Add, corev1.Capability(c))
+		}
+		for _, c := range sc.Capabilities.Drop {
+			result.Capabilities.Drop = append(result.Capabilities.Drop, corev1.Capability(c))
+		}
+	}
+	if sc.SeccompProfile != nil {
+		result.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileType(sc.SeccompProfile.Type),
+		}
+		if sc.SeccompProfile.LocalhostProfile != "" {
+			result.SeccompProfile.LocalhostProfile = &sc.SeccompProfile.LocalhostProfile
+		}
+	}
+	return result
+}
+
+// completeSecurityContext sets the pod security context of the workspace module config into the PodSpec, and
+// completes the security context of the containers and init containers with the defaults of the workspace module
+// config, where the fields set by the containers take precedence. Then the PodSpec is validated against the pod
+// security level.
+func completeSecurityContext(podSpec *corev1.PodSpec, config apiv1.GenericConfig) error {
+	securityConfig := struct {
+		SecurityContext          *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+		ContainerSecurityContext *corev1.SecurityContext    `json:"containerSecurityContext,omitempty"`
+		PodSecurityLevel         PodSecurityLevel           `json:"podSecurityLevel,omitempty"`
+	}{}
+	if err := decodeConfigFields(config, &securityConfig,
+		workload.FieldSecurityContext,
+		workload.FieldContainerSecurityContext,
+		workload.FieldPodSecurityLevel,
+	); err != nil {
+		return fmt.Errorf("invalid security context config, %w", err)
+	}
+
+	podSpec.SecurityContext = securityConfig.SecurityContext
+	if defaults := securityConfig.ContainerSecurityContext; defaults != nil {
+		for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+			for i := range containers {
+				ctn := &containers[i]
+				if ctn.SecurityContext == nil {
+					ctn.SecurityContext = defaults.DeepCopy()
+					continue
+				}
+				mergeSecurityContext(ctn.SecurityContext, defaults.DeepCopy())
+			}
+		}
+	}
+
+	return validatePodSecurity(podSpec, securityConfig.PodSecurityLevel)
+}
+
+// mergeSecurityContext sets the unset fields of the security context to the defaults. Unlike mergo, the explicitly
+// set zero values, e.g. runAsUser 0 or allowPrivilegeEscalation false, are kept, as all the fields are pointers.
+func mergeSecurityContext(sc, defaults *corev1.SecurityContext) {
+	dst, src := reflect.ValueOf(sc).Elem(), reflect.ValueOf(defaults).Elem()
+	for i := 0; i < dst.NumField(); i++ {
+		if field := dst.Field(i); field.Kind() == reflect.Ptr && field.IsNil() {
+			field.Set(src.Field(i))
+		}
+	}
+}
+
+// validatePodSecurity checks the PodSpec against the controls of the Pod Security Standards about the security
+// context, and returns the error listing all the violations. An empty level means no restriction.
+func validatePodSecurity(podSpec *corev1.PodSpec, level PodSecurityLevel) error {
+	switch level {
+	case "", PodSecurityPrivileged:
+		return nil
+	case PodSecurityBaseline, PodSecurityRestricted:
+	default:
+		return ErrInvalidPodSecurityLevel
+	}
+
+	podSC := podSpec.SecurityContext
+	if podSC == nil {
+		podSC = &corev1.PodSecurityContext{}
+	}
+	var violations []string
+	if podSC.SeccompProfile != nil && podSC.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		violations = append(violations, "pod must not set seccompProfile type to Unconfined")
+	}
+	if level == PodSecurityRestricted && podSC.RunAsUser != nil && *podSC.RunAsUser == 0 {
+		violations = append(violations, "pod must not set runAsUser to 0")
+	}
+
+	for _, ctn := range append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...) {
+		sc := ctn.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		violate := func(format string, args ...any) {
+			violations = append(violations, fmt.Sprintf("container %s ", ctn.Name)+fmt.Sprintf(format, args...))
+		}
+
+		// controls of the baseline level
+		if sc.Privileged != nil && *sc.Privileged {
+			violate("must not be privileged")
+		}
+		if sc.SeccompProfile != nil && sc.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+			violate("must not set seccompProfile type to Unconfined")
+		}
+		var added []corev1.Capability
+		if sc.Capabilities != nil {
+			added = sc.Capabilities.Add
+		}
+		if level == PodSecurityBaseline {
+			for _, c := range added {
+				if !baselineCapabilities[c] {
+					violate("must not add capability %s", c)
+				}
+			}
+			continue
+		}
+
+		// controls of the restricted level
+		for _, c := range added {
+			if c != "NET_BIND_SERVICE" {
+				violate("must not add capability %s", c)
+			}
+		}
+		if sc.Capabilities == nil || !hasCapability(sc.Capabilities.Drop, "ALL") {
+			violate("must drop capability ALL")
+		}
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			violate("must set allowPrivilegeEscalation to false")
+		}
+		if runAsNonRoot := firstNonNil(sc.RunAsNonRoot, podSC.RunAsNonRoot); runAsNonRoot == nil || !*runAsNonRoot {
+			violate("must set runAsNonRoot to true")
+		}
+		if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+			violate("must not set runAsUser to 0")
+		}
+		seccompProfile := podSC.SeccompProfile
+		if sc.SeccompProfile != nil {
+			seccompProfile = sc.SeccompProfile
+		}
+		if seccompProfile == nil || (seccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault &&
+			seccompProfile.Type != corev1.SeccompProfileTypeLocalhost) {
+			violate("must set seccompProfile type to RuntimeDefault or Localhost")
+		}
+	}
+
+	if len(violations) != 0 {
+		return fmt.Errorf("%w %s: %s", ErrViolatePodSecurityLevel, level, strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+func hasCapability(capabilities []corev1.Capability, capability corev1.Capability) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonNil(values ...*bool) *bool {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload"
+	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
+)
+
+func restrictedSecurityContext() *corev1.SecurityContext {
+	f, t := false, true
+	return &corev1.SecurityContext{
+		RunAsNonRoot:             &t,
+		AllowPrivilegeEscalation: &f,
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+func TestConvertKusionSecurityContextToV1(t *testing.T) {
+	uid, t1 := int64(1000), true
+	actual := convertKusionSecurityContextToV1(&container.SecurityContext{
+		RunAsUser:              &uid,
+		ReadOnlyRootFilesystem: &t1,
+		Capabilities:           &container.Capabilities{Add: []string{"NET_BIND_SERVICE"}, Drop: []string{"ALL"}},
+		SeccompProfile:         &container.SeccompProfile{Type: "Localhost", LocalhostProfile: "profiles/audit.json"},
+	})
+	profile := "profiles/audit.json"
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsUser:              &uid,
+		ReadOnlyRootFilesystem: &t1,
+		Capabilities: &corev1.Capabilities{
+			Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: &profile},
+	}, actual)
+}
+
+func TestCompleteSecurityContext(t *testing.T) {
+	root, uid, fsGroup, f, t1 := int64(0), int64(1000), int64(2000), false, true
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "default"},
+			{Name: "override", SecurityContext: &corev1.SecurityContext{RunAsUser: &root, ReadOnlyRootFilesystem: &f}},
+		},
+	}
+	config := apiv1.GenericConfig{
+		"securityContext": apiv1.GenericConfig{"fsGroup": 2000},
+		"containerSecurityContext": apiv1.GenericConfig{
+			"runAsUser":              1000,
+			"readOnlyRootFilesystem": true,
+			"capabilities":           apiv1.GenericConfig{"drop": []any{"ALL"}},
+		},
+	}
+	require.NoError(t, completeSecurityContext(&podSpec, config))
+	assert.Equal(t, &corev1.PodSecurityContext{FSGroup: &fsGroup}, podSpec.SecurityContext)
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsUser:              &uid,
+		ReadOnlyRootFilesystem: &t1,
+		Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}, podSpec.Containers[0].SecurityContext)
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsUser:              &root,
+		ReadOnlyRootFilesystem: &f,
+		Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}, podSpec.Containers[1].SecurityContext, "the fields set by the container should take precedence")
+
+	err := completeSecurityContext(&corev1.PodSpec{}, apiv1.GenericConfig{"securityContext": "invalid"})
+	assert.Error(t, err)
+}
+
+func TestValidatePodSecurity(t *testing.T) {
+	root, t1 := int64(0), true
+	testcases := []struct {
+		name       string
+		level      PodSecurityLevel
+		podSC      *corev1.PodSecurityContext
+		sc         func(sc *corev1.SecurityContext)
+		violations []string
+		wantErr    error
+	}{
+		{
+			name:  "no restriction",
+			level: "",
+			sc:    func(sc *corev1.SecurityContext) { sc.Privileged = &t1 },
+		},
+		{
+			name:    "invalid level",
+			level:   "strict",
+			wantErr: ErrInvalidPodSecurityLevel,
+		},
+		{
+			name:  "baseline",
+			level: PodSecurityBaseline,
+			sc: func(sc *corev1.SecurityContext) {
+				sc.Capabilities.Add = []corev1.Capability{"CHOWN"}
+				sc.RunAsUser = &root
+			},
+		},
+		{
+			name:  "violate baseline",
+			level: PodSecurityBaseline,
+			sc: func(sc *corev1.SecurityContext) {
+				sc.Privileged = &t1
+				sc.Capabilities.Add = []corev1.Capability{"SYS_ADMIN"}
+				sc.SeccompProfile.Type = corev1.SeccompProfileTypeUnconfined
+			},
+			violations: []string{
+				"container main must not be privileged",
+				"container main must not set seccompProfile type to Unconfined",
+				"container main must not add capability SYS_ADMIN",
+			},
+			wantErr: ErrViolatePodSecurityLevel,
+		},
+		{
+			name:  "restricted",
+			level: PodSecurityRestricted,
+			sc: func(sc *corev1.SecurityContext) {
+				sc.Capabilities.Add = []corev1.Capability{"NET_BIND_SERVICE"}
+			},
+		},
+		{
+			name:  "restricted by pod security context",
+			level: PodSecurityRestricted,
+			podSC: &corev1.PodSecurityContext{
+				RunAsNonRoot:   &t1,
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			sc: func(sc *corev1.SecurityContext) {
+				sc.RunAsNonRoot = nil
+				sc.SeccompProfile = nil
+			},
+		},
+		{
+			name:  "violate restricted",
+			level: PodSecurityRestricted,
+			podSC: &corev1.PodSecurityContext{RunAsUser: &root},
+			sc: func(sc *corev1.SecurityContext) {
+				sc.Capabilities = nil
+				sc.AllowPrivilegeEscalation = nil
+				sc.RunAsNonRoot = nil
+				sc.SeccompProfile = nil
+			},
+			violations: []string{
+				"pod must not set runAsUser to 0",
+				"container main must drop capability ALL",
+				"container main must set allowPrivilegeEscalation to false",
+				"container main must set runAsNonRoot to true",
+				"container main must set seccompProfile type to RuntimeDefault or Localhost",
+			},
+			wantErr: ErrViolatePodSecurityLevel,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := restrictedSecurityContext()
+			if tc.sc != nil {
+				tc.sc(sc)
+			}
+			podSpec := &corev1.PodSpec{
+				SecurityContext: tc.podSC,
+				Containers:      []corev1.Container{{Name: "main", SecurityContext: sc}},
+			}
+			err := validatePodSecurity(podSpec, tc.level)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.wantErr)
+			for _, violation := range tc.violations {
+				assert.Contains(t, err.Error(), violation)
+			}
+		})
+	}
+}
+
+func TestServiceGenerator_GenerateWithSecurityContext(t *testing.T) {
+	f := false
+	g := &ServiceGenerator{
+		Project: "default",
+		Stack:   "dev",
+		App:     "foo",
+		Service: &workload.Service{
+			Base: workload.Base{
+				Containers: map[string]container.Container{
+					"nginx": {
+						Image: "nginx:v1",
+						SecurityContext: &container.SecurityContext{
+							ReadOnlyRootFilesystem: &f,
+						},
+					},
+				},
+			},
+		},
+		Config: apiv1.GenericConfig{
+			"securityContext": apiv1.GenericConfig{
+				"runAsNonRoot":   true,
+				"seccompProfile": apiv1.GenericConfig{"type": "RuntimeDefault"},
+			},
+			"containerSecurityContext": apiv1.GenericConfig{
+				"allowPrivilegeEscalation": false,
+				"readOnlyRootFilesystem":   true,
+				"capabilities":             apiv1.GenericConfig{"drop": []any{"ALL"}},
+			},
+			"podSecurityLevel": "restricted",
+		},
+		Namespace: "default",
+	}
+	spec := &apiv1.Intent{}
+	require.NoError(t, g.Generate(spec))
+	containers, _, _ := unstructured.NestedSlice(spec.Resources[0].Attributes, "spec", "template", "spec", "containers")
+	require.Len(t, containers, 1)
+	assert.Equal(t, map[string]any{
+		"allowPrivilegeEscalation": false,
+		"readOnlyRootFilesystem":   false,
+		"capabilities":             map[string]any{"drop": []any{"ALL"}},
+	}, containers[0].(map[string]any)["securityContext"])
+
+	// the container adding a capability violates the restricted level
+	g.Service.Containers["nginx"] = container.Container{
+		Image: "nginx:v1",
+		SecurityContext: &container.SecurityContext{
+			Capabilities: &container.Capabilities{Add: []string{"NET_ADMIN"}},
+		},
+	}
+	assert.ErrorIs(t, g.Generate(&apiv1.Intent{}), ErrViolatePodSecurityLevel)
+}
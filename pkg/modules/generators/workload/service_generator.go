@@ -3,10 +3,13 @@ package workload
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"kusionstack.io/kube-api/apps/v1alpha1"
 
 	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
@@ -17,13 +20,20 @@ import (
 )
 
 var (
-	ErrEmptySelectors        = errors.New("selectors must not be empty")
-	ErrInvalidPort           = errors.New("port must be between 1 and 65535")
-	ErrInvalidTargetPort     = errors.New("targetPort must be between 1 and 65535 if exist")
-	ErrInvalidProtocol       = errors.New("protocol must be TCP or UDP")
-	ErrDuplicatePortProtocol = errors.New("port-protocol pair must not be duplicate")
+	ErrEmptySelectors               = errors.New("selectors must not be empty")
+	ErrInvalidPort                  = errors.New("port must be between 1 and 65535")
+	ErrInvalidTargetPort            = errors.New("targetPort must be between 1 and 65535 if exist")
+	ErrInvalidProtocol              = errors.New("protocol must be TCP or UDP")
+	ErrDuplicatePortProtocol        = errors.New("port-protocol pair must not be duplicate")
+	ErrStatefulSetOnlyFields        = errors.New("podManagementPolicy and volumeClaimTemplates are only valid for StatefulSet")
+	ErrInvalidPodManagementPolicy   = errors.New("podManagementPolicy must be OrderedReady or Parallel")
+	ErrInvalidVolumeClaimTemplate   = errors.New("volumeClaimTemplate must have name, mountPath and valid size")
+	ErrDuplicateVolumeClaimTemplate = errors.New("volumeClaimTemplate name must not be duplicate")
 )
 
+// headlessServiceSuffix is the suffix of the headless Service name generated for StatefulSet.
+const headlessServiceSuffix = "-headless"
+
 // ServiceGenerator is a struct for generating Service Workload resources.
 type ServiceGenerator struct {
 	Project   string
@@ -80,18 +90,36 @@ func (g *ServiceGenerator) Generate(spec *apiv1.Intent) error {
 	if err := completeServiceInput(g.Service, g.Config); err != nil {
 		return fmt.Errorf("complete Service input by workspace config failed, %w", err)
 	}
+	if err := validateServiceInput(g.Service); err != nil {
+		return fmt.Errorf("invalid Service input, %w", err)
+	}
+	autoscaling, err := getAutoscaling(g.Config)
+	if err != nil {
+		return fmt.Errorf("invalid autoscaling config, %w", err)
+	}
+	if autoscaling != nil {
+		if service.Type == workload.DaemonSet {
+			return ErrAutoscalingNotSupportedType
+		}
+		// leave the replicas to the HorizontalPodAutoscaler, to avoid the applies fighting with it
+		service.Replicas = nil
+	}
 
 	uniqueAppName := modules.UniqueAppName(g.Project, g.Stack, g.App)
 
 	// Create a slice of containers based on the App's
 	// containers along with related volumes and configMaps.
-	containers, volumes, configMaps, err := toOrderedContainers(service.Containers, uniqueAppName)
+	nativeSidecar, err := isNativeSidecar(g.Config)
+	if err != nil {
+		return err
+	}
+	podContainers, err := toPodContainers(&service.Base, uniqueAppName, nativeSidecar)
 	if err != nil {
 		return err
 	}
 
 	// Create ConfigMap objects based on the App's configuration.
-	for _, cm := range configMaps {
+	for _, cm := range podContainers.configMaps {
 		cmObj := cm
 		cmObj.Namespace = g.Namespace
 		if err = modules.AppendToIntent(
@@ -122,10 +150,17 @@ func (g *ServiceGenerator) Generate(spec *apiv1.Intent) error {
 			Annotations: annotations,
 		},
 		Spec: v1.PodSpec{
-			Containers: containers,
-			Volumes:    volumes,
+			InitContainers: podContainers.initContainers,
+			Containers:     podContainers.containers,
+			Volumes:        podContainers.volumes,
 		},
 	}
+	if err = completePodScheduling(&podTemplateSpec.Spec, g.Config, selectors); err != nil {
+		return err
+	}
+	if err = completeSecurityContext(&podTemplateSpec.Spec, g.Config); err != nil {
+		return err
+	}
 
 	var resource any
 	typeMeta := metav1.TypeMeta{}
@@ -160,12 +195,85 @@ func (g *ServiceGenerator) Generate(spec *apiv1.Intent) error {
 				Template: podTemplateSpec,
 			},
 		}
+	case workload.StatefulSet:
+		typeMeta = metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       string(workload.StatefulSet),
+		}
+		claims, volumeMounts := toVolumeClaimTemplates(service.VolumeClaimTemplates)
+		// mount to the init containers as well, including the native sidecars
+		for _, ctns := range [][]v1.Container{podTemplateSpec.Spec.InitContainers, podTemplateSpec.Spec.Containers} {
+			for i := range ctns {
+				ctns[i].VolumeMounts = append(ctns[i].VolumeMounts, volumeMounts...)
+			}
+		}
+		resource = &appsv1.StatefulSet{
+			TypeMeta:   typeMeta,
+			ObjectMeta: objectMeta,
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:             service.Replicas,
+				Selector:             &metav1.LabelSelector{MatchLabels: selectors},
+				Template:             podTemplateSpec,
+				VolumeClaimTemplates: claims,
+				ServiceName:          uniqueAppName + headlessServiceSuffix,
+				PodManagementPolicy:  appsv1.PodManagementPolicyType(service.PodManagementPolicy),
+			},
+		}
+	case workload.DaemonSet:
+		typeMeta = metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       string(workload.DaemonSet),
+		}
+		resource = &appsv1.DaemonSet{
+			TypeMeta:   typeMeta,
+			ObjectMeta: objectMeta,
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: selectors},
+				Template: podTemplateSpec,
+			},
+		}
 	}
 
 	// Add the Deployment resource to the spec.
 	if err = modules.AppendToIntent(apiv1.Kubernetes, modules.KubernetesResourceID(typeMeta, objectMeta), spec, resource); err != nil {
 		return err
 	}
+	if err = setNativeSidecars(
+		spec.Resources[len(spec.Resources)-1].Attributes,
+		[]string{"spec", "template", "spec"},
+		podContainers.nativeSidecars,
+	); err != nil {
+		return err
+	}
+
+	// Add the HorizontalPodAutoscaler scaling the workload to the spec.
+	if autoscaling != nil {
+		hpa := toHorizontalPodAutoscaler(autoscaling, typeMeta, objectMeta)
+		if err = modules.AppendToIntent(
+			apiv1.Kubernetes,
+			modules.KubernetesResourceID(hpa.TypeMeta, hpa.ObjectMeta),
+			spec,
+			hpa,
+		); err != nil {
+			return err
+		}
+	}
+
+	// Add the PodDisruptionBudget protecting the pods of the workload to the spec.
+	pdb, err := toPodDisruptionBudget(g.Config, objectMeta, selectors)
+	if err != nil {
+		return err
+	}
+	if pdb != nil {
+		if err = modules.AppendToIntent(
+			apiv1.Kubernetes,
+			modules.KubernetesResourceID(pdb.TypeMeta, pdb.ObjectMeta),
+			spec,
+			pdb,
+		); err != nil {
+			return err
+		}
+	}
 
 	// validate and complete service ports
 	if len(g.Service.Ports) != 0 {
@@ -176,9 +284,86 @@ func (g *ServiceGenerator) Generate(spec *apiv1.Intent) error {
 			return err
 		}
 	}
+
+	// StatefulSet requires a headless Service to control the network identity of the pods.
+	if service.Type == workload.StatefulSet {
+		headlessService := toHeadlessService(uniqueAppName+headlessServiceSuffix, g.Namespace, labels, selectors, service.Ports)
+		if err = modules.AppendToIntent(
+			apiv1.Kubernetes,
+			modules.KubernetesResourceID(headlessService.TypeMeta, headlessService.ObjectMeta),
+			spec,
+			headlessService,
+		); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// toVolumeClaimTemplates converts the volumeClaimTemplates of the Service to the PersistentVolumeClaims and the
+// VolumeMounts of the containers, should be called after validateServiceInput.
+func toVolumeClaimTemplates(templates []workload.VolumeClaimTemplate) ([]v1.PersistentVolumeClaim, []v1.VolumeMount) {
+	var claims []v1.PersistentVolumeClaim
+	var volumeMounts []v1.VolumeMount
+	for _, t := range templates {
+		accessModes := []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}
+		if len(t.AccessModes) != 0 {
+			accessModes = make([]v1.PersistentVolumeAccessMode, 0, len(t.AccessModes))
+			for _, mode := range t.AccessModes {
+				accessModes = append(accessModes, v1.PersistentVolumeAccessMode(mode))
+			}
+		}
+		claim := v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: t.Name},
+			Spec: v1.PersistentVolumeClaimSpec{
+				AccessModes: accessModes,
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceStorage: resource.MustParse(t.Size),
+					},
+				},
+			},
+		}
+		if t.StorageClassName != "" {
+			storageClassName := t.StorageClassName
+			claim.Spec.StorageClassName = &storageClassName
+		}
+		claims = append(claims, claim)
+		volumeMounts = append(volumeMounts, v1.VolumeMount{Name: t.Name, MountPath: t.MountPath})
+	}
+	return claims, volumeMounts
+}
+
+// toHeadlessService returns the headless Service of the StatefulSet, which exposes the ports of the Service. The
+// ports are named by the protocol and port, such as tcp-8080, which are valid DNS-1123 labels.
+func toHeadlessService(name, namespace string, labels, selectors map[string]string, ports []network.Port) *v1.Service {
+	var servicePorts []v1.ServicePort
+	for _, port := range ports {
+		servicePorts = append(servicePorts, v1.ServicePort{
+			Name:       fmt.Sprintf("%s-%d", strings.ToLower(string(port.Protocol)), port.Port),
+			Port:       int32(port.Port),
+			TargetPort: intstr.FromInt(port.TargetPort),
+			Protocol:   v1.Protocol(port.Protocol),
+		})
+	}
+	return &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIP: v1.ClusterIPNone,
+			Selector:  selectors,
+			Ports:     servicePorts,
+		},
+	}
+}
+
 func validatePorts(ports []network.Port) error {
 	portProtocolRecord := make(map[string]struct{})
 	for _, port := range ports {
@@ -241,7 +426,7 @@ func completeServiceInput(service *workload.Service, config apiv1.GenericConfig)
 	if platformServiceType == "" {
 		platformServiceType = workload.Deployment
 	}
-	if platformServiceType != workload.Deployment && platformServiceType != workload.Collaset {
+	if !isSupportedServiceType(platformServiceType) {
 		return fmt.Errorf("unsupported Service type %s", platformServiceType)
 	}
 	if service.Type == "" {
@@ -249,3 +434,46 @@ func completeServiceInput(service *workload.Service, config apiv1.GenericConfig)
 	}
 	return nil
 }
+
+func isSupportedServiceType(serviceType workload.ServiceType) bool {
+	switch serviceType {
+	case workload.Deployment, workload.Collaset, workload.StatefulSet, workload.DaemonSet:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateServiceInput validates the type of the Service and the fields only valid for StatefulSet, should be
+// called after completeServiceInput.
+func validateServiceInput(service *workload.Service) error {
+	if !isSupportedServiceType(service.Type) {
+		return fmt.Errorf("unsupported Service type %s", service.Type)
+	}
+	if service.Type != workload.StatefulSet {
+		if service.PodManagementPolicy != "" || len(service.VolumeClaimTemplates) != 0 {
+			return ErrStatefulSetOnlyFields
+		}
+		return nil
+	}
+
+	switch appsv1.PodManagementPolicyType(service.PodManagementPolicy) {
+	case "", appsv1.OrderedReadyPodManagement, appsv1.ParallelPodManagement:
+	default:
+		return ErrInvalidPodManagementPolicy
+	}
+	names := make(map[string]struct{}, len(service.VolumeClaimTemplates))
+	for _, t := range service.VolumeClaimTemplates {
+		if t.Name == "" || t.MountPath == "" {
+			return fmt.Errorf("%w: %+v", ErrInvalidVolumeClaimTemplate, t)
+		}
+		if _, err := resource.ParseQuantity(t.Size); err != nil {
+			return fmt.Errorf("%w: %+v", ErrInvalidVolumeClaimTemplate, t)
+		}
+		if _, ok := names[t.Name]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateVolumeClaimTemplate, t.Name)
+		}
+		names[t.Name] = struct{}{}
+	}
+	return nil
+}
@@ -1,12 +1,14 @@
 package workload
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload/network"
@@ -438,3 +440,253 @@ func TestCompleteServiceInput(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceGenerator_GenerateStatefulSetAndDaemonSet(t *testing.T) {
+	sts := `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+    creationTimestamp: null
+    labels:
+        app.kubernetes.io/name: kafka
+        app.kubernetes.io/part-of: default
+    name: default-dev-kafka
+    namespace: default
+spec:
+    podManagementPolicy: Parallel
+    replicas: 3
+    selector:
+        matchLabels:
+            app.kubernetes.io/name: kafka
+            app.kubernetes.io/part-of: default
+    serviceName: default-dev-kafka-headless
+    template:
+        metadata:
+            creationTimestamp: null
+            labels:
+                app.kubernetes.io/name: kafka
+                app.kubernetes.io/part-of: default
+        spec:
+            containers:
+                - image: kafka:v1
+                  name: kafka
+                  resources: {}
+                  volumeMounts:
+                    - mountPath: /var/lib/kafka
+                      name: data
+    updateStrategy: {}
+    volumeClaimTemplates:
+        - metadata:
+            creationTimestamp: null
+            name: data
+          spec:
+            accessModes:
+                - ReadWriteOnce
+            resources:
+                requests:
+                    storage: 10Gi
+            storageClassName: ssd
+          status: {}
+status:
+    availableReplicas: 0
+    replicas: 0
+`
+	headlessSvc := `apiVersion: v1
+kind: Service
+metadata:
+    creationTimestamp: null
+    labels:
+        app.kubernetes.io/name: kafka
+        app.kubernetes.io/part-of: default
+    name: default-dev-kafka-headless
+    namespace: default
+spec:
+    clusterIP: None
+    ports:
+        - name: tcp-9092
+          port: 9092
+          protocol: TCP
+          targetPort: 9092
+    selector:
+        app.kubernetes.io/name: kafka
+        app.kubernetes.io/part-of: default
+status:
+    loadBalancer: {}
+`
+	ds := `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+    creationTimestamp: null
+    labels:
+        app.kubernetes.io/name: kafka
+        app.kubernetes.io/part-of: default
+    name: default-dev-kafka
+    namespace: default
+spec:
+    selector:
+        matchLabels:
+            app.kubernetes.io/name: kafka
+            app.kubernetes.io/part-of: default
+    template:
+        metadata:
+            creationTimestamp: null
+            labels:
+                app.kubernetes.io/name: kafka
+                app.kubernetes.io/part-of: default
+        spec:
+            containers:
+                - image: kafka:v1
+                  name: kafka
+                  resources: {}
+    updateStrategy: {}
+status:
+    currentNumberScheduled: 0
+    desiredNumberScheduled: 0
+    numberMisscheduled: 0
+    numberReady: 0
+`
+	r3 := int32(3)
+	tests := []struct {
+		name    string
+		service *workload.Service
+		config  apiv1.GenericConfig
+		want    []string
+	}{
+		{
+			name: "StatefulSet",
+			service: &workload.Service{
+				Base: workload.Base{
+					Containers: map[string]container.Container{"kafka": {Image: "kafka:v1"}},
+					Replicas:   &r3,
+				},
+				Type:                "StatefulSet",
+				PodManagementPolicy: "Parallel",
+				VolumeClaimTemplates: []workload.VolumeClaimTemplate{
+					{Name: "data", MountPath: "/var/lib/kafka", Size: "10Gi", StorageClassName: "ssd"},
+				},
+				Ports: []network.Port{{Port: 9092, Protocol: "TCP"}},
+			},
+			want: []string{sts, headlessSvc},
+		},
+		{
+			name: "DaemonSet in workspace config",
+			service: &workload.Service{
+				Base: workload.Base{
+					Containers: map[string]container.Container{"kafka": {Image: "kafka:v1"}},
+				},
+			},
+			config: apiv1.GenericConfig{"type": "DaemonSet", "replicas": 2},
+			want:   []string{ds},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ServiceGenerator{
+				Project:   "default",
+				Stack:     "dev",
+				App:       "kafka",
+				Service:   tt.service,
+				Config:    tt.config,
+				Namespace: "default",
+			}
+			spec := &apiv1.Intent{}
+			require.NoError(t, g.Generate(spec))
+			require.Len(t, spec.Resources, len(tt.want))
+			for i := range spec.Resources {
+				b, err := yaml.Marshal(spec.Resources[i].Attributes)
+				require.NoError(t, err)
+				require.Equal(t, tt.want[i], string(b))
+			}
+		})
+	}
+}
+
+func TestServiceGenerator_GenerateStatefulSetVolumeMounts(t *testing.T) {
+	g := &ServiceGenerator{
+		Project: "default",
+		Stack:   "dev",
+		App:     "kafka",
+		Service: &workload.Service{
+			Base: workload.Base{
+				Containers:     map[string]container.Container{"kafka": {Image: "kafka:v1"}},
+				InitContainers: map[string]container.Container{"init": {Image: "busybox:v1"}},
+				Sidecars:       map[string]container.Container{"proxy": {Image: "proxy:v1"}},
+			},
+			Type: workload.StatefulSet,
+			VolumeClaimTemplates: []workload.VolumeClaimTemplate{
+				{Name: "data", MountPath: "/var/lib/kafka", Size: "10Gi"},
+			},
+		},
+		Config:    apiv1.GenericConfig{"nativeSidecar": true},
+		Namespace: "default",
+	}
+	spec := &apiv1.Intent{}
+	require.NoError(t, g.Generate(spec))
+
+	// the volume claims are mounted to the containers, init containers and native sidecars
+	var names []string
+	for _, field := range []string{"initContainers", "containers"} {
+		ctns, _, err := unstructured.NestedSlice(spec.Resources[0].Attributes, "spec", "template", "spec", field)
+		require.NoError(t, err)
+		for _, c := range ctns {
+			ctn := c.(map[string]any)
+			names = append(names, ctn["name"].(string))
+			assert.Contains(t, ctn["volumeMounts"], map[string]any{"name": "data", "mountPath": "/var/lib/kafka"})
+		}
+	}
+	assert.ElementsMatch(t, []string{"init", "proxy", "kafka"}, names)
+}
+
+func TestValidateServiceInput(t *testing.T) {
+	testcases := []struct {
+		name    string
+		service *workload.Service
+		wantErr error
+	}{
+		{
+			name:    "valid StatefulSet",
+			service: &workload.Service{Type: workload.StatefulSet, PodManagementPolicy: "OrderedReady"},
+		},
+		{
+			name:    "unsupported type",
+			service: &workload.Service{Type: "ReplicaSet"},
+			wantErr: errors.New("unsupported Service type ReplicaSet"),
+		},
+		{
+			name:    "StatefulSet only fields",
+			service: &workload.Service{Type: workload.Deployment, PodManagementPolicy: "Parallel"},
+			wantErr: ErrStatefulSetOnlyFields,
+		},
+		{
+			name:    "invalid podManagementPolicy",
+			service: &workload.Service{Type: workload.StatefulSet, PodManagementPolicy: "Random"},
+			wantErr: ErrInvalidPodManagementPolicy,
+		},
+		{
+			name: "invalid size",
+			service: &workload.Service{Type: workload.StatefulSet, VolumeClaimTemplates: []workload.VolumeClaimTemplate{
+				{Name: "data", MountPath: "/data", Size: "large"},
+			}},
+			wantErr: ErrInvalidVolumeClaimTemplate,
+		},
+		{
+			name: "duplicate name",
+			service: &workload.Service{Type: workload.StatefulSet, VolumeClaimTemplates: []workload.VolumeClaimTemplate{
+				{Name: "data", MountPath: "/data", Size: "1Gi"},
+				{Name: "data", MountPath: "/log", Size: "1Gi"},
+			}},
+			wantErr: ErrDuplicateVolumeClaimTemplate,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateServiceInput(tc.service)
+			switch {
+			case tc.wantErr == nil:
+				assert.NoError(t, err)
+			case errors.Is(err, tc.wantErr):
+			default:
+				assert.EqualError(t, err, tc.wantErr.Error())
+			}
+		})
+	}
+}
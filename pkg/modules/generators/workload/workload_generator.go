@@ -1,6 +1,7 @@
 package workload
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"path/filepath"
@@ -9,9 +10,11 @@ import (
 
 	"github.com/imdario/mergo"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	apiv1 "kusionstack.io/kusion/pkg/apis/core/v1"
@@ -23,6 +26,11 @@ import (
 	"kusionstack.io/kusion/pkg/workspace"
 )
 
+var (
+	ErrDuplicateContainerName        = errors.New("container names must be unique across containers, initContainers and sidecars")
+	ErrInitContainerProbeOrLifecycle = errors.New("probes and lifecycle are not supported by initContainers, use sidecars instead")
+)
+
 type Generator struct {
 	// Project represents the Project name
 	Project string
@@ -38,6 +46,8 @@ type Generator struct {
 	PlatformConfigs map[string]apiv1.GenericConfig
 	// SecretStoreSpec contains configuration to describe target secret store.
 	SecretStoreSpec *apiv1.SecretStoreSpec
+	// SecretResolver retrieves the external secrets, which is shared during a build.
+	SecretResolver *secret.ExternalSecretResolver
 }
 
 func NewWorkloadGeneratorFunc(g *Generator) modules.NewGeneratorFunc {
@@ -73,6 +83,7 @@ func (g *Generator) Generate(spec *apiv1.Intent) error {
 				Namespace:       g.Namespace,
 				Workload:        g.Workload,
 				SecretStoreSpec: g.SecretStoreSpec,
+				SecretResolver:  g.SecretResolver,
 			}))
 		case workload.TypeJob:
 			gfs = append(gfs, NewJobGeneratorFunc(g), secret.NewSecretGeneratorFunc(&secret.GeneratorRequest{
@@ -80,6 +91,7 @@ func (g *Generator) Generate(spec *apiv1.Intent) error {
 				Namespace:       g.Namespace,
 				Workload:        g.Workload,
 				SecretStoreSpec: g.SecretStoreSpec,
+				SecretResolver:  g.SecretResolver,
 			}))
 		}
 
@@ -100,14 +112,23 @@ func toOrderedContainers(
 
 	// Create a slice of volumes and configMaps based on the containers' files to be created.
 	var volumes []corev1.Volume
-	var volumeMounts []corev1.VolumeMount
 	var configMaps []corev1.ConfigMap
 
 	if err := modules.ForeachOrdered(appContainers, func(containerName string, c container.Container) error {
-		// Create a slice of env vars based on the container's env vars.
+		// Create a slice of env vars and env sources based on the container's env vars.
 		var envs []corev1.EnvVar
+		var envFroms []corev1.EnvFromSource
 		for _, m := range c.Env {
-			envs = append(envs, *MagicEnvVar(m.Key.(string), m.Value.(string)))
+			k, v := m.Key.(string), m.Value.(string)
+			if envFrom := MagicEnvFrom(k, v); envFrom != nil {
+				envFroms = append(envFroms, *envFrom)
+				continue
+			}
+			env := MagicEnvVar(k, v)
+			if env == nil {
+				return fmt.Errorf("invalid value %s of environment variable %s in container %s", v, k, containerName)
+			}
+			envs = append(envs, *env)
 		}
 
 		resourceRequirements, err := handleResourceRequirementsV1(c.Resources)
@@ -123,6 +144,7 @@ func toOrderedContainers(
 			Args:       c.Args,
 			WorkingDir: c.WorkingDir,
 			Env:        envs,
+			EnvFrom:    envFroms,
 			Resources:  resourceRequirements,
 		}
 		if err = updateContainer(&c, &ctn); err != nil {
@@ -130,10 +152,12 @@ func toOrderedContainers(
 		}
 
 		// Append the configMap, volume and volumeMount objects into the corresponding slices.
-		volumes, volumeMounts, configMaps, err = handleFileCreation(c, uniqueAppName, containerName)
+		ctnVolumes, volumeMounts, ctnConfigMaps, err := handleFileCreation(c, uniqueAppName, containerName)
 		if err != nil {
 			return err
 		}
+		volumes = append(volumes, ctnVolumes...)
+		configMaps = append(configMaps, ctnConfigMaps...)
 		ctn.VolumeMounts = append(ctn.VolumeMounts, volumeMounts...)
 
 		// Append the container object to the containers slice.
@@ -145,6 +169,108 @@ func toOrderedContainers(
 	return containers, volumes, configMaps, nil
 }
 
+// podContainers is the containers of the pod generated from the workload, together with the volumes and
+// configMaps of their files.
+type podContainers struct {
+	containers     []corev1.Container
+	initContainers []corev1.Container
+	volumes        []corev1.Volume
+	configMaps     []corev1.ConfigMap
+	// the names of the sidecars generated as the native sidecar containers
+	nativeSidecars []string
+}
+
+// toPodContainers converts the containers, init containers and sidecars of the workload to the containers of the
+// pod. The sidecars are generated as the init containers with restartPolicy Always after the init containers if
+// nativeSidecar is true, otherwise as the regular containers after the containers.
+func toPodContainers(base *workload.Base, uniqueAppName string, nativeSidecar bool) (*podContainers, error) {
+	names := make(map[string]bool)
+	for _, group := range []map[string]container.Container{base.Containers, base.InitContainers, base.Sidecars} {
+		for name := range group {
+			if names[name] {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateContainerName, name)
+			}
+			names[name] = true
+		}
+	}
+	// Kubernetes only allows the probes and lifecycle on the init containers with restartPolicy Always, i.e. the
+	// native sidecars, while the sidecars are regular containers if not native.
+	if err := modules.ForeachOrdered(base.InitContainers, func(name string, c container.Container) error {
+		if c.LivenessProbe != nil || c.ReadinessProbe != nil || c.StartupProbe != nil || c.Lifecycle != nil {
+			return fmt.Errorf("%w: %s", ErrInitContainerProbeOrLifecycle, name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	result := &podContainers{}
+	convert := func(containers map[string]container.Container) ([]corev1.Container, error) {
+		ctns, volumes, configMaps, err := toOrderedContainers(containers, uniqueAppName)
+		if err != nil {
+			return nil, err
+		}
+		result.volumes = append(result.volumes, volumes...)
+		result.configMaps = append(result.configMaps, configMaps...)
+		return ctns, nil
+	}
+
+	var err error
+	if result.containers, err = convert(base.Containers); err != nil {
+		return nil, err
+	}
+	if result.initContainers, err = convert(base.InitContainers); err != nil {
+		return nil, err
+	}
+	sidecars, err := convert(base.Sidecars)
+	if err != nil {
+		return nil, err
+	}
+	if !nativeSidecar {
+		result.containers = append(result.containers, sidecars...)
+		return result, nil
+	}
+	result.initContainers = append(result.initContainers, sidecars...)
+	for _, sidecar := range sidecars {
+		result.nativeSidecars = append(result.nativeSidecars, sidecar.Name)
+	}
+	return result, nil
+}
+
+// isNativeSidecar returns whether the workspace module config enables the native sidecar containers.
+func isNativeSidecar(config apiv1.GenericConfig) (bool, error) {
+	sidecarConfig := struct {
+		NativeSidecar bool `json:"nativeSidecar,omitempty"`
+	}{}
+	if err := decodeConfigFields(config, &sidecarConfig, workload.FieldNativeSidecar); err != nil {
+		return false, fmt.Errorf("invalid nativeSidecar config, %w", err)
+	}
+	return sidecarConfig.NativeSidecar, nil
+}
+
+// setNativeSidecars sets restartPolicy Always to the init containers of the native sidecars in the pod spec of
+// the generated resource, as the field is not available in the Kubernetes API types in use.
+func setNativeSidecars(resource map[string]any, podSpecFields []string, sidecars []string) error {
+	if len(sidecars) == 0 {
+		return nil
+	}
+	fields := append(append([]string{}, podSpecFields...), "initContainers")
+	initContainers, _, err := unstructured.NestedSlice(resource, fields...)
+	if err != nil {
+		return err
+	}
+	for _, c := range initContainers {
+		ctn, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if name, _ := ctn["name"].(string); slices.Contains(sidecars, name) {
+			ctn["restartPolicy"] = string(corev1.RestartPolicyAlways)
+		}
+	}
+	return unstructured.SetNestedSlice(resource, initContainers, fields...)
+}
+
 // updateContainer updates corev1.Container with passed parameters.
 func updateContainer(in *container.Container, out *corev1.Container) error {
 	if in.ReadinessProbe != nil {
@@ -179,6 +305,10 @@ func updateContainer(in *container.Container, out *corev1.Container) error {
 		out.Lifecycle = lifecycle
 	}
 
+	if in.SecurityContext != nil {
+		out.SecurityContext = convertKusionSecurityContextToV1(in.SecurityContext)
+	}
+
 	return nil
 }
 
@@ -261,6 +391,12 @@ func convertKusionProbeToV1Probe(p *container.Probe) (*corev1.Probe, error) {
 			return nil, err
 		}
 		result.TCPSocket = action
+	case "Grpc":
+		action, err := grpcAction(probeHandler.GRPCAction)
+		if err != nil {
+			return nil, err
+		}
+		result.GRPC = action
 	}
 	return result, nil
 }
@@ -296,6 +432,19 @@ func lifecycleHandler(in *container.LifecycleHandler) (*corev1.LifecycleHandler,
 		result.HTTPGet = action
 	case "Exec":
 		result.Exec = &corev1.ExecAction{Command: in.Command}
+	case "Grpc":
+		return nil, container.ErrGrpcLifecycleHandler
+	}
+	return result, nil
+}
+
+func grpcAction(action *container.GRPCAction) (*corev1.GRPCAction, error) {
+	if action == nil || action.Port < 1 || action.Port > 65535 {
+		return nil, errors.New("the port of the grpc probe must be between 1 and 65535")
+	}
+	result := &corev1.GRPCAction{Port: action.Port}
+	if action.Service != "" {
+		result.Service = &action.Service
 	}
 	return result, nil
 }
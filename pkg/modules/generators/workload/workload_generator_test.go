@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
 
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload/container"
 	"kusionstack.io/kusion/pkg/apis/core/v1/workload/network"
@@ -186,6 +187,41 @@ func TestToOrderedContainers(t *testing.T) {
 		assert.Equal(t, wantedConfigMapData, actualConfigMaps[0].Data, "ConfigMap data mismatch")
 		assert.Equal(t, wantedConfigMapData, actualConfigMaps[1].Data, "ConfigMap data mismatch")
 	})
+	t.Run("toOrderedContainers should convert magic env vars to env and envFrom", func(t *testing.T) {
+		appContainers := map[string]container.Container{
+			"nginx": {
+				Image: "nginx:v1",
+				Env: yaml.MapSlice{
+					{Key: "DB_*", Value: "secret://db"},
+					{Key: "*", Value: "configmap://app"},
+					{Key: "POD_NAME", Value: "fieldref://metadata.name"},
+					{Key: "LOG_LEVEL", Value: "configmap://app/logLevel"},
+				},
+			},
+		}
+
+		actualContainers, _, _, err := toOrderedContainers(appContainers, "mock-app-name")
+		assert.NoError(t, err)
+		assert.Equal(t, []corev1.EnvFromSource{
+			{
+				Prefix:    "DB_",
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}},
+			},
+			{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}},
+			},
+		}, actualContainers[0].EnvFrom)
+		assert.Len(t, actualContainers[0].Env, 2)
+		assert.Equal(t, "metadata.name", actualContainers[0].Env[0].ValueFrom.FieldRef.FieldPath)
+		assert.Equal(t, "logLevel", actualContainers[0].Env[1].ValueFrom.ConfigMapKeyRef.Key)
+
+		appContainers["nginx"] = container.Container{
+			Image: "nginx:v1",
+			Env:   yaml.MapSlice{{Key: "TOKEN", Value: "secret://token/key/extra"}},
+		}
+		_, _, _, err = toOrderedContainers(appContainers, "mock-app-name")
+		assert.Error(t, err)
+	})
 	t.Run("toOrderedContainers should convert app containers with probe to ordered containers", func(t *testing.T) {
 		appContainers := map[string]container.Container{
 			"nginx": {
@@ -269,6 +305,42 @@ func TestToOrderedContainers(t *testing.T) {
 		assert.Equal(t, "10.0.0.1", actualContainers[0].StartupProbe.TCPSocket.Host, "TCPSocket.Host mismatch")
 		assert.Equal(t, "8888", actualContainers[0].StartupProbe.TCPSocket.Port.String(), "TCPSocket.Port mismatch")
 	})
+	t.Run("toOrderedContainers should convert app containers with grpc probe to ordered containers", func(t *testing.T) {
+		grpcProbe := func(port int32, service string) *container.Probe {
+			return &container.Probe{
+				ProbeHandler: &container.ProbeHandler{
+					TypeWrapper: container.TypeWrapper{Type: "Grpc"},
+					GRPCAction:  &container.GRPCAction{Port: port, Service: service},
+				},
+			}
+		}
+		appContainers := map[string]container.Container{
+			"server": {
+				Image:          "grpc-server:v1",
+				LivenessProbe:  grpcProbe(9090, ""),
+				ReadinessProbe: grpcProbe(9090, "readiness"),
+			},
+		}
+
+		actualContainers, _, _, err := toOrderedContainers(appContainers, "mock-app-name")
+		assert.NoError(t, err, "Error should be nil")
+		service := "readiness"
+		assert.Equal(t, &corev1.GRPCAction{Port: 9090}, actualContainers[0].LivenessProbe.GRPC, "LivenessProbe.GRPC mismatch")
+		assert.Equal(t, &corev1.GRPCAction{Port: 9090, Service: &service}, actualContainers[0].ReadinessProbe.GRPC, "ReadinessProbe.GRPC mismatch")
+
+		appContainers["server"] = container.Container{Image: "grpc-server:v1", StartupProbe: grpcProbe(0, "")}
+		_, _, _, err = toOrderedContainers(appContainers, "mock-app-name")
+		assert.Error(t, err, "the port of the grpc probe should be validated")
+
+		appContainers["server"] = container.Container{
+			Image: "grpc-server:v1",
+			Lifecycle: &container.Lifecycle{
+				PreStop: &container.LifecycleHandler{TypeWrapper: container.TypeWrapper{Type: "Grpc"}},
+			},
+		}
+		_, _, _, err = toOrderedContainers(appContainers, "mock-app-name")
+		assert.ErrorIs(t, err, container.ErrGrpcLifecycleHandler)
+	})
 	t.Run("toOrderedContainers should convert app containers with lifecycle to ordered containers", func(t *testing.T) {
 		appContainers := map[string]container.Container{
 			"nginx": {
@@ -496,3 +568,126 @@ func TestCompleteBaseWorkload(t *testing.T) {
 		})
 	}
 }
+
+func TestToPodContainers(t *testing.T) {
+	file := map[string]container.FileSpec{
+		"/etc/app/app.conf": {Content: "some file contents", Mode: "0644"},
+	}
+	base := &workload.Base{
+		Containers: map[string]container.Container{
+			"main": {Image: "main:v1", Files: file},
+			"app":  {Image: "app:v1"},
+		},
+		InitContainers: map[string]container.Container{
+			"migrate": {Image: "migrate:v1", Files: file},
+			"init":    {Image: "init:v1"},
+		},
+		Sidecars: map[string]container.Container{
+			"proxy": {Image: "proxy:v1", Files: file},
+		},
+	}
+	names := func(containers []corev1.Container) []string {
+		var result []string
+		for _, c := range containers {
+			result = append(result, c.Name)
+		}
+		return result
+	}
+
+	t.Run("sidecars as regular containers", func(t *testing.T) {
+		actual, err := toPodContainers(base, "mock-app-name", false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"app", "main", "proxy"}, names(actual.containers))
+		assert.Equal(t, []string{"init", "migrate"}, names(actual.initContainers))
+		assert.Empty(t, actual.nativeSidecars)
+
+		// the files of all the containers are kept, whose names don't collide across the container groups
+		var configMapNames, volumeNames []string
+		for _, cm := range actual.configMaps {
+			configMapNames = append(configMapNames, cm.Name)
+		}
+		for _, v := range actual.volumes {
+			volumeNames = append(volumeNames, v.Name)
+		}
+		expected := []string{"mock-app-name-main-0", "mock-app-name-migrate-0", "mock-app-name-proxy-0"}
+		assert.Equal(t, expected, configMapNames)
+		assert.Equal(t, expected, volumeNames)
+	})
+
+	t.Run("native sidecars", func(t *testing.T) {
+		actual, err := toPodContainers(base, "mock-app-name", true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"app", "main"}, names(actual.containers))
+		assert.Equal(t, []string{"init", "migrate", "proxy"}, names(actual.initContainers))
+		assert.Equal(t, []string{"proxy"}, actual.nativeSidecars)
+	})
+
+	t.Run("probes and lifecycle of init containers", func(t *testing.T) {
+		probe := &container.Probe{
+			ProbeHandler: &container.ProbeHandler{
+				TypeWrapper: container.TypeWrapper{Type: "Exec"},
+				ExecAction:  &container.ExecAction{Command: []string{"/bin/true"}},
+			},
+		}
+		lifecycle := &container.Lifecycle{
+			PreStop: &container.LifecycleHandler{
+				TypeWrapper: container.TypeWrapper{Type: "Exec"},
+				ExecAction:  &container.ExecAction{Command: []string{"/bin/true"}},
+			},
+		}
+		for _, c := range []container.Container{
+			{Image: "init:v1", ReadinessProbe: probe},
+			{Image: "init:v1", LivenessProbe: probe},
+			{Image: "init:v1", StartupProbe: probe},
+			{Image: "init:v1", Lifecycle: lifecycle},
+		} {
+			_, err := toPodContainers(&workload.Base{
+				InitContainers: map[string]container.Container{"init": c},
+			}, "mock-app-name", true)
+			assert.ErrorIs(t, err, ErrInitContainerProbeOrLifecycle)
+		}
+
+		// the probes and lifecycle are allowed by the sidecars, either native or not
+		for _, nativeSidecar := range []bool{true, false} {
+			_, err := toPodContainers(&workload.Base{
+				Sidecars: map[string]container.Container{
+					"proxy": {Image: "proxy:v1", ReadinessProbe: probe, Lifecycle: lifecycle},
+				},
+			}, "mock-app-name", nativeSidecar)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("duplicate container names", func(t *testing.T) {
+		_, err := toPodContainers(&workload.Base{
+			Containers: map[string]container.Container{"main": {Image: "main:v1"}},
+			Sidecars:   map[string]container.Container{"main": {Image: "proxy:v1"}},
+		}, "mock-app-name", false)
+		assert.ErrorIs(t, err, ErrDuplicateContainerName)
+	})
+}
+
+func TestSetNativeSidecars(t *testing.T) {
+	resource := map[string]any{
+		"spec": map[string]any{
+			"jobTemplate": map[string]any{
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": map[string]any{
+							"initContainers": []any{
+								map[string]any{"name": "init"},
+								map[string]any{"name": "proxy"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err := setNativeSidecars(resource, []string{"spec", "jobTemplate", "spec", "template", "spec"}, []string{"proxy"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"name": "init"},
+		map[string]any{"name": "proxy", "restartPolicy": "Always"},
+	}, resource["spec"].(map[string]any)["jobTemplate"].(map[string]any)["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["initContainers"])
+}
@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -22,6 +23,11 @@ const (
 
 var mu sync.Mutex
 
+var (
+	ErrInvalidModuleKey = errors.New("invalid module key")
+	ErrModuleNotFound   = errors.New("module dir doesn't exist")
+)
+
 // PluginMap is the map of plugins we can dispense.
 var PluginMap = map[string]plugin.Plugin{
 	PluginKey: &GRPCPlugin{},
@@ -50,13 +56,13 @@ func NewPlugin(key string) (*Plugin, error) {
 func (p *Plugin) initModule() error {
 	key := p.key
 	split := strings.Split(key, "@")
-	msg := "invalid module key: %s. The correct format for a key should be as follows: namespace/resourceType@version. e.g. kusionstack/mysql@v0.1"
+	msg := "%w: %s. The correct format for a key should be as follows: namespace/resourceType@version. e.g. kusionstack/mysql@v0.1"
 	if len(split) != 2 {
-		return fmt.Errorf(msg, key)
+		return fmt.Errorf(msg, ErrInvalidModuleKey, key)
 	}
 	prefix := strings.Split(split[0], "/")
 	if len(prefix) != 2 {
-		return fmt.Errorf(msg, key)
+		return fmt.Errorf(msg, ErrInvalidModuleKey, key)
 	}
 
 	// build the plugin client
@@ -96,7 +102,7 @@ func buildPluginPath(namespace, resourceType, version string) (string, error) {
 	_, err = os.Stat(p)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", fmt.Errorf("module dir doesn't exist. %s", p)
+			return "", fmt.Errorf("%w. %s", ErrModuleNotFound, p)
 		} else {
 			return "", err
 		}
@@ -119,7 +125,9 @@ func newPluginClient(path string) *plugin.Client {
 }
 
 func (p *Plugin) KillPluginClient() {
-	p.client.Kill()
+	if p.client != nil {
+		p.client.Kill()
+	}
 }
 
 func PluginDir() (string, error) {
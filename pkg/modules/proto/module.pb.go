@@ -122,7 +122,8 @@ type GeneratorResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Resources [][]byte `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"` // Project represents the project name
+	Resources       [][]byte          `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`                                      // Project represents the project name
+	MagicEnvSchemes []*MagicEnvScheme `protobuf:"bytes,2,rep,name=magic_env_schemes,json=magicEnvSchemes,proto3" json:"magic_env_schemes,omitempty"` // MagicEnvSchemes are the schemes of the magic environment variables referring to the Secrets generated by the module
 }
 
 func (x *GeneratorResponse) Reset() {
@@ -164,6 +165,77 @@ func (x *GeneratorResponse) GetResources() [][]byte {
 	return nil
 }
 
+func (x *GeneratorResponse) GetMagicEnvSchemes() []*MagicEnvScheme {
+	if x != nil {
+		return x.MagicEnvSchemes
+	}
+	return nil
+}
+
+// MagicEnvScheme represents the magic environment variables in the format of <scheme>://<name>/<key>, which refer to the key of the Secret named <secret_name_prefix><name><secret_name_suffix>
+type MagicEnvScheme struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scheme           string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`                                               // Scheme is the scheme of the values, such as mysql
+	SecretNamePrefix string `protobuf:"bytes,2,opt,name=secret_name_prefix,json=secretNamePrefix,proto3" json:"secret_name_prefix,omitempty"` // SecretNamePrefix is the prefix of the name of the referred Secret
+	SecretNameSuffix string `protobuf:"bytes,3,opt,name=secret_name_suffix,json=secretNameSuffix,proto3" json:"secret_name_suffix,omitempty"` // SecretNameSuffix is the suffix of the name of the referred Secret, such as -mysql
+}
+
+func (x *MagicEnvScheme) Reset() {
+	*x = MagicEnvScheme{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_module_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MagicEnvScheme) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MagicEnvScheme) ProtoMessage() {}
+
+func (x *MagicEnvScheme) ProtoReflect() protoreflect.Message {
+	mi := &file_module_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MagicEnvScheme.ProtoReflect.Descriptor instead.
+func (*MagicEnvScheme) Descriptor() ([]byte, []int) {
+	return file_module_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MagicEnvScheme) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *MagicEnvScheme) GetSecretNamePrefix() string {
+	if x != nil {
+		return x.SecretNamePrefix
+	}
+	return ""
+}
+
+func (x *MagicEnvScheme) GetSecretNameSuffix() string {
+	if x != nil {
+		return x.SecretNameSuffix
+	}
+	return ""
+}
+
 var File_module_proto protoreflect.FileDescriptor
 
 var file_module_proto_rawDesc = []byte{
@@ -183,15 +255,28 @@ var file_module_proto_rawDesc = []byte{
 	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63,
 	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x72, 0x75, 0x6e,
-	0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x31, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x6e, 0x0a, 0x11, 0x47, 0x65,
 	0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x32, 0x3b, 0x0a,
-	0x06, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x47, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x3b, 0x0a,
+	0x11, 0x6d, 0x61, 0x67, 0x69, 0x63, 0x5f, 0x65, 0x6e, 0x76, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x61, 0x67, 0x69, 0x63,
+	0x45, 0x6e, 0x76, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x0f, 0x6d, 0x61, 0x67, 0x69, 0x63,
+	0x45, 0x6e, 0x76, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x0e, 0x4d,
+	0x61, 0x67, 0x69, 0x63, 0x45, 0x6e, 0x76, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x5f, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x66, 0x66, 0x69,
+	0x78, 0x32, 0x3b, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a,
+	0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -206,19 +291,21 @@ func file_module_proto_rawDescGZIP() []byte {
 	return file_module_proto_rawDescData
 }
 
-var file_module_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_module_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_module_proto_goTypes = []interface{}{
 	(*GeneratorRequest)(nil),  // 0: GeneratorRequest
 	(*GeneratorResponse)(nil), // 1: GeneratorResponse
+	(*MagicEnvScheme)(nil),    // 2: MagicEnvScheme
 }
 var file_module_proto_depIdxs = []int32{
-	0, // 0: Module.Generate:input_type -> GeneratorRequest
-	1, // 1: Module.Generate:output_type -> GeneratorResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: GeneratorResponse.magic_env_schemes:type_name -> MagicEnvScheme
+	0, // 1: Module.Generate:input_type -> GeneratorRequest
+	1, // 2: Module.Generate:output_type -> GeneratorResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_module_proto_init() }
@@ -251,6 +338,18 @@ func file_module_proto_init() {
 				return nil
 			}
 		}
+		file_module_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MagicEnvScheme); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -258,7 +357,7 @@ func file_module_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_module_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
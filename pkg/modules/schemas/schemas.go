@@ -0,0 +1,211 @@
+package schemas
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/modules"
+)
+
+// PluginSchemaFile is the file name of the JSON Schema published by a kusion module plugin, which is placed in
+// the version directory of the module, next to the platform directories of the binaries, such as
+// $KUSION_MODULE_PATH/kusionstack/mysql/v0.1/schema.json.
+const PluginSchemaFile = "schema.json"
+
+// builtinSchemas contains the JSON Schemas of the platform configs of the built-in generators, whose file name
+// is the module name.
+//
+//go:embed *.json
+var builtinSchemas embed.FS
+
+var (
+	mu      sync.Mutex
+	schemas = map[string]*jsonschema.Schema{}
+)
+
+// Get returns the JSON Schema of the platform config of the specified module. The schemas of the built-in
+// modules take precedence, and then the schema published by the latest version of the plugin module whose
+// resource type is the module name. If the module publishes no schema, nil is returned.
+func Get(moduleName string) (*jsonschema.Schema, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if schema, ok := schemas[moduleName]; ok {
+		return schema, nil
+	}
+
+	url, content, err := loadSchema(moduleName)
+	if err != nil {
+		return nil, err
+	}
+	var schema *jsonschema.Schema
+	if content != nil {
+		compiler := jsonschema.NewCompiler()
+		compiler.Draft = jsonschema.Draft2020
+		if err = compiler.AddResource(url, bytes.NewReader(content)); err != nil {
+			return nil, fmt.Errorf("load schema of module %s failed: %w", moduleName, err)
+		}
+		if schema, err = compiler.Compile(url); err != nil {
+			return nil, fmt.Errorf("compile schema of module %s failed: %w", moduleName, err)
+		}
+	}
+	schemas[moduleName] = schema
+	return schema, nil
+}
+
+// loadSchema returns the location and content of the schema of the module, nil content if not found.
+func loadSchema(moduleName string) (string, []byte, error) {
+	fileName := moduleName + ".json"
+	if content, err := builtinSchemas.ReadFile(fileName); err == nil {
+		return "builtin://" + fileName, content, nil
+	}
+
+	dir, err := modules.PluginDir()
+	if err != nil {
+		return "", nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*", moduleName, "*", PluginSchemaFile))
+	if err != nil || len(files) == 0 {
+		return "", nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return lessVersion(filepath.Base(filepath.Dir(files[i])), filepath.Base(filepath.Dir(files[j])))
+	})
+	file := files[len(files)-1]
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("read schema of module %s failed: %w", moduleName, err)
+	}
+	return "file://" + filepath.ToSlash(file), content, nil
+}
+
+// lessVersion compares the versions of the plugin modules, and compares as strings if not semantic versions.
+func lessVersion(a, b string) bool {
+	va, errA := version.NewVersion(a)
+	vb, errB := version.NewVersion(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return va.LessThan(vb)
+}
+
+// ValidateModuleConfigs validates the platform configs of the modules against the JSON Schemas they publish,
+// and the modules without schema are skipped. All the unknown fields, wrong types and missing required values
+// are reported with the paths of the values, such as "modules.service.default.replica".
+func ValidateModuleConfigs(configs v1.ModuleConfigs) error {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var allErrs []error
+	for _, name := range names {
+		allErrs = append(allErrs, validateModuleConfig(name, configs[name])...)
+	}
+	return utilerrors.NewAggregate(allErrs)
+}
+
+// ValidateModuleConfig validates the platform config of the module against its JSON Schema. The default block
+// is validated as it is, while each patcher block is validated after overriding the default block, which is the
+// config the selected projects get.
+func ValidateModuleConfig(name string, config *v1.ModuleConfig) error {
+	return utilerrors.NewAggregate(validateModuleConfig(name, config))
+}
+
+func validateModuleConfig(name string, config *v1.ModuleConfig) []error {
+	if config == nil {
+		return nil
+	}
+	schema, err := Get(name)
+	if err != nil {
+		return []error{err}
+	}
+	if schema == nil {
+		return nil
+	}
+
+	path := "modules." + name
+	allErrs := validateGenericConfig(schema, config.Default, path+"."+v1.DefaultBlock)
+	patcherNames := make([]string, 0, len(config.ModulePatcherConfigs))
+	for patcherName := range config.ModulePatcherConfigs {
+		patcherNames = append(patcherNames, patcherName)
+	}
+	sort.Strings(patcherNames)
+	for _, patcherName := range patcherNames {
+		patcher := config.ModulePatcherConfigs[patcherName]
+		if patcher == nil {
+			continue
+		}
+		merged := make(v1.GenericConfig, len(config.Default)+len(patcher.GenericConfig))
+		for k, v := range config.Default {
+			merged[k] = v
+		}
+		for k, v := range patcher.GenericConfig {
+			merged[k] = v
+		}
+		allErrs = append(allErrs, validateGenericConfig(schema, merged, path+"."+patcherName)...)
+	}
+	return allErrs
+}
+
+// validateGenericConfig validates the config against the schema, and returns the errors of the leaf validation
+// failures, which are prefixed with the path of the config.
+func validateGenericConfig(schema *jsonschema.Schema, config v1.GenericConfig, path string) []error {
+	if config == nil {
+		config = v1.GenericConfig{}
+	}
+	// convert the config to the values of json types
+	content, err := json.Marshal(config)
+	if err != nil {
+		return []error{fmt.Errorf("%s: json marshal failed: %w", path, err)}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var value interface{}
+	if err = decoder.Decode(&value); err != nil {
+		return []error{fmt.Errorf("%s: json unmarshal failed: %w", path, err)}
+	}
+
+	err = schema.Validate(value)
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []error{fmt.Errorf("%s: %w", path, err)}
+	}
+	var allErrs []error
+	for _, leaf := range leafErrors(validationErr) {
+		location := path
+		if leaf.InstanceLocation != "" {
+			location += strings.ReplaceAll(leaf.InstanceLocation, "/", ".")
+		}
+		allErrs = append(allErrs, fmt.Errorf("%s: %s", location, leaf.Message))
+	}
+	return allErrs
+}
+
+// leafErrors returns the validation errors without causes, which describe the exact failures.
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
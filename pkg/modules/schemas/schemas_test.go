@@ -0,0 +1,185 @@
+package schemas
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/modules"
+)
+
+func writePluginSchema(t *testing.T, dir, version, content string) {
+	schemaDir := filepath.Join(dir, "kusionstack", "mysql", version)
+	assert.NoError(t, os.MkdirAll(schemaDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(schemaDir, PluginSchemaFile), []byte(content), 0o644))
+}
+
+func resetSchemas() {
+	mu.Lock()
+	defer mu.Unlock()
+	schemas = map[string]*jsonschema.Schema{}
+}
+
+func TestGet(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(modules.DefaultModulePathEnv, dir)
+	writePluginSchema(t, dir, "v0.1.0", `{"type": "object", "required": ["instanceType"]}`)
+	writePluginSchema(t, dir, "v0.10.0", `{"type": "object", "required": ["type"]}`)
+	resetSchemas()
+
+	for _, name := range []string{"service", "job", "namespace"} {
+		schema, err := Get(name)
+		assert.NoError(t, err)
+		assert.NotNil(t, schema, name)
+	}
+
+	schema, err := Get("mysql")
+	assert.NoError(t, err)
+	assert.NotNil(t, schema)
+	assert.True(t, strings.Contains(schema.Location, filepath.ToSlash(filepath.Join("v0.10.0", PluginSchemaFile))))
+
+	schema, err = Get("not-exist")
+	assert.NoError(t, err)
+	assert.Nil(t, schema)
+}
+
+func TestValidateModuleConfigs(t *testing.T) {
+	testcases := []struct {
+		name     string
+		configs  v1.ModuleConfigs
+		expected []string
+	}{
+		{
+			name: "valid module configs",
+			configs: v1.ModuleConfigs{
+				"service": {
+					Default: v1.GenericConfig{
+						"replicas": 2,
+						"type":     "CollaSet",
+						"labels":   map[string]any{"k": "v"},
+					},
+					ModulePatcherConfigs: v1.ModulePatcherConfigs{
+						"large": {
+							GenericConfig:   v1.GenericConfig{"replicas": 5},
+							ProjectSelector: []string{"foo"},
+						},
+					},
+				},
+				"namespace": {Default: v1.GenericConfig{"name": "foo"}},
+				"unknown":   {Default: v1.GenericConfig{"any": "thing"}},
+			},
+		},
+		{
+			name: "unknown field",
+			configs: v1.ModuleConfigs{
+				"service": {Default: v1.GenericConfig{"replica": 3}},
+			},
+			expected: []string{"modules.service.default: additionalProperties 'replica' not allowed"},
+		},
+		{
+			name: "wrong types",
+			configs: v1.ModuleConfigs{
+				"job": {
+					Default: v1.GenericConfig{"replicas": "3"},
+					ModulePatcherConfigs: v1.ModulePatcherConfigs{
+						"small": {
+							GenericConfig:   v1.GenericConfig{"labels": map[string]any{"k": 1}},
+							ProjectSelector: []string{"foo"},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"modules.job.default.replicas: expected integer, but got string",
+				"modules.job.small.replicas: expected integer, but got string",
+				"modules.job.small.labels.k: expected string, but got number",
+			},
+		},
+		{
+			name: "scheduling and podDisruptionBudget",
+			configs: v1.ModuleConfigs{
+				"service": {
+					Default: v1.GenericConfig{
+						"nodeSelector":        map[string]any{"disktype": "ssd"},
+						"tolerations":         []any{map[string]any{"key": "dedicated", "operator": "Exists"}},
+						"priorityClassName":   "high-priority",
+						"podDisruptionBudget": map[string]any{"minAvailable": 1, "maxUnavailable": "25%"},
+					},
+				},
+				"job": {Default: v1.GenericConfig{"podDisruptionBudget": map[string]any{"minAvailable": 1}}},
+			},
+			expected: []string{
+				"modules.service.default.podDisruptionBudget",
+				"modules.job.default: additionalProperties 'podDisruptionBudget' not allowed",
+			},
+		},
+		{
+			name: "unsupported enum value",
+			configs: v1.ModuleConfigs{
+				"service": {Default: v1.GenericConfig{"type": "ReplicaSet"}},
+			},
+			expected: []string{"modules.service.default.type"},
+		},
+		{
+			name: "unsupported job restart policy",
+			configs: v1.ModuleConfigs{
+				"job": {Default: v1.GenericConfig{"restartPolicy": "Always", "backoffLimit": 3, "suspend": true}},
+			},
+			expected: []string{"modules.job.default.restartPolicy"},
+		},
+		{
+			name: "unsupported pod security level",
+			configs: v1.ModuleConfigs{
+				"job": {Default: v1.GenericConfig{
+					"podSecurityLevel":         "strict",
+					"containerSecurityContext": map[string]any{"runAsNonRoot": true},
+				}},
+			},
+			expected: []string{"modules.job.default.podSecurityLevel"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateModuleConfigs(tc.configs)
+			if len(tc.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, msg := range tc.expected {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestValidateModuleConfig_PluginRequired(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(modules.DefaultModulePathEnv, dir)
+	writePluginSchema(t, dir, "v0.1.0", `{
+  "type": "object",
+  "properties": {"instanceType": {"type": "string"}},
+  "required": ["instanceType"]
+}`)
+	resetSchemas()
+
+	config := &v1.ModuleConfig{
+		Default: v1.GenericConfig{},
+		ModulePatcherConfigs: v1.ModulePatcherConfigs{
+			"large": {
+				GenericConfig:   v1.GenericConfig{"instanceType": "db.m5.large"},
+				ProjectSelector: []string{"foo"},
+			},
+		},
+	}
+	err := ValidateModuleConfig("mysql", config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "modules.mysql.default: missing properties: 'instanceType'")
+	assert.NotContains(t, err.Error(), "modules.mysql.large")
+}
@@ -52,6 +52,21 @@ func DetectProjectAndStack(stackDir string) (p *v1.Project, s *v1.Stack, err err
 	return p, s, nil
 }
 
+// DetectProject try to get the project from given path, which is the project directory or its subdirectory
+func DetectProject(path string) (*v1.Project, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir, err := findProjectPathFrom(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return getProjectFrom(projectDir)
+}
+
 // isProjectFile determine whether the given path is Project file
 func isProjectFile(path string) bool {
 	f, err := os.Stat(path)
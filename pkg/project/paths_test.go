@@ -221,6 +221,64 @@ func TestDetectProjectAndStack(t *testing.T) {
 	}
 }
 
+func TestDetectProject(t *testing.T) {
+	FakeProject := &v1.Project{
+		Name: TestProjectA,
+		Path: filepath.Join(TestCurrentDir, TestProjectPathA),
+		Stacks: []*v1.Stack{
+			{
+				Name: TestStackA,
+				Path: filepath.Join(TestCurrentDir, TestStackPathAA),
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		project *v1.Project
+		wantErr bool
+		preRun  func()
+	}{
+		{
+			name:    "success-from-project",
+			path:    "./testdata/appops/http-echo/",
+			project: FakeProject,
+			wantErr: false,
+			preRun:  func() {},
+		},
+		{
+			name:    "success-from-stack",
+			path:    "./testdata/appops/http-echo/dev/",
+			project: FakeProject,
+			wantErr: false,
+			preRun:  func() {},
+		},
+		{
+			name:    "fail-for-FindProjectPathFrom",
+			path:    "./testdata/appops/http-echo/",
+			project: nil,
+			wantErr: true,
+			preRun: func() {
+				mockFindProjectPathFrom("", ErrFake)
+			},
+		},
+	}
+	for _, tt := range tests {
+		mockey.PatchConvey(tt.name, t, func() {
+			tt.preRun()
+			project, err := DetectProject(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DetectProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(project, tt.project) {
+				t.Errorf("DetectProject() got = %v, want %v", project, tt.project)
+			}
+		})
+	}
+}
+
 func mockAbs(mockAbs string, mockErr error) {
 	mockey.Mock(filepath.Abs).To(func(_ string) (string, error) {
 		return mockAbs, mockErr
@@ -0,0 +1,244 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+const (
+	FormatAge = "age"
+	FormatPGP = "pgp"
+
+	// EnvAgeIdentityFile is the environment variable of the age identity file to decrypt.
+	EnvAgeIdentityFile = "KUSION_SECRET_AGE_IDENTITY_FILE"
+	// EnvPGPPrivateKeyFile is the environment variable of the armored PGP private key to decrypt.
+	EnvPGPPrivateKeyFile = "KUSION_SECRET_PGP_PRIVATE_KEY_FILE"
+	// EnvPGPPassphrase is the environment variable of the passphrase of the PGP private key.
+	EnvPGPPassphrase = "KUSION_SECRET_PGP_PASSPHRASE"
+
+	pgpMessageType = "PGP MESSAGE"
+	pgpArmorHeader = "-----BEGIN " + pgpMessageType + "-----"
+	ageBinaryMagic = "age-encryption.org/v1"
+)
+
+var (
+	ErrUnknownFormat          = errors.New("unknown encryption format, only the files encrypted by age or PGP are supported")
+	ErrMissingAgeIdentity     = errors.New("age identity file is required to decrypt the file encrypted by age")
+	ErrMissingPGPPrivateKey   = errors.New("PGP private key file is required to decrypt the file encrypted by PGP")
+	ErrMissingRecipients      = errors.New("age recipients or PGP public key file is required to encrypt")
+	ErrMultipleRecipientTypes = errors.New("only one of age recipients and PGP public key file can be specified")
+)
+
+// DecryptionKeys are the private keys to decrypt the secret file.
+type DecryptionKeys struct {
+	AgeIdentityFile   string
+	PGPPrivateKeyFile string
+	PGPPassphrase     string
+}
+
+// EncryptionKeys are the recipients to encrypt the secret file to, and only one type of them can be specified.
+type EncryptionKeys struct {
+	AgeRecipients    []string
+	PGPPublicKeyFile string
+}
+
+// Complete fills the unset keys with the environment variables.
+func (k *DecryptionKeys) Complete() {
+	if k.AgeIdentityFile == "" {
+		k.AgeIdentityFile = os.Getenv(EnvAgeIdentityFile)
+	}
+	if k.PGPPrivateKeyFile == "" {
+		k.PGPPrivateKeyFile = os.Getenv(EnvPGPPrivateKeyFile)
+	}
+	if k.PGPPassphrase == "" {
+		k.PGPPassphrase = os.Getenv(EnvPGPPassphrase)
+	}
+}
+
+// DetectFormat returns the encryption format of the content, FormatAge or FormatPGP.
+func DetectFormat(content []byte) (string, error) {
+	trimmed := bytes.TrimSpace(content)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte(agearmor.Header)), bytes.HasPrefix(trimmed, []byte(ageBinaryMagic)):
+		return FormatAge, nil
+	case bytes.HasPrefix(trimmed, []byte(pgpArmorHeader)):
+		return FormatPGP, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Decrypt decrypts the content encrypted by age or PGP, and returns the plaintext and the encryption format.
+func Decrypt(content []byte, keys DecryptionKeys) ([]byte, string, error) {
+	format, err := DetectFormat(content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var plaintext []byte
+	switch format {
+	case FormatAge:
+		plaintext, err = decryptAge(content, keys.AgeIdentityFile)
+	case FormatPGP:
+		plaintext, err = decryptPGP(content, keys.PGPPrivateKeyFile, keys.PGPPassphrase)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return plaintext, format, nil
+}
+
+// Encrypt encrypts the plaintext to the age recipients with ASCII armor, or to the PGP public keys with ASCII
+// armor, so that the encrypted file can be committed and diffed as a text file.
+func Encrypt(plaintext []byte, keys EncryptionKeys) ([]byte, error) {
+	switch {
+	case len(keys.AgeRecipients) != 0 && keys.PGPPublicKeyFile != "":
+		return nil, ErrMultipleRecipientTypes
+	case len(keys.AgeRecipients) != 0:
+		return encryptAge(plaintext, keys.AgeRecipients)
+	case keys.PGPPublicKeyFile != "":
+		return encryptPGP(plaintext, keys.PGPPublicKeyFile)
+	default:
+		return nil, ErrMissingRecipients
+	}
+}
+
+func decryptAge(content []byte, identityFile string) ([]byte, error) {
+	identities, err := readAgeIdentities(identityFile)
+	if err != nil {
+		return nil, err
+	}
+	var in io.Reader = bytes.NewReader(content)
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte(agearmor.Header)) {
+		in = agearmor.NewReader(bytes.NewReader(bytes.TrimSpace(content)))
+	}
+	r, err := age.Decrypt(in, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("age decrypt failed: %w", err)
+	}
+	return io.ReadAll(r)
+}
+
+func encryptAge(plaintext []byte, recipients []string) ([]byte, error) {
+	var ageRecipients []age.Recipient
+	for _, r := range recipients {
+		recipient, err := age.ParseX25519Recipient(strings.TrimSpace(r))
+		if err != nil {
+			return nil, fmt.Errorf("parse age recipient %s failed: %w", r, err)
+		}
+		ageRecipients = append(ageRecipients, recipient)
+	}
+
+	out := &bytes.Buffer{}
+	armorWriter := agearmor.NewWriter(out)
+	w, err := age.Encrypt(armorWriter, ageRecipients...)
+	if err != nil {
+		return nil, fmt.Errorf("age encrypt failed: %w", err)
+	}
+	if _, err = w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf("age encrypt failed: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return nil, fmt.Errorf("age encrypt failed: %w", err)
+	}
+	if err = armorWriter.Close(); err != nil {
+		return nil, fmt.Errorf("age encrypt failed: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func readAgeIdentities(identityFile string) ([]age.Identity, error) {
+	if identityFile == "" {
+		return nil, ErrMissingAgeIdentity
+	}
+	content, err := os.ReadFile(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("read age identity file %s failed: %w", identityFile, err)
+	}
+	identities, err := age.ParseIdentities(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse age identity file %s failed: %w", identityFile, err)
+	}
+	return identities, nil
+}
+
+func decryptPGP(content []byte, privateKeyFile, passphrase string) ([]byte, error) {
+	if privateKeyFile == "" {
+		return nil, ErrMissingPGPPrivateKey
+	}
+	keyring, err := readPGPKeyRing(privateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase != "" {
+		for _, entity := range keyring {
+			if err = entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf("decrypt PGP private key failed: %w", err)
+			}
+		}
+	}
+
+	block, err := pgparmor.Decode(bytes.NewReader(bytes.TrimSpace(content)))
+	if err != nil {
+		return nil, fmt.Errorf("decode PGP message failed: %w", err)
+	}
+	if block.Type != pgpMessageType {
+		return nil, fmt.Errorf("unexpected PGP block type %s", block.Type)
+	}
+	md, err := openpgp.ReadMessage(block.Body, keyring, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("PGP decrypt failed: %w", err)
+	}
+	return io.ReadAll(md.UnverifiedBody)
+}
+
+func encryptPGP(plaintext []byte, publicKeyFile string) ([]byte, error) {
+	keyring, err := readPGPKeyRing(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+	armorWriter, err := pgparmor.Encode(out, pgpMessageType, nil)
+	if err != nil {
+		return nil, fmt.Errorf("PGP encrypt failed: %w", err)
+	}
+	w, err := openpgp.Encrypt(armorWriter, keyring, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("PGP encrypt failed: %w", err)
+	}
+	if _, err = w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf("PGP encrypt failed: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return nil, fmt.Errorf("PGP encrypt failed: %w", err)
+	}
+	if err = armorWriter.Close(); err != nil {
+		return nil, fmt.Errorf("PGP encrypt failed: %w", err)
+	}
+	// the armor ends without a newline
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+func readPGPKeyRing(keyFile string) (openpgp.EntityList, error) {
+	f, err := os.Open(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read PGP key file %s failed: %w", keyFile, err)
+	}
+	defer f.Close()
+	keyring, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse PGP key file %s failed: %w", keyFile, err)
+	}
+	return keyring, nil
+}
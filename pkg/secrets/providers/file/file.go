@@ -0,0 +1,115 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tidwall/gjson"
+	"gopkg.in/yaml.v3"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/secrets"
+)
+
+// VersionSeparator separates the name and the version of a secret in the secret file, the secret whose key is
+// "name@version" is returned for the ref with the version, and the secret whose key is "name" for the ref
+// without version.
+const VersionSeparator = "@"
+
+const (
+	errInvalidFileSecretStore = "cannot find valid file provider spec"
+	errReadSecretFile         = "read secret file %s failed: %w"
+	errDecryptSecretFile      = "decrypt secret file %s failed: %w"
+)
+
+// DefaultSecretStoreProvider should implement the secrets.SecretStoreProvider interface
+var _ secrets.SecretStoreProvider = &DefaultSecretStoreProvider{}
+
+// fileSecretStore should implement the secrets.SecretStore interface
+var _ secrets.SecretStore = &fileSecretStore{}
+
+type DefaultSecretStoreProvider struct{}
+
+// NewSecretStore constructs a secret store with the secrets decrypted from the encrypted file.
+func (p *DefaultSecretStoreProvider) NewSecretStore(spec v1.SecretStoreSpec) (secrets.SecretStore, error) {
+	providerSpec := spec.Provider
+	if providerSpec == nil || providerSpec.File == nil {
+		return nil, errors.New(errInvalidFileSecretStore)
+	}
+	fileSpec := providerSpec.File
+
+	content, err := os.ReadFile(fileSpec.Path)
+	if err != nil {
+		return nil, fmt.Errorf(errReadSecretFile, fileSpec.Path, err)
+	}
+	keys := DecryptionKeys{
+		AgeIdentityFile:   fileSpec.AgeIdentityFile,
+		PGPPrivateKeyFile: fileSpec.PGPPrivateKeyFile,
+	}
+	keys.Complete()
+	plaintext, _, err := Decrypt(content, keys)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptSecretFile, fileSpec.Path, err)
+	}
+	data, err := ParseData(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptSecretFile, fileSpec.Path, err)
+	}
+
+	return &fileSecretStore{data: data}, nil
+}
+
+// ParseData parses the decrypted secret file, which is a YAML or JSON object keyed by the secret names.
+func ParseData(plaintext []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	if err := yaml.Unmarshal(plaintext, &data); err != nil {
+		return nil, fmt.Errorf("invalid secret file, must be a YAML or JSON object: %w", err)
+	}
+	return data, nil
+}
+
+type fileSecretStore struct {
+	data map[string]any
+}
+
+// GetSecret retrieves the secret of the ref name and version, whose value is either a string or an object. If
+// the property is specified, it is used as the gjson path to select a field of the value.
+func (f *fileSecretStore) GetSecret(_ context.Context, ref v1.ExternalSecretRef) ([]byte, error) {
+	key := ref.Name
+	if ref.Version != "" {
+		key += VersionSeparator + ref.Version
+	}
+	value, ok := f.data[key]
+	if !ok || value == nil {
+		return nil, secrets.NoSecretErr
+	}
+
+	var content string
+	if s, isString := value.(string); isString {
+		content = s
+	} else {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("json marshal secret %s failed: %w", key, err)
+		}
+		content = string(b)
+	}
+
+	if ref.Property != "" {
+		val := gjson.Get(content, ref.Property)
+		if !val.Exists() {
+			return nil, secrets.NoSecretErr
+		}
+		return []byte(val.String()), nil
+	}
+	return []byte(content), nil
+}
+
+func init() {
+	secrets.Register(&DefaultSecretStoreProvider{}, &v1.ProviderSpec{
+		File: &v1.FileProvider{},
+	})
+}
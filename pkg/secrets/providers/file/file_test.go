@@ -0,0 +1,184 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/secrets"
+)
+
+const plaintext = `db-password: s3cr3t
+api-auth:
+  accessKey: some sensitive info
+  secretKey: "*******"
+api-auth@v1:
+  accessKey: old sensitive info
+`
+
+// mockAgeIdentity writes a new age identity file, and returns the file path and the recipient.
+func mockAgeIdentity(t *testing.T) (string, string) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "age.key")
+	require.NoError(t, os.WriteFile(path, []byte(identity.String()+"\n"), 0o600))
+	return path, identity.Recipient().String()
+}
+
+// mockPGPKey writes a new PGP private key and public key, and returns the file paths.
+func mockPGPKey(t *testing.T, passphrase string) (string, string) {
+	entity, err := openpgp.NewEntity("kusion", "test", "kusion@example.com", nil)
+	require.NoError(t, err)
+
+	publicKey := &bytes.Buffer{}
+	w, err := pgparmor.Encode(publicKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	if passphrase != "" {
+		require.NoError(t, entity.EncryptPrivateKeys([]byte(passphrase), nil))
+	}
+	privateKey := &bytes.Buffer{}
+	w, err = pgparmor.Encode(privateKey, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivateWithoutSigning(w, nil))
+	require.NoError(t, w.Close())
+
+	dir := t.TempDir()
+	privateKeyFile := filepath.Join(dir, "private.asc")
+	publicKeyFile := filepath.Join(dir, "public.asc")
+	require.NoError(t, os.WriteFile(privateKeyFile, privateKey.Bytes(), 0o600))
+	require.NoError(t, os.WriteFile(publicKeyFile, publicKey.Bytes(), 0o600))
+	return privateKeyFile, publicKeyFile
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	identityFile, recipient := mockAgeIdentity(t)
+	privateKeyFile, publicKeyFile := mockPGPKey(t, "passphrase")
+
+	t.Run("age", func(t *testing.T) {
+		ciphertext, err := Encrypt([]byte(plaintext), EncryptionKeys{AgeRecipients: []string{recipient}})
+		require.NoError(t, err)
+		format, err := DetectFormat(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, FormatAge, format)
+
+		got, format, err := Decrypt(ciphertext, DecryptionKeys{AgeIdentityFile: identityFile})
+		require.NoError(t, err)
+		assert.Equal(t, FormatAge, format)
+		assert.Equal(t, plaintext, string(got))
+
+		_, _, err = Decrypt(ciphertext, DecryptionKeys{})
+		assert.ErrorIs(t, err, ErrMissingAgeIdentity)
+	})
+
+	t.Run("pgp", func(t *testing.T) {
+		ciphertext, err := Encrypt([]byte(plaintext), EncryptionKeys{PGPPublicKeyFile: publicKeyFile})
+		require.NoError(t, err)
+
+		got, format, err := Decrypt(ciphertext, DecryptionKeys{PGPPrivateKeyFile: privateKeyFile, PGPPassphrase: "passphrase"})
+		require.NoError(t, err)
+		assert.Equal(t, FormatPGP, format)
+		assert.Equal(t, plaintext, string(got))
+
+		_, _, err = Decrypt(ciphertext, DecryptionKeys{PGPPrivateKeyFile: privateKeyFile, PGPPassphrase: "wrong"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, _, err := Decrypt([]byte(plaintext), DecryptionKeys{})
+		assert.ErrorIs(t, err, ErrUnknownFormat)
+		_, err = Encrypt([]byte(plaintext), EncryptionKeys{})
+		assert.ErrorIs(t, err, ErrMissingRecipients)
+		_, err = Encrypt([]byte(plaintext), EncryptionKeys{AgeRecipients: []string{recipient}, PGPPublicKeyFile: publicKeyFile})
+		assert.ErrorIs(t, err, ErrMultipleRecipientTypes)
+	})
+}
+
+func TestGetSecret(t *testing.T) {
+	identityFile, recipient := mockAgeIdentity(t)
+	ciphertext, err := Encrypt([]byte(plaintext), EncryptionKeys{AgeRecipients: []string{recipient}})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "secrets.enc.yaml")
+	require.NoError(t, os.WriteFile(path, ciphertext, 0o600))
+
+	t.Setenv(EnvAgeIdentityFile, identityFile)
+	provider, ok := secrets.GetProvider(&v1.ProviderSpec{File: &v1.FileProvider{}})
+	require.True(t, ok)
+	store, err := provider.NewSecretStore(v1.SecretStoreSpec{
+		Provider: &v1.ProviderSpec{
+			File: &v1.FileProvider{Path: path},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		ref      v1.ExternalSecretRef
+		expValue string
+		expErr   error
+	}{
+		{
+			name:     "string value",
+			ref:      v1.ExternalSecretRef{Name: "db-password"},
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "property of object value",
+			ref:      v1.ExternalSecretRef{Name: "api-auth", Property: "accessKey"},
+			expValue: "some sensitive info",
+		},
+		{
+			name:     "object value",
+			ref:      v1.ExternalSecretRef{Name: "api-auth@v1"},
+			expValue: `{"accessKey":"old sensitive info"}`,
+		},
+		{
+			name:     "versioned value",
+			ref:      v1.ExternalSecretRef{Name: "api-auth", Property: "accessKey", Version: "v1"},
+			expValue: "old sensitive info",
+		},
+		{
+			name:   "version not found",
+			ref:    v1.ExternalSecretRef{Name: "api-auth", Version: "v2"},
+			expErr: secrets.NoSecretErr,
+		},
+		{
+			name:   "property not found",
+			ref:    v1.ExternalSecretRef{Name: "api-auth", Property: "token"},
+			expErr: secrets.NoSecretErr,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := store.GetSecret(context.Background(), tc.ref)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expValue, string(got))
+		})
+	}
+}
+
+func TestNewSecretStore_Invalid(t *testing.T) {
+	p := &DefaultSecretStoreProvider{}
+	_, err := p.NewSecretStore(v1.SecretStoreSpec{Provider: &v1.ProviderSpec{}})
+	assert.EqualError(t, err, errInvalidFileSecretStore)
+
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(plaintext), 0o600))
+	_, err = p.NewSecretStore(v1.SecretStoreSpec{Provider: &v1.ProviderSpec{File: &v1.FileProvider{Path: path}}})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
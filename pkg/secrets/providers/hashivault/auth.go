@@ -0,0 +1,186 @@
+package hashivault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+
+	"kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+const (
+	defaultAppRoleMountPath               = "approle"
+	defaultKubernetesMountPath            = "kubernetes"
+	defaultJWTMountPath                   = "jwt"
+	defaultKubernetesServiceAccountTokens = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	envVaultRoleID   = "VAULT_ROLE_ID"
+	envVaultSecretID = "VAULT_SECRET_ID"
+	envVaultJWT      = "VAULT_JWT"
+
+	errReadAuthFile    = "failed to read %s: %w"
+	errEmptyAuthValue  = "empty %s for the %s auth method of Vault"
+	errLoginVault      = "failed to login Vault with the %s auth method: %w"
+	errNoAuthInfoLogin = "no auth info returned by login"
+)
+
+// loginAuthMethod implements the vault.AuthMethod interface by writing the login data to the path
+// "auth/<mountPath>/login", which is shared by the AppRole, Kubernetes and JWT auth methods.
+type loginAuthMethod struct {
+	name      string
+	mountPath string
+	data      func() (map[string]interface{}, error)
+}
+
+func (m *loginAuthMethod) Login(ctx context.Context, client *vault.Client) (*vault.Secret, error) {
+	data, err := m.data()
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("auth/%s/login", strings.Trim(m.mountPath, "/"))
+	secret, err := client.Logical().WriteWithContext(ctx, path, data)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, errors.New(errNoAuthInfoLogin)
+	}
+	return secret, nil
+}
+
+// newAuthMethod returns the vault.AuthMethod of the auth spec, and nil if the auth spec doesn't need to login,
+// e.g. the token auth method.
+func newAuthMethod(auth *v1.VaultAuth) vault.AuthMethod {
+	switch {
+	case auth == nil || auth.Token != nil:
+		return nil
+	case auth.AppRole != nil:
+		appRole := auth.AppRole
+		return &loginAuthMethod{
+			name:      "AppRole",
+			mountPath: defaultIfEmpty(appRole.MountPath, defaultAppRoleMountPath),
+			data: func() (map[string]interface{}, error) {
+				roleID := defaultIfEmpty(appRole.RoleID, os.Getenv(envVaultRoleID))
+				if roleID == "" {
+					return nil, fmt.Errorf(errEmptyAuthValue, "role id", "AppRole")
+				}
+				secretID, err := readValueFromFileOrEnv(appRole.SecretIDFile, envVaultSecretID)
+				if err != nil {
+					return nil, err
+				}
+				if secretID == "" {
+					return nil, fmt.Errorf(errEmptyAuthValue, "secret id", "AppRole")
+				}
+				return map[string]interface{}{"role_id": roleID, "secret_id": secretID}, nil
+			},
+		}
+	case auth.Kubernetes != nil:
+		kubernetes := auth.Kubernetes
+		return &loginAuthMethod{
+			name:      "Kubernetes",
+			mountPath: defaultIfEmpty(kubernetes.MountPath, defaultKubernetesMountPath),
+			data: func() (map[string]interface{}, error) {
+				jwt, err := readValueFromFileOrEnv(
+					defaultIfEmpty(kubernetes.ServiceAccountTokenFile, defaultKubernetesServiceAccountTokens), "")
+				if err != nil {
+					return nil, err
+				}
+				if jwt == "" {
+					return nil, fmt.Errorf(errEmptyAuthValue, "jwt", "Kubernetes")
+				}
+				return map[string]interface{}{"role": kubernetes.Role, "jwt": jwt}, nil
+			},
+		}
+	case auth.JWT != nil:
+		jwtAuth := auth.JWT
+		return &loginAuthMethod{
+			name:      "JWT",
+			mountPath: defaultIfEmpty(jwtAuth.MountPath, defaultJWTMountPath),
+			data: func() (map[string]interface{}, error) {
+				jwt, err := readValueFromFileOrEnv(jwtAuth.TokenFile, envVaultJWT)
+				if err != nil {
+					return nil, err
+				}
+				if jwt == "" {
+					return nil, fmt.Errorf(errEmptyAuthValue, "jwt", "JWT")
+				}
+				data := map[string]interface{}{"jwt": jwt}
+				if jwtAuth.Role != "" {
+					data["role"] = jwtAuth.Role
+				}
+				return data, nil
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// authenticator logs in Vault with the auth method and sets the obtained token into the client. Rather than
+// renewing the token in the background, it logs in again once the token is about to expire, for the secrets are
+// only read during a short run of the CLI.
+type authenticator struct {
+	client *vault.Client
+	method vault.AuthMethod
+
+	mu       sync.Mutex
+	loggedIn bool
+	// expireAt is the time to login again, which is zero if the token never expires
+	expireAt time.Time
+}
+
+// login logs in Vault if not logged in yet or the token is about to expire.
+func (a *authenticator) login(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.loggedIn && (a.expireAt.IsZero() || time.Now().Before(a.expireAt)) {
+		return nil
+	}
+
+	secret, err := a.client.Auth().Login(ctx, a.method)
+	if err != nil {
+		return fmt.Errorf(errLoginVault, authMethodName(a.method), err)
+	}
+	a.loggedIn, a.expireAt = true, time.Time{}
+	if ttl, err := secret.TokenTTL(); err == nil && ttl > 0 {
+		// login again before the token expires to leave time for the requests
+		a.expireAt = time.Now().Add(ttl * 4 / 5)
+	}
+	return nil
+}
+
+func authMethodName(method vault.AuthMethod) string {
+	if m, ok := method.(*loginAuthMethod); ok {
+		return m.name
+	}
+	return "unknown"
+}
+
+// readValueFromFileOrEnv reads the trimmed content of the file if the path is specified, otherwise the value of
+// the environment variable.
+func readValueFromFileOrEnv(path, env string) (string, error) {
+	if path == "" {
+		if env == "" {
+			return "", nil
+		}
+		return os.Getenv(env), nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf(errReadAuthFile, path, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func defaultIfEmpty(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,240 @@
+package hashivault
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+const (
+	testNamespace = "ns1"
+	testToken     = "s.test-token"
+)
+
+// mockVaultServer starts a TLS server standing in for Vault, which accepts the login requests of the auth
+// methods, serves the KV v2 secret "secret/data/app" for the test token, and counts the logins.
+func mockVaultServer(t *testing.T) (*httptest.Server, string, *int32) {
+	logins := new(int32)
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, body map[string]interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	loginHandler := func(expected map[string]interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !assert.ObjectsAreEqual(expected, body) {
+				w.WriteHeader(http.StatusBadRequest)
+				writeJSON(w, map[string]interface{}{"errors": []string{"invalid login data"}})
+				return
+			}
+			atomic.AddInt32(logins, 1)
+			writeJSON(w, map[string]interface{}{
+				"auth": map[string]interface{}{
+					"client_token":   testToken,
+					"lease_duration": 1,
+				},
+			})
+		}
+	}
+	mux.HandleFunc("/v1/auth/approle/login", loginHandler(map[string]interface{}{
+		"role_id":   "role-id",
+		"secret_id": "secret-id",
+	}))
+	mux.HandleFunc("/v1/auth/k8s/login", loginHandler(map[string]interface{}{
+		"role": "kusion",
+		"jwt":  "sa-token",
+	}))
+	mux.HandleFunc("/v1/auth/jwt/login", loginHandler(map[string]interface{}{
+		"role": "ci",
+		"jwt":  "oidc-token",
+	}))
+	mux.HandleFunc("/v1/secret/data/app", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != testToken || r.Header.Get("X-Vault-Namespace") != testNamespace {
+			w.WriteHeader(http.StatusForbidden)
+			writeJSON(w, map[string]interface{}{"errors": []string{"permission denied"}})
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{"password": "t0p-Secret"},
+			},
+		})
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	caCert := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caCert,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	return server, caCert, logins
+}
+
+func writeTempFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte(content+"\n"), 0o600))
+	return path
+}
+
+func TestNewSecretStore_Auth(t *testing.T) {
+	server, caCert, _ := mockVaultServer(t)
+	t.Setenv("VAULT_TOKEN", "")
+	t.Setenv("VAULT_SERVER_TOKEN", "")
+
+	testCases := []struct {
+		name      string
+		env       map[string]string
+		auth      *v1.VaultAuth
+		expectErr bool
+	}{
+		{
+			name: "token file",
+			auth: &v1.VaultAuth{Token: &v1.VaultTokenAuth{TokenFile: writeTempFile(t, testToken)}},
+		},
+		{
+			name: "token env",
+			env:  map[string]string{"VAULT_SERVER_TOKEN": testToken},
+		},
+		{
+			name: "AppRole",
+			env:  map[string]string{envVaultRoleID: "role-id"},
+			auth: &v1.VaultAuth{AppRole: &v1.VaultAppRoleAuth{SecretIDFile: writeTempFile(t, "secret-id")}},
+		},
+		{
+			name:      "AppRole with invalid secret id",
+			env:       map[string]string{envVaultSecretID: "invalid"},
+			auth:      &v1.VaultAuth{AppRole: &v1.VaultAppRoleAuth{RoleID: "role-id"}},
+			expectErr: true,
+		},
+		{
+			name:      "AppRole without secret id",
+			auth:      &v1.VaultAuth{AppRole: &v1.VaultAppRoleAuth{RoleID: "role-id"}},
+			expectErr: true,
+		},
+		{
+			name: "Kubernetes",
+			auth: &v1.VaultAuth{Kubernetes: &v1.VaultKubernetesAuth{
+				MountPath:               "k8s",
+				Role:                    "kusion",
+				ServiceAccountTokenFile: writeTempFile(t, "sa-token"),
+			}},
+		},
+		{
+			name: "Kubernetes without service account token",
+			auth: &v1.VaultAuth{Kubernetes: &v1.VaultKubernetesAuth{
+				MountPath:               "k8s",
+				Role:                    "kusion",
+				ServiceAccountTokenFile: filepath.Join(t.TempDir(), "not-exist"),
+			}},
+			expectErr: true,
+		},
+		{
+			name: "Kubernetes with empty service account token",
+			auth: &v1.VaultAuth{Kubernetes: &v1.VaultKubernetesAuth{
+				MountPath:               "k8s",
+				Role:                    "kusion",
+				ServiceAccountTokenFile: writeTempFile(t, ""),
+			}},
+			expectErr: true,
+		},
+		{
+			name: "JWT",
+			env:  map[string]string{envVaultJWT: "oidc-token"},
+			auth: &v1.VaultAuth{JWT: &v1.VaultJWTAuth{Role: "ci"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			store, err := (&DefaultSecretStoreProvider{}).NewSecretStore(v1.SecretStoreSpec{
+				Provider: &v1.ProviderSpec{
+					Vault: &v1.VaultProvider{
+						Server:    server.URL,
+						Version:   v1.VaultKVStoreV2,
+						Namespace: testNamespace,
+						Auth:      tc.auth,
+						TLS:       &v1.VaultTLSConfig{CACert: caCert},
+					},
+				},
+			})
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			value, err := store.GetSecret(context.Background(), v1.ExternalSecretRef{Name: "secret/app", Property: "password"})
+			require.NoError(t, err)
+			assert.Equal(t, "t0p-Secret", string(value))
+		})
+	}
+}
+
+func TestNewSecretStore_TLS(t *testing.T) {
+	server, _, _ := mockVaultServer(t)
+	t.Setenv("VAULT_SERVER_TOKEN", testToken)
+	spec := v1.SecretStoreSpec{
+		Provider: &v1.ProviderSpec{
+			Vault: &v1.VaultProvider{
+				Server:    server.URL,
+				Version:   v1.VaultKVStoreV2,
+				Namespace: testNamespace,
+			},
+		},
+	}
+	ref := v1.ExternalSecretRef{Name: "secret/app", Property: "password"}
+
+	// the certificate of the server is not trusted without the CA certificate
+	store, err := (&DefaultSecretStoreProvider{}).NewSecretStore(spec)
+	require.NoError(t, err)
+	_, err = store.GetSecret(context.Background(), ref)
+	assert.Error(t, err)
+
+	spec.Provider.Vault.TLS = &v1.VaultTLSConfig{Insecure: true}
+	store, err = (&DefaultSecretStoreProvider{}).NewSecretStore(spec)
+	require.NoError(t, err)
+	_, err = store.GetSecret(context.Background(), ref)
+	assert.NoError(t, err)
+
+	spec.Provider.Vault.TLS = &v1.VaultTLSConfig{CACert: filepath.Join(t.TempDir(), "not-exist")}
+	_, err = (&DefaultSecretStoreProvider{}).NewSecretStore(spec)
+	assert.Error(t, err)
+}
+
+func TestAuthenticator_Login(t *testing.T) {
+	server, caCert, logins := mockVaultServer(t)
+	t.Setenv(envVaultRoleID, "role-id")
+	t.Setenv(envVaultSecretID, "secret-id")
+
+	_, auth, err := getVaultClient(&v1.VaultProvider{
+		Server: server.URL,
+		Auth:   &v1.VaultAuth{AppRole: &v1.VaultAppRoleAuth{}},
+		TLS:    &v1.VaultTLSConfig{CACert: caCert},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, auth)
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+
+	// the token is reused before it is about to expire
+	require.NoError(t, auth.login(context.Background()))
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+
+	// login again once the token is about to expire
+	time.Sleep(time.Second)
+	require.NoError(t, auth.login(context.Background()))
+	assert.Equal(t, int32(2), atomic.LoadInt32(logins))
+}
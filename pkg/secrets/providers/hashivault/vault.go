@@ -26,6 +26,7 @@ const (
 	errDataPropertyFormat      = "unexpected data format %s for property field: %s"
 	errSecretFormat            = "cannot find property %s in secret data"
 	errBuildVaultClient        = "failed to new Vault client: %w"
+	errConfigureVaultTLS       = "failed to configure TLS of Vault client: %w"
 )
 
 // DefaultSecretStoreProvider should implement the secrets.SecretStoreProvider interface
@@ -44,7 +45,7 @@ func (p *DefaultSecretStoreProvider) NewSecretStore(spec v1.SecretStoreSpec) (se
 	}
 
 	vaultSpec := providerSpec.Vault
-	client, err := getVaultClient(vaultSpec.Server)
+	client, auth, err := getVaultClient(vaultSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -52,22 +53,61 @@ func (p *DefaultSecretStoreProvider) NewSecretStore(spec v1.SecretStoreSpec) (se
 	store := vaultSecretStore{
 		provider: vaultSpec,
 		logical:  client.Logical(),
+		auth:     auth,
 	}
 	return &store, nil
 }
 
-func getVaultClient(server string) (*vault.Client, error) {
+// getVaultClient returns the client with the token set, and the authenticator to login again if the auth method
+// is specified.
+func getVaultClient(vaultSpec *v1.VaultProvider) (*vault.Client, *authenticator, error) {
 	cfg := vault.DefaultConfig()
-	cfg.Address = server
+	cfg.Address = vaultSpec.Server
+	if tlsSpec := vaultSpec.TLS; tlsSpec != nil {
+		err := cfg.ConfigureTLS(&vault.TLSConfig{
+			CACert:        tlsSpec.CACert,
+			ClientCert:    tlsSpec.ClientCert,
+			ClientKey:     tlsSpec.ClientKey,
+			TLSServerName: tlsSpec.ServerName,
+			Insecure:      tlsSpec.Insecure,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf(errConfigureVaultTLS, err)
+		}
+	}
 	c, err := vault.NewClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf(errBuildVaultClient, err)
+		return nil, nil, fmt.Errorf(errBuildVaultClient, err)
+	}
+	if vaultSpec.Namespace != "" {
+		c.SetNamespace(vaultSpec.Namespace)
+	}
+
+	// login with the auth method if specified, otherwise use the static token
+	if method := newAuthMethod(vaultSpec.Auth); method != nil {
+		auth := &authenticator{client: c, method: method}
+		if err = auth.login(context.Background()); err != nil {
+			return nil, nil, err
+		}
+		return c, auth, nil
+	}
+	token, err := getStaticToken(vaultSpec.Auth)
+	if err != nil {
+		return nil, nil, err
 	}
-	token := getVaultToken()
 	if token != "" {
 		c.SetToken(token)
 	}
-	return c, nil
+	return c, nil, nil
+}
+
+// getStaticToken returns the token read from the token file of the token auth method if specified, otherwise
+// from the environment variables.
+func getStaticToken(auth *v1.VaultAuth) (string, error) {
+	if auth != nil && auth.Token != nil && auth.Token.TokenFile != "" {
+		return readValueFromFileOrEnv(auth.Token.TokenFile, "")
+	}
+	return getVaultToken(), nil
 }
 
 // getVaultToken ensures that we check both VAULT_SERVER_TOKEN and VAULT_TOKEN environment
@@ -90,10 +130,17 @@ func getVaultToken() string {
 type vaultSecretStore struct {
 	provider *v1.VaultProvider
 	logical  Logical
+	// auth logs in again once the token is about to expire, which is nil for the static token
+	auth *authenticator
 }
 
 // GetSecret retrieves ref secret value from Vault server.
 func (v *vaultSecretStore) GetSecret(ctx context.Context, ref v1.ExternalSecretRef) ([]byte, error) {
+	if v.auth != nil {
+		if err := v.auth.login(ctx); err != nil {
+			return nil, err
+		}
+	}
 	secretData, err := v.readSecret(ctx, ref.Name, ref.Version)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,105 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/secrets"
+)
+
+const (
+	errInvalidKubernetesSecretStore = "cannot find valid Kubernetes provider spec"
+	errBuildKubernetesClient        = "failed to new Kubernetes client: %w"
+	errGetSecret                    = "failed to get Secret %s/%s: %w"
+	errVersionNotSupported          = "version is not supported by the Kubernetes secret store"
+)
+
+// DefaultSecretStoreProvider should implement the secrets.SecretStoreProvider interface
+var _ secrets.SecretStoreProvider = &DefaultSecretStoreProvider{}
+
+// kubernetesSecretStore should implement the secrets.SecretStore interface
+var _ secrets.SecretStore = &kubernetesSecretStore{}
+
+type DefaultSecretStoreProvider struct{}
+
+// NewSecretStore constructs a secret store reading the existing Secrets in the namespace of the cluster.
+func (p *DefaultSecretStoreProvider) NewSecretStore(spec v1.SecretStoreSpec) (secrets.SecretStore, error) {
+	providerSpec := spec.Provider
+	if providerSpec == nil || providerSpec.Kubernetes == nil {
+		return nil, errors.New(errInvalidKubernetesSecretStore)
+	}
+
+	kubernetesSpec := providerSpec.Kubernetes
+	client, err := getKubernetesClient(kubernetesSpec.KubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetesSecretStore{
+		namespace: kubernetesSpec.Namespace,
+		client:    client,
+	}, nil
+}
+
+// getKubernetesClient builds the client by the kubeconfig file. If the kubeconfig is not specified, the default
+// loading rules are used, i.e. the KUBECONFIG environment variable, ~/.kube/config and the in-cluster config.
+func getKubernetesClient(kubeConfig string) (clientset.Interface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeConfig
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf(errBuildKubernetesClient, err)
+	}
+	client, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf(errBuildKubernetesClient, err)
+	}
+	return client, nil
+}
+
+type kubernetesSecretStore struct {
+	namespace string
+	client    clientset.Interface
+}
+
+// GetSecret retrieves the data of the Secret named by the ref name. If the property is specified, the value of the
+// data key is returned, otherwise the whole data is returned as a JSON object.
+func (k *kubernetesSecretStore) GetSecret(ctx context.Context, ref v1.ExternalSecretRef) ([]byte, error) {
+	if ref.Version != "" {
+		return nil, errors.New(errVersionNotSupported)
+	}
+	secret, err := k.client.CoreV1().Secrets(k.namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, secrets.NoSecretErr
+		}
+		return nil, fmt.Errorf(errGetSecret, k.namespace, ref.Name, err)
+	}
+
+	if ref.Property != "" {
+		value, ok := secret.Data[ref.Property]
+		if !ok {
+			return nil, secrets.NoSecretErr
+		}
+		return value, nil
+	}
+	data := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		data[key] = string(value)
+	}
+	return json.Marshal(data)
+}
+
+func init() {
+	secrets.Register(&DefaultSecretStoreProvider{}, &v1.ProviderSpec{
+		Kubernetes: &v1.KubernetesProvider{},
+	})
+}
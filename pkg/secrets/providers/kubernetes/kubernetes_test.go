@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/secrets"
+)
+
+func mockClientset() clientset.Interface {
+	return fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shared-secrets"},
+			Data: map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte("t0p-Secret"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Data: map[string][]byte{
+				"token": []byte("api-token"),
+			},
+		},
+	)
+}
+
+func TestGetSecret(t *testing.T) {
+	store := &kubernetesSecretStore{
+		namespace: "shared-secrets",
+		client:    mockClientset(),
+	}
+
+	testCases := []struct {
+		name     string
+		ref      v1.ExternalSecretRef
+		expValue string
+		expErr   error
+	}{
+		{
+			name:     "data key",
+			ref:      v1.ExternalSecretRef{Name: "db", Property: "password"},
+			expValue: "t0p-Secret",
+		},
+		{
+			name:     "whole data",
+			ref:      v1.ExternalSecretRef{Name: "db"},
+			expValue: `{"password":"t0p-Secret","username":"admin"}`,
+		},
+		{
+			name:   "data key not found",
+			ref:    v1.ExternalSecretRef{Name: "db", Property: "token"},
+			expErr: secrets.NoSecretErr,
+		},
+		{
+			name:   "secret in other namespace",
+			ref:    v1.ExternalSecretRef{Name: "api", Property: "token"},
+			expErr: secrets.NoSecretErr,
+		},
+		{
+			name:   "version not supported",
+			ref:    v1.ExternalSecretRef{Name: "db", Property: "password", Version: "1"},
+			expErr: errors.New(errVersionNotSupported),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := store.GetSecret(context.Background(), tc.ref)
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expValue, string(got))
+		})
+	}
+}
+
+func TestNewSecretStore(t *testing.T) {
+	mockey.PatchConvey("new secret store", t, func() {
+		mockey.Mock(getKubernetesClient).Return(mockClientset(), nil).Build()
+
+		provider, ok := secrets.GetProvider(&v1.ProviderSpec{Kubernetes: &v1.KubernetesProvider{}})
+		require.True(t, ok)
+
+		_, err := provider.NewSecretStore(v1.SecretStoreSpec{Provider: &v1.ProviderSpec{}})
+		assert.EqualError(t, err, errInvalidKubernetesSecretStore)
+
+		store, err := provider.NewSecretStore(v1.SecretStoreSpec{
+			Provider: &v1.ProviderSpec{
+				Kubernetes: &v1.KubernetesProvider{Namespace: "shared-secrets"},
+			},
+		})
+		require.NoError(t, err)
+		got, err := store.GetSecret(context.Background(), v1.ExternalSecretRef{Name: "db", Property: "username"})
+		require.NoError(t, err)
+		assert.Equal(t, "admin", string(got))
+	})
+}
+
+func TestGetKubernetesClient(t *testing.T) {
+	_, err := getKubernetesClient("not-exist-kubeconfig")
+	assert.Error(t, err)
+}
@@ -7,5 +7,7 @@ import (
 	_ "kusionstack.io/kusion/pkg/secrets/providers/aws/secretsmanager"
 	_ "kusionstack.io/kusion/pkg/secrets/providers/azure/keyvault"
 	_ "kusionstack.io/kusion/pkg/secrets/providers/fake"
+	_ "kusionstack.io/kusion/pkg/secrets/providers/file"
 	_ "kusionstack.io/kusion/pkg/secrets/providers/hashivault"
+	_ "kusionstack.io/kusion/pkg/secrets/providers/kubernetes"
 )
@@ -0,0 +1,212 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+var (
+	ErrEmptyExtendedWorkspaceName = errors.New("empty extended workspace name")
+	ErrRepeatedExtendedWorkspace  = errors.New("repeated extended workspace")
+	ErrWorkspaceExtendsCycle      = errors.New("cycle found in workspace extends")
+)
+
+// fieldExtends and fieldBackends are the yaml field names of the workspace which are handled specially when
+// merging, the former is not inherited, and the latter is inherited as a whole.
+const (
+	fieldExtends  = "extends"
+	fieldBackends = "backends"
+)
+
+// pathSeparator joins the segments of the path of a workspace value, which cannot appear in the field names
+// and map keys.
+const pathSeparator = "\x00"
+
+// EffectiveWorkspace is the workspace resolved from the workspace and the workspaces it extends, which also
+// records the origin of each value.
+type EffectiveWorkspace struct {
+	*v1.Workspace
+
+	// origins records the name of the workspace where each value comes from, whose key is the path of the
+	// value joined by pathSeparator.
+	origins map[string]string
+}
+
+// Origin returns the name of the workspace where the value of the path comes from, where the path is composed
+// of the yaml field names and map keys, such as ["modules", "database", "default", "type"]. If the path is a
+// map merged from multiple workspaces, the origin of its nearest ancestor which is not merged is returned, and
+// empty if none.
+func (e *EffectiveWorkspace) Origin(path ...string) string {
+	for i := len(path); i > 0; i-- {
+		if origin, ok := e.origins[strings.Join(path[:i], pathSeparator)]; ok {
+			return origin
+		}
+	}
+	return ""
+}
+
+// MarshalYAMLWithOrigins returns the yaml content of the effective workspace, where the origin of each value is
+// shown as line comment.
+func (e *EffectiveWorkspace) MarshalYAMLWithOrigins() ([]byte, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(e.Workspace); err != nil {
+		return nil, err
+	}
+	e.annotateOrigins(node, nil)
+	return yaml.Marshal(node)
+}
+
+// annotateOrigins sets the origin of the values as line comments of the mapping keys.
+func (e *EffectiveWorkspace) annotateOrigins(node *yaml.Node, path []string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		valuePath := append(append([]string{}, path...), key.Value)
+		if value.Kind == yaml.MappingNode && len(value.Content) != 0 {
+			if _, ok := e.origins[strings.Join(valuePath, pathSeparator)]; !ok {
+				e.annotateOrigins(value, valuePath)
+				continue
+			}
+		}
+		if origin := e.Origin(valuePath...); origin != "" {
+			key.LineComment = "from " + origin
+		}
+	}
+}
+
+// ResolveWorkspace resolves the effective workspace of ws, where getWorkspace is used to get the stored workspace
+// by name. The extended workspaces are resolved recursively and deep merged in order, then the configs of ws
+// override them: maps are merged key by key, while other values, including lists such as projectSelector, and the
+// deprecated backends are replaced as a whole. ErrWorkspaceExtendsCycle is returned if the extends form a cycle.
+func ResolveWorkspace(ws *v1.Workspace, getWorkspace func(name string) (*v1.Workspace, error)) (*EffectiveWorkspace, error) {
+	if ws == nil {
+		return nil, ErrEmptyWorkspace
+	}
+	merged, origins, err := resolveWorkspace(ws, getWorkspace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("yaml marshal effective workspace failed: %w", err)
+	}
+	effective := &v1.Workspace{}
+	if err = yaml.Unmarshal(content, effective); err != nil {
+		return nil, fmt.Errorf("yaml unmarshal effective workspace failed: %w", err)
+	}
+	effective.Name = ws.Name
+	return &EffectiveWorkspace{Workspace: effective, origins: origins}, nil
+}
+
+func resolveWorkspace(ws *v1.Workspace, getWorkspace func(name string) (*v1.Workspace, error), chain []string) (map[string]any, map[string]string, error) {
+	for i, name := range chain {
+		if name == ws.Name {
+			cycle := append(append([]string{}, chain[i:]...), ws.Name)
+			return nil, nil, fmt.Errorf("%w: %s", ErrWorkspaceExtendsCycle, strings.Join(cycle, " -> "))
+		}
+	}
+	chain = append(chain, ws.Name)
+
+	merged := make(map[string]any)
+	origins := make(map[string]string)
+	for _, name := range ws.Extends {
+		extended, err := getWorkspace(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get workspace %s extended by %s failed: %w", name, ws.Name, err)
+		}
+		extended.Name = name
+		extendedMap, extendedOrigins, err := resolveWorkspace(extended, getWorkspace, chain)
+		if err != nil {
+			return nil, nil, err
+		}
+		mergeValues(merged, extendedMap, origins, extendedOrigins, nil)
+	}
+
+	own, err := toGenericMap(ws)
+	if err != nil {
+		return nil, nil, err
+	}
+	delete(own, fieldExtends)
+	ownOrigins := make(map[string]string)
+	recordOrigins(own, ws.Name, ownOrigins, nil)
+	mergeValues(merged, own, origins, ownOrigins, nil)
+	return merged, origins, nil
+}
+
+// mergeValues merges src into dst, and updates the origins of dst by the origins of src.
+func mergeValues(dst, src map[string]any, dstOrigins, srcOrigins map[string]string, path []string) {
+	for k, srcValue := range src {
+		valuePath := append(append([]string{}, path...), k)
+		srcMap, srcIsMap := srcValue.(map[string]any)
+		dstMap, dstIsMap := dst[k].(map[string]any)
+		if srcIsMap && dstIsMap && !(len(path) == 0 && k == fieldBackends) {
+			// the merged map has no single origin any more
+			delete(dstOrigins, strings.Join(valuePath, pathSeparator))
+			mergeValues(dstMap, srcMap, dstOrigins, srcOrigins, valuePath)
+			continue
+		}
+
+		dst[k] = srcValue
+		prefix := strings.Join(valuePath, pathSeparator)
+		for key := range dstOrigins {
+			if key == prefix || strings.HasPrefix(key, prefix+pathSeparator) {
+				delete(dstOrigins, key)
+			}
+		}
+		for key, origin := range srcOrigins {
+			if key == prefix || strings.HasPrefix(key, prefix+pathSeparator) {
+				dstOrigins[key] = origin
+			}
+		}
+	}
+}
+
+// recordOrigins records the origin of all the values of m.
+func recordOrigins(m map[string]any, origin string, origins map[string]string, path []string) {
+	for k, v := range m {
+		valuePath := append(append([]string{}, path...), k)
+		origins[strings.Join(valuePath, pathSeparator)] = origin
+		if next, ok := v.(map[string]any); ok {
+			recordOrigins(next, origin, origins, valuePath)
+		}
+	}
+}
+
+// toGenericMap converts the workspace to the generic map composed of map[string]any, []any and scalars.
+func toGenericMap(ws *v1.Workspace) (map[string]any, error) {
+	content, err := yaml.Marshal(ws)
+	if err != nil {
+		return nil, fmt.Errorf("yaml marshal workspace %s failed: %w", ws.Name, err)
+	}
+	m := make(map[string]any)
+	if err = yaml.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("yaml unmarshal workspace %s failed: %w", ws.Name, err)
+	}
+	return m, nil
+}
+
+// validateExtends checks the names of the extended workspaces are valid.
+func validateExtends(ws *v1.Workspace) error {
+	extended := make(map[string]struct{}, len(ws.Extends))
+	for _, name := range ws.Extends {
+		if name == "" {
+			return ErrEmptyExtendedWorkspaceName
+		}
+		if name == ws.Name {
+			return fmt.Errorf("%w: %s -> %s", ErrWorkspaceExtendsCycle, ws.Name, ws.Name)
+		}
+		if _, ok := extended[name]; ok {
+			return fmt.Errorf("%w: %s", ErrRepeatedExtendedWorkspace, name)
+		}
+		extended[name] = struct{}{}
+	}
+	return nil
+}
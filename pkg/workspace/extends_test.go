@@ -0,0 +1,197 @@
+package workspace
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+func mockExtendsWorkspaces() map[string]*v1.Workspace {
+	return map[string]*v1.Workspace{
+		"base": {
+			Name: "base",
+			Modules: v1.ModuleConfigs{
+				"database": {
+					Default: v1.GenericConfig{
+						"type":         "aws",
+						"version":      "5.7",
+						"instanceType": "db.t3.micro",
+					},
+					ModulePatcherConfigs: v1.ModulePatcherConfigs{
+						"smallClass": {
+							GenericConfig:   v1.GenericConfig{"instanceType": "db.t3.small"},
+							ProjectSelector: []string{"foo", "bar"},
+						},
+					},
+				},
+			},
+			Runtimes: &v1.RuntimeConfigs{
+				Kubernetes: &v1.KubernetesConfig{KubeConfig: "/etc/kubeconfig.yaml"},
+			},
+		},
+		"region-eu": {
+			Name: "region-eu",
+			Modules: v1.ModuleConfigs{
+				"database": {
+					Default: v1.GenericConfig{"region": "eu-west-1"},
+				},
+			},
+		},
+		"prod": {
+			Name:    "prod",
+			Extends: []string{"base", "region-eu"},
+			Modules: v1.ModuleConfigs{
+				"database": {
+					Default: v1.GenericConfig{"instanceType": "db.m5.large"},
+					ModulePatcherConfigs: v1.ModulePatcherConfigs{
+						"smallClass": {
+							ProjectSelector: []string{"baz"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func mockWorkspaceGetter(workspaces map[string]*v1.Workspace) func(string) (*v1.Workspace, error) {
+	return func(name string) (*v1.Workspace, error) {
+		ws, ok := workspaces[name]
+		if !ok {
+			return nil, ErrWorkspaceNotExist
+		}
+		return ws, nil
+	}
+}
+
+func TestResolveWorkspace(t *testing.T) {
+	workspaces := mockExtendsWorkspaces()
+	effective, err := ResolveWorkspace(workspaces["prod"], mockWorkspaceGetter(workspaces))
+	assert.NoError(t, err)
+
+	expected := &v1.Workspace{
+		Name: "prod",
+		Modules: v1.ModuleConfigs{
+			"database": {
+				Default: v1.GenericConfig{
+					"type":         "aws",
+					"version":      "5.7",
+					"instanceType": "db.m5.large",
+					"region":       "eu-west-1",
+				},
+				ModulePatcherConfigs: v1.ModulePatcherConfigs{
+					"smallClass": {
+						GenericConfig:   v1.GenericConfig{"instanceType": "db.t3.small"},
+						ProjectSelector: []string{"baz"},
+					},
+				},
+			},
+		},
+		Runtimes: &v1.RuntimeConfigs{
+			Kubernetes: &v1.KubernetesConfig{KubeConfig: "/etc/kubeconfig.yaml"},
+		},
+	}
+	assert.Equal(t, expected, effective.Workspace)
+	assert.NoError(t, ValidateWorkspace(effective.Workspace))
+
+	assert.Equal(t, "base", effective.Origin("modules", "database", "default", "type"))
+	assert.Equal(t, "prod", effective.Origin("modules", "database", "default", "instanceType"))
+	assert.Equal(t, "region-eu", effective.Origin("modules", "database", "default", "region"))
+	assert.Equal(t, "base", effective.Origin("modules", "database", "smallClass", "instanceType"))
+	assert.Equal(t, "prod", effective.Origin("modules", "database", "smallClass", "projectSelector"))
+	assert.Equal(t, "base", effective.Origin("runtimes", "kubernetes", "kubeConfig"))
+	assert.Equal(t, "base", effective.Origin("runtimes"))
+	assert.Equal(t, "", effective.Origin("modules"))
+
+	content, err := effective.MarshalYAMLWithOrigins()
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type: aws # from base")
+	assert.Contains(t, string(content), "region: eu-west-1 # from region-eu")
+	assert.Contains(t, string(content), "runtimes: # from base")
+}
+
+func TestResolveWorkspace_Failed(t *testing.T) {
+	testcases := []struct {
+		name       string
+		workspaces map[string]*v1.Workspace
+		expected   error
+		chain      string
+	}{
+		{
+			name: "extended workspace not exist",
+			workspaces: map[string]*v1.Workspace{
+				"dev": {Name: "dev", Extends: []string{"base"}},
+			},
+			expected: ErrWorkspaceNotExist,
+		},
+		{
+			name: "extends cycle",
+			workspaces: map[string]*v1.Workspace{
+				"dev":  {Name: "dev", Extends: []string{"base"}},
+				"base": {Name: "base", Extends: []string{"root"}},
+				"root": {Name: "root", Extends: []string{"dev"}},
+			},
+			expected: ErrWorkspaceExtendsCycle,
+			chain:    "dev -> base -> root -> dev",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ResolveWorkspace(tc.workspaces["dev"], mockWorkspaceGetter(tc.workspaces))
+			assert.True(t, errors.Is(err, tc.expected))
+			assert.True(t, strings.Contains(err.Error(), tc.chain))
+		})
+	}
+}
+
+func TestResolveWorkspace_Diamond(t *testing.T) {
+	workspaces := map[string]*v1.Workspace{
+		"base": {Name: "base", Runtimes: &v1.RuntimeConfigs{Kubernetes: &v1.KubernetesConfig{KubeConfig: "base"}}},
+		"a":    {Name: "a", Extends: []string{"base"}},
+		"b":    {Name: "b", Extends: []string{"base"}},
+		"dev":  {Name: "dev", Extends: []string{"a", "b"}},
+	}
+	effective, err := ResolveWorkspace(workspaces["dev"], mockWorkspaceGetter(workspaces))
+	assert.NoError(t, err)
+	assert.Equal(t, "base", effective.Runtimes.Kubernetes.KubeConfig)
+	assert.Nil(t, effective.Extends)
+}
+
+func TestOperator_Extends(t *testing.T) {
+	operator := mockOperator(t.TempDir())
+	workspaces := mockExtendsWorkspaces()
+	for _, name := range []string{"base", "region-eu", "prod"} {
+		assert.NoError(t, operator.CreateWorkspace(workspaces[name]))
+	}
+
+	ws, err := operator.GetWorkspace("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", ws.Modules["database"].Default["region"])
+	assert.Nil(t, ws.Extends)
+
+	raw, err := operator.GetRawWorkspace("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"base", "region-eu"}, raw.Extends)
+
+	effective, err := operator.GetEffectiveWorkspace("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "region-eu", effective.Origin("modules", "database", "default", "region"))
+
+	// updating base to extend prod makes a cycle
+	base, err := operator.GetRawWorkspace("base")
+	assert.NoError(t, err)
+	base.Extends = []string{"prod"}
+	assert.ErrorIs(t, operator.UpdateWorkspace(base), ErrWorkspaceExtendsCycle)
+
+	// the effective workspace should be valid
+	assert.Error(t, operator.CreateWorkspace(&v1.Workspace{
+		Name:    "invalid",
+		Extends: []string{"base"},
+		Modules: v1.ModuleConfigs{"database": {ModulePatcherConfigs: v1.ModulePatcherConfigs{"large": {}}}},
+	}))
+}
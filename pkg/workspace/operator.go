@@ -3,29 +3,23 @@ package workspace
 import (
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
-
-	yaml "gopkg.in/yaml.v3"
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
-	"kusionstack.io/kusion/pkg/util/kfile"
+	"kusionstack.io/kusion/pkg/workspace/storages"
 )
 
-const (
-	defaultRelativeStoragePath = "workspaces"
-	suffixYAML                 = ".yaml"
-)
+const defaultRelativeStoragePath = "workspaces"
 
 var (
-	ErrEmptyStoragePath = errors.New("empty storage path")
-	ErrUnexpectedDir    = errors.New("unexpected dir under storage path")
-	ErrFileNotYAML      = errors.New("not yaml file under storage path")
-
-	ErrEmptyWorkspace        = errors.New("empty workspace")
-	ErrWorkspaceNotExist     = errors.New("workspace does not exist")
-	ErrWorkspaceAlreadyExist = errors.New("workspace has already existed")
+	ErrEmptyStorage     = errors.New("empty workspace storage")
+	ErrEmptyStoragePath = storages.ErrEmptyStoragePath
+	ErrUnexpectedDir    = storages.ErrUnexpectedDir
+	ErrFileNotYAML      = storages.ErrFileNotYAML
+
+	ErrEmptyWorkspace        = storages.ErrEmptyWorkspace
+	ErrWorkspaceNotExist     = storages.ErrWorkspaceNotExist
+	ErrWorkspaceAlreadyExist = storages.ErrWorkspaceAlreadyExist
+	ErrWorkspaceConflict     = storages.ErrWorkspaceConflict
 )
 
 // CheckWorkspaceExistenceByDefaultOperator checks the workspace exists or not by default operator.
@@ -34,7 +28,7 @@ func CheckWorkspaceExistenceByDefaultOperator(name string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return operator.WorkspaceExist(name), nil
+	return operator.WorkspaceExist(name)
 }
 
 // GetWorkspaceByDefaultOperator gets a workspace by default operator.
@@ -46,6 +40,24 @@ func GetWorkspaceByDefaultOperator(name string) (*v1.Workspace, error) {
 	return operator.GetWorkspace(name)
 }
 
+// GetRawWorkspaceByDefaultOperator gets a workspace as it is stored by default operator.
+func GetRawWorkspaceByDefaultOperator(name string) (*v1.Workspace, error) {
+	operator, err := NewValidDefaultOperator()
+	if err != nil {
+		return nil, err
+	}
+	return operator.GetRawWorkspace(name)
+}
+
+// GetEffectiveWorkspaceByDefaultOperator gets an effective workspace with the origins of values by default operator.
+func GetEffectiveWorkspaceByDefaultOperator(name string) (*EffectiveWorkspace, error) {
+	operator, err := NewValidDefaultOperator()
+	if err != nil {
+		return nil, err
+	}
+	return operator.GetEffectiveWorkspace(name)
+}
+
 // GetWorkspaceNamesByDefaultOperator list all the workspace names by default operator.
 func GetWorkspaceNamesByDefaultOperator() ([]string, error) {
 	operator, err := NewValidDefaultOperator()
@@ -82,35 +94,40 @@ func DeleteWorkspaceByDefaultOperator(name string) error {
 	return operator.DeleteWorkspace(name)
 }
 
-// Operator is used to handle the CURD operations of workspace. Operator only supports local file
-// system as backend for now.
+// Operator is used to handle the CURD operations of workspace, which stores the workspaces by a
+// storages.Storage, such as local file system, mysql, oss, s3 and http service.
 type Operator struct {
-	// storagePath is absolute path of the directory to store the workspace configs locally. The
-	// storagePath should only include workspace configuration files.
-	storagePath string
+	storage storages.Storage
+
+	// revisions records the revisions of the workspaces got by the operator. When updating a workspace
+	// got before, the revision is used to avoid overwriting the modification made by others in between.
+	revisions map[string]string
 }
 
-// NewOperator news an Operator with the specified storage path. If the directory of the storage
-// path has not created, then create the directory.
+// NewOperator news an Operator which stores workspaces under the specified storage path of the local
+// file system. If the directory of the storage path has not created, then create the directory.
 func NewOperator(storagePath string) (*Operator, error) {
-	_, err := os.Stat(storagePath)
-	if os.IsNotExist(err) {
-		if err = os.MkdirAll(storagePath, os.ModePerm); err != nil {
-			return nil, fmt.Errorf("create directory %s failed, %w", storagePath, err)
-		}
+	storage, err := storages.NewLocalStorage(storagePath)
+	if err != nil {
+		return nil, err
 	}
-	return &Operator{storagePath: storagePath}, nil
+	return NewOperatorWithStorage(storage), nil
+}
+
+// NewOperatorWithStorage news an Operator with the specified workspace storage.
+func NewOperatorWithStorage(storage storages.Storage) *Operator {
+	return &Operator{storage: storage}
 }
 
-// NewDefaultOperator returns a default backend, whose storage path is the directory "workspace"
-// under kfile.KusionDataFolder().
+// NewDefaultOperator returns a default operator, whose storage is decided by the current backend of
+// kusion config. If the current backend is not set, the workspaces are stored under the directory
+// "workspaces" of kfile.KusionDataFolder().
 func NewDefaultOperator() (*Operator, error) {
-	kusionDataDir, err := kfile.KusionDataFolder()
+	storage, err := NewDefaultStorage()
 	if err != nil {
-		return nil, fmt.Errorf("get kusion data folder failed, %w", err)
+		return nil, err
 	}
-	storagePath := filepath.Join(kusionDataDir, defaultRelativeStoragePath)
-	return NewOperator(storagePath)
+	return NewOperatorWithStorage(storage), nil
 }
 
 // NewValidDefaultOperator news a default operator and then do the validation work.
@@ -127,127 +144,141 @@ func NewValidDefaultOperator() (*Operator, error) {
 
 // Validate is used to validate the Operator is valid or not.
 func (o *Operator) Validate() error {
-	if o.storagePath == "" {
-		return ErrEmptyStoragePath
+	if o.storage == nil {
+		return ErrEmptyStorage
 	}
-
-	files, err := o.getWorkspaceFiles()
-	if err != nil {
-		return err
-	}
-	// under storage path, directories or files without suffix ".yaml" are not allowed.
-	for _, file := range files {
-		if file.IsDir() {
-			return fmt.Errorf("%w, dir path: %s", ErrUnexpectedDir, file.Name())
-		}
-		if !strings.HasSuffix(file.Name(), suffixYAML) {
-			return fmt.Errorf("%w, file path: %s", ErrFileNotYAML, file.Name())
-		}
+	if validator, ok := o.storage.(interface{ Validate() error }); ok {
+		return validator.Validate()
 	}
 	return nil
 }
 
 // WorkspaceExist checks the workspace exists or not.
-func (o *Operator) WorkspaceExist(name string) bool {
-	_, err := os.Stat(o.workspaceFilePath(name))
-	return !os.IsNotExist(err)
+func (o *Operator) WorkspaceExist(name string) (bool, error) {
+	return o.storage.Exist(name)
 }
 
 // GetWorkspaceNames gets all the workspace names.
 func (o *Operator) GetWorkspaceNames() ([]string, error) {
-	files, err := o.getWorkspaceFiles()
+	return o.storage.GetNames()
+}
+
+// GetWorkspace gets the effective workspace by name, which is resolved from the workspaces it extends, and
+// whose secret references are replaced by the secret data. The validity of the returned workspace is not
+// guaranteed.
+func (o *Operator) GetWorkspace(name string) (*v1.Workspace, error) {
+	ws, err := o.GetRawWorkspace(name)
 	if err != nil {
 		return nil, err
 	}
+	if len(ws.Extends) != 0 {
+		effective, err := ResolveWorkspace(ws, o.getStoredWorkspace)
+		if err != nil {
+			return nil, err
+		}
+		ws = effective.Workspace
+	}
+	return ResolveSecretReferences(ws)
+}
 
-	workspaces := make([]string, len(files))
-	for i, file := range files {
-		workspaces[i] = strings.TrimSuffix(file.Name(), suffixYAML)
+// GetEffectiveWorkspace gets the effective workspace by name, which also records the origin of each value. The
+// secret references are kept as they are.
+func (o *Operator) GetEffectiveWorkspace(name string) (*EffectiveWorkspace, error) {
+	ws, err := o.GetRawWorkspace(name)
+	if err != nil {
+		return nil, err
 	}
-	return workspaces, nil
+	return ResolveWorkspace(ws, o.getStoredWorkspace)
 }
 
-// GetWorkspace gets the workspace by name. The validity of the returned workspace is not guaranteed.
-func (o *Operator) GetWorkspace(name string) (*v1.Workspace, error) {
+// GetRawWorkspace gets the workspace by name as it is stored, whose extends are not resolved. It should be used
+// to get the workspace to update.
+func (o *Operator) GetRawWorkspace(name string) (*v1.Workspace, error) {
 	if name == "" {
 		return nil, ErrEmptyWorkspaceName
 	}
-	content, err := os.ReadFile(o.workspaceFilePath(name))
-	if os.IsNotExist(err) {
-		return nil, ErrWorkspaceNotExist
-	} else if err != nil {
-		return nil, fmt.Errorf("read workspace file failed: %w", err)
+	ws, revision, err := o.storage.Get(name)
+	if err != nil {
+		return nil, err
 	}
-
-	ws := &v1.Workspace{}
-	if err = yaml.Unmarshal(content, ws); err != nil {
-		return nil, fmt.Errorf("yaml unmarshal failed: %w", err)
+	if o.revisions == nil {
+		o.revisions = make(map[string]string)
 	}
-	ws.Name = name
+	o.revisions[name] = revision
 	return ws, nil
 }
 
-// CreateWorkspace creates a workspace. The validation of workspace should be done before creating.
+// CreateWorkspace creates a workspace. The validation of workspace should be done before creating, while the
+// extended workspaces are checked to exist without cycle, and the effective workspace is validated.
 func (o *Operator) CreateWorkspace(ws *v1.Workspace) error {
 	if ws == nil {
 		return ErrEmptyWorkspace
 	}
-	exist := o.WorkspaceExist(ws.Name)
-	if exist {
-		return ErrWorkspaceAlreadyExist
+	if err := o.validateExtends(ws); err != nil {
+		return err
 	}
-
-	return o.writeWorkspaceFile(ws)
+	return o.storage.Create(ws)
 }
 
-// UpdateWorkspace updates a workspace.The validation of workspace should be done before updating.
+// UpdateWorkspace updates a workspace. The validation of workspace should be done before updating, while the
+// extends are checked as CreateWorkspace does. ErrWorkspaceConflict is returned when the workspace has been
+// modified by others since it was got by the operator, or since the update started if it was not got before.
 func (o *Operator) UpdateWorkspace(ws *v1.Workspace) error {
 	if ws == nil {
 		return ErrEmptyWorkspace
 	}
-	exist := o.WorkspaceExist(ws.Name)
-	if !exist {
-		return ErrWorkspaceNotExist
+	revision, ok := o.revisions[ws.Name]
+	if !ok {
+		// record the stored revision before validating, which may take a while to get the extended workspaces
+		_, stored, err := o.storage.Get(ws.Name)
+		if err != nil {
+			return err
+		}
+		revision = stored
 	}
-
-	return o.writeWorkspaceFile(ws)
+	if err := o.validateExtends(ws); err != nil {
+		return err
+	}
+	if err := o.storage.Update(ws, revision); err != nil {
+		return err
+	}
+	// the new revision is unknown, get the workspace again before the next update
+	delete(o.revisions, ws.Name)
+	return nil
 }
 
-// DeleteWorkspace deletes a workspace.
-func (o *Operator) DeleteWorkspace(name string) error {
-	if name == "" {
-		return ErrEmptyWorkspaceName
-	}
-	exist := o.WorkspaceExist(name)
-	if !exist {
+// validateExtends resolves the workspace to create or update, where it replaces the stored one with the same
+// name, to check the extends, and validates the effective workspace.
+func (o *Operator) validateExtends(ws *v1.Workspace) error {
+	if len(ws.Extends) == 0 {
 		return nil
 	}
-
-	if err := os.Remove(o.workspaceFilePath(name)); err != nil {
-		return fmt.Errorf("remove workspace file failed: %w", err)
+	effective, err := ResolveWorkspace(ws, func(name string) (*v1.Workspace, error) {
+		if name == ws.Name {
+			return ws, nil
+		}
+		return o.getStoredWorkspace(name)
+	})
+	if err != nil {
+		return err
+	}
+	if err = ValidateWorkspace(effective.Workspace); err != nil {
+		return fmt.Errorf("invalid effective workspace %s: %w", ws.Name, err)
 	}
 	return nil
 }
 
-func (o *Operator) workspaceFilePath(name string) string {
-	return filepath.Join(o.storagePath, name+suffixYAML)
+// getStoredWorkspace gets the stored workspace without recording its revision.
+func (o *Operator) getStoredWorkspace(name string) (*v1.Workspace, error) {
+	ws, _, err := o.storage.Get(name)
+	return ws, err
 }
 
-func (o *Operator) getWorkspaceFiles() ([]os.DirEntry, error) {
-	files, err := os.ReadDir(o.storagePath)
-	if err != nil {
-		return nil, fmt.Errorf("read files under storage path %s failed: %w", o.storagePath, err)
-	}
-	return files, nil
-}
-
-func (o *Operator) writeWorkspaceFile(ws *v1.Workspace) error {
-	content, err := yaml.Marshal(ws)
-	if err != nil {
-		return fmt.Errorf("yaml marshal workspace failed: %w", err)
-	}
-	if err = os.WriteFile(o.workspaceFilePath(ws.Name), content, 0o640); err != nil {
-		return fmt.Errorf("write workspace file failed: %w", err)
+// DeleteWorkspace deletes a workspace.
+func (o *Operator) DeleteWorkspace(name string) error {
+	if name == "" {
+		return ErrEmptyWorkspaceName
 	}
-	return nil
+	delete(o.revisions, name)
+	return o.storage.Delete(name)
 }
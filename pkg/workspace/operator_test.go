@@ -10,6 +10,7 @@ import (
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
 	"kusionstack.io/kusion/pkg/util/kfile"
+	"kusionstack.io/kusion/pkg/workspace/storages"
 )
 
 func testDataFolder() string {
@@ -17,10 +18,13 @@ func testDataFolder() string {
 	return filepath.Join(pwd, "testdata")
 }
 
+func mockOperator(storagePath string) *Operator {
+	operator, _ := NewOperator(storagePath)
+	return operator
+}
+
 func mockValidOperator() *Operator {
-	return &Operator{
-		storagePath: filepath.Join(testDataFolder(), defaultRelativeStoragePath),
-	}
+	return mockOperator(filepath.Join(testDataFolder(), defaultRelativeStoragePath))
 }
 
 func TestNewDefaultOperator(t *testing.T) {
@@ -31,7 +35,9 @@ func TestNewDefaultOperator(t *testing.T) {
 			operator, err := NewDefaultOperator()
 			storagePath := filepath.Join(testDataFolder(), defaultRelativeStoragePath)
 			assert.Nil(t, err)
-			assert.Equal(t, storagePath, operator.storagePath)
+			localStorage, ok := operator.storage.(*storages.LocalStorage)
+			assert.True(t, ok)
+			assert.Equal(t, storagePath, localStorage.Path())
 			assert.DirExists(t, storagePath)
 		})
 	})
@@ -49,23 +55,19 @@ func TestOperator_Validate(t *testing.T) {
 			operator: mockValidOperator(),
 		},
 		{
-			name:     "invalid operator empty storage path",
+			name:     "invalid operator empty storage",
 			success:  false,
 			operator: &Operator{},
 		},
 		{
-			name:    "invalid operator not yaml workspace",
-			success: false,
-			operator: &Operator{
-				storagePath: filepath.Join(testDataFolder(), "invalid_workspaces_not_yaml"),
-			},
+			name:     "invalid operator not yaml workspace",
+			success:  false,
+			operator: mockOperator(filepath.Join(testDataFolder(), "invalid_workspaces_not_yaml")),
 		},
 		{
-			name:    "invalid operator dir workspace",
-			success: false,
-			operator: &Operator{
-				storagePath: filepath.Join(testDataFolder(), "invalid_workspaces_dir"),
-			},
+			name:     "invalid operator dir workspace",
+			success:  false,
+			operator: mockOperator(filepath.Join(testDataFolder(), "invalid_workspaces_dir")),
 		},
 	}
 
@@ -229,3 +231,46 @@ func TestOperator_DeleteWorkspace(t *testing.T) {
 		})
 	}
 }
+
+func TestOperator_UpdateWorkspaceConflict(t *testing.T) {
+	operator := mockOperator(t.TempDir())
+	assert.NoError(t, operator.CreateWorkspace(mockValidWorkspace("dev")))
+
+	ws, err := operator.GetWorkspace("dev")
+	assert.NoError(t, err)
+
+	// the workspace is modified by others after got
+	other := mockOperator(operator.storage.(*storages.LocalStorage).Path())
+	otherWs := mockValidWorkspace("dev")
+	otherWs.Modules = nil
+	assert.NoError(t, other.UpdateWorkspace(otherWs))
+
+	err = operator.UpdateWorkspace(ws)
+	assert.ErrorIs(t, err, ErrWorkspaceConflict)
+
+	ws, err = operator.GetWorkspace("dev")
+	assert.NoError(t, err)
+	assert.NoError(t, operator.UpdateWorkspace(ws))
+}
+
+func TestOperator_UpdateWorkspaceNotGot(t *testing.T) {
+	operator := mockOperator(t.TempDir())
+	assert.NoError(t, operator.CreateWorkspace(mockValidWorkspace("dev")))
+	other := mockOperator(operator.storage.(*storages.LocalStorage).Path())
+
+	mockey.PatchConvey("modified by others while validating", t, func() {
+		mockey.Mock((*Operator).validateExtends).To(func(o *Operator, ws *v1.Workspace) error {
+			if o == operator {
+				otherWs := mockValidWorkspace("dev")
+				otherWs.Modules = nil
+				assert.NoError(t, other.UpdateWorkspace(otherWs))
+			}
+			return nil
+		}).Build()
+
+		err := operator.UpdateWorkspace(mockValidWorkspace("dev"))
+		assert.ErrorIs(t, err, ErrWorkspaceConflict)
+	})
+
+	assert.NoError(t, operator.UpdateWorkspace(mockValidWorkspace("dev")))
+}
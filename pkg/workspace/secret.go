@@ -0,0 +1,170 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/secrets"
+)
+
+// SecretReferencePrefix is the prefix of the string value in workspace which refers to a secret in the secret
+// store of the workspace, in the format of secret://<name>[/property][?version=<version>].
+const SecretReferencePrefix = "secret://"
+
+// fieldSecretStore is the yaml field name of the secret store, whose values cannot be secret references.
+const fieldSecretStore = "secretStore"
+
+var (
+	ErrInvalidSecretReference      = errors.New("invalid secret reference")
+	ErrSecretStoreNotConfigured    = errors.New("secret store must be configured in workspace to resolve secret references")
+	ErrSecretStoreProviderNotFound = errors.New("no matched secret store provider found")
+)
+
+// IsSecretReference returns the string value refers to a secret or not.
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretReferencePrefix)
+}
+
+// ParseSecretReference parses the secret reference to ExternalSecretRef. The last segment of the path is the
+// property of the secret when there are more than one segments, such as secret://db/password refers to the
+// property password of the secret db, while secret://db refers to the whole secret db.
+func ParseSecretReference(value string) (*v1.ExternalSecretRef, error) {
+	if !IsSecretReference(value) {
+		return nil, fmt.Errorf("%w: %s, which must start with %s", ErrInvalidSecretReference, value, SecretReferencePrefix)
+	}
+	uri, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s, %v", ErrInvalidSecretReference, value, err)
+	}
+
+	ref := &v1.ExternalSecretRef{Name: uri.Host}
+	if path := strings.Trim(uri.Path, "/"); path != "" {
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			ref.Name = uri.Host + "/" + path[:i]
+			ref.Property = path[i+1:]
+		} else {
+			ref.Property = path
+		}
+	}
+	if ref.Name == "" {
+		return nil, fmt.Errorf("%w: %s, empty secret name", ErrInvalidSecretReference, value)
+	}
+	ref.Version = uri.Query().Get("version")
+	return ref, nil
+}
+
+// ResolveSecretReferences returns a copy of the workspace, where all the string values referring to secrets are
+// replaced by the secret data got from the secret store of the workspace. The secret store config itself cannot
+// contain secret references. If there is no secret reference, the workspace itself is returned.
+func ResolveSecretReferences(ws *v1.Workspace) (*v1.Workspace, error) {
+	if ws == nil {
+		return nil, ErrEmptyWorkspace
+	}
+	m, err := toGenericMap(ws)
+	if err != nil {
+		return nil, err
+	}
+	delete(m, fieldSecretStore)
+
+	refs := make(map[string][]string)
+	collectSecretReferences(m, nil, refs)
+	if len(refs) == 0 {
+		return ws, nil
+	}
+	spec := GetSecretStoreSpec(ws)
+	if spec == nil || spec.Provider == nil {
+		return nil, ErrSecretStoreNotConfigured
+	}
+	provider, exist := secrets.GetProvider(spec.Provider)
+	if !exist {
+		return nil, ErrSecretStoreProviderNotFound
+	}
+	store, err := provider.NewSecretStore(*spec)
+	if err != nil {
+		return nil, fmt.Errorf("new secret store failed: %w", err)
+	}
+
+	values := make([]string, 0, len(refs))
+	for value := range refs {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	resolved := make(map[string]string, len(refs))
+	var allErrs []error
+	for _, value := range values {
+		ref, err := ParseSecretReference(value)
+		if err == nil {
+			var data []byte
+			if data, err = store.GetSecret(context.Background(), *ref); err == nil {
+				resolved[value] = string(data)
+				continue
+			}
+		}
+		allErrs = append(allErrs, fmt.Errorf("resolve %s at %s failed: %w", value, strings.Join(refs[value], ", "), err))
+	}
+	if len(allErrs) != 0 {
+		return nil, utilerrors.NewAggregate(allErrs)
+	}
+
+	replaceSecretReferences(m, resolved)
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("yaml marshal resolved workspace failed: %w", err)
+	}
+	resolvedWs := &v1.Workspace{}
+	if err = yaml.Unmarshal(content, resolvedWs); err != nil {
+		return nil, fmt.Errorf("yaml unmarshal resolved workspace failed: %w", err)
+	}
+	resolvedWs.Name = ws.Name
+	resolvedWs.SecretStore = ws.SecretStore
+	return resolvedWs, nil
+}
+
+// collectSecretReferences collects the secret references in the value, whose key is the reference and value is
+// the paths where it is used.
+func collectSecretReferences(value any, path []string, refs map[string][]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			collectSecretReferences(item, append(append([]string{}, path...), k), refs)
+		}
+	case []any:
+		for i, item := range v {
+			collectSecretReferences(item, append(append([]string{}, path...), fmt.Sprintf("[%d]", i)), refs)
+		}
+	case string:
+		if IsSecretReference(v) {
+			refs[v] = append(refs[v], strings.ReplaceAll(strings.Join(path, "."), ".[", "["))
+		}
+	}
+}
+
+// replaceSecretReferences replaces the secret references in the value by the resolved secret data.
+func replaceSecretReferences(value any, resolved map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			if s, ok := item.(string); ok && IsSecretReference(s) {
+				v[k] = resolved[s]
+				continue
+			}
+			replaceSecretReferences(item, resolved)
+		}
+	case []any:
+		for i, item := range v {
+			if s, ok := item.(string); ok && IsSecretReference(s) {
+				v[i] = resolved[s]
+				continue
+			}
+			replaceSecretReferences(item, resolved)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	_ "kusionstack.io/kusion/pkg/secrets/providers/fake"
+)
+
+func mockFakeSecretStore() *v1.SecretStoreSpec {
+	return &v1.SecretStoreSpec{
+		Provider: &v1.ProviderSpec{
+			Fake: &v1.FakeProvider{
+				Data: []v1.FakeProviderData{
+					{Key: "db", Value: `{"password": "db-password"}`},
+					{Key: "cloud/aws", Value: `{"accessKey": "ak", "secretKey": "sk"}`},
+					{Key: "token", Value: "plain-token", Version: "v2"},
+				},
+			},
+		},
+	}
+}
+
+func TestParseSecretReference(t *testing.T) {
+	testcases := []struct {
+		name     string
+		value    string
+		success  bool
+		expected *v1.ExternalSecretRef
+	}{
+		{
+			name:     "whole secret",
+			value:    "secret://token",
+			success:  true,
+			expected: &v1.ExternalSecretRef{Name: "token"},
+		},
+		{
+			name:     "secret property",
+			value:    "secret://db/password",
+			success:  true,
+			expected: &v1.ExternalSecretRef{Name: "db", Property: "password"},
+		},
+		{
+			name:     "secret property with nested name and version",
+			value:    "secret://cloud/aws/accessKey?version=v1",
+			success:  true,
+			expected: &v1.ExternalSecretRef{Name: "cloud/aws", Property: "accessKey", Version: "v1"},
+		},
+		{
+			name:    "invalid prefix",
+			value:   "ref://db/password",
+			success: false,
+		},
+		{
+			name:    "empty name",
+			value:   "secret:///password",
+			success: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := ParseSecretReference(tc.value)
+			assert.Equal(t, tc.success, err == nil)
+			if tc.success {
+				assert.Equal(t, tc.expected, ref)
+			}
+		})
+	}
+}
+
+func TestResolveSecretReferences(t *testing.T) {
+	ws := &v1.Workspace{
+		Name: "dev",
+		Runtimes: &v1.RuntimeConfigs{
+			Terraform: v1.TerraformConfig{
+				"aws": {
+					Source:  "hashicorp/aws",
+					Version: "1.0.4",
+					GenericConfig: v1.GenericConfig{
+						"access_key": "secret://cloud/aws/accessKey",
+						"secret_key": "secret://cloud/aws/secretKey",
+						"region":     "us-east-1",
+					},
+				},
+			},
+		},
+		Backends: &v1.DeprecatedBackendConfigs{
+			Mysql: &v1.DeprecatedMysqlConfig{
+				DBName:   "kusion",
+				User:     "root",
+				Password: "secret://db/password",
+				Host:     "127.0.0.1",
+			},
+		},
+		Modules: v1.ModuleConfigs{
+			"service": {
+				Default: v1.GenericConfig{
+					"annotations": map[string]any{"token": "secret://token?version=v2"},
+				},
+			},
+		},
+		SecretStore: mockFakeSecretStore(),
+	}
+
+	resolved, err := ResolveSecretReferences(ws)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", resolved.Runtimes.Terraform["aws"].GenericConfig["access_key"])
+	assert.Equal(t, "sk", resolved.Runtimes.Terraform["aws"].GenericConfig["secret_key"])
+	assert.Equal(t, "us-east-1", resolved.Runtimes.Terraform["aws"].GenericConfig["region"])
+	assert.Equal(t, "db-password", resolved.Backends.Mysql.Password)
+	assert.Equal(t, v1.GenericConfig{"token": "plain-token"}, resolved.Modules["service"].Default["annotations"])
+	assert.Equal(t, ws.SecretStore, resolved.SecretStore)
+	// the original workspace is not modified
+	assert.Equal(t, "secret://db/password", ws.Backends.Mysql.Password)
+
+	t.Run("no secret reference", func(t *testing.T) {
+		plain := &v1.Workspace{Name: "dev"}
+		got, err := ResolveSecretReferences(plain)
+		assert.NoError(t, err)
+		assert.Same(t, plain, got)
+	})
+
+	t.Run("secret store not configured", func(t *testing.T) {
+		_, err := ResolveSecretReferences(&v1.Workspace{
+			Name:     "dev",
+			Backends: &v1.DeprecatedBackendConfigs{Mysql: &v1.DeprecatedMysqlConfig{Password: "secret://db/password"}},
+		})
+		assert.ErrorIs(t, err, ErrSecretStoreNotConfigured)
+	})
+
+	t.Run("secret not exist", func(t *testing.T) {
+		_, err := ResolveSecretReferences(&v1.Workspace{
+			Name:        "dev",
+			Backends:    &v1.DeprecatedBackendConfigs{Mysql: &v1.DeprecatedMysqlConfig{Password: "secret://db/pwd"}},
+			SecretStore: mockFakeSecretStore(),
+		})
+		assert.ErrorContains(t, err, "resolve secret://db/pwd at backends.mysql.password failed")
+	})
+}
+
+func TestOperator_GetWorkspaceWithSecretReferences(t *testing.T) {
+	operator := mockOperator(t.TempDir())
+	ws := &v1.Workspace{
+		Name:        "dev",
+		Backends:    &v1.DeprecatedBackendConfigs{Mysql: &v1.DeprecatedMysqlConfig{Password: "secret://db/password"}},
+		SecretStore: mockFakeSecretStore(),
+	}
+	assert.NoError(t, operator.CreateWorkspace(ws))
+
+	got, err := operator.GetWorkspace("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "db-password", got.Backends.Mysql.Password)
+
+	raw, err := operator.GetRawWorkspace("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret://db/password", raw.Backends.Mysql.Password)
+}
@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/config"
+	"kusionstack.io/kusion/pkg/config/validation"
+	"kusionstack.io/kusion/pkg/util/kfile"
+	"kusionstack.io/kusion/pkg/workspace/storages"
+)
+
+// NewStorage news a workspace storage by the backend config, where the sensitive data not set in the
+// config is read from the environment variables. For the local backend, the workspaces are stored under
+// the directory "workspaces" of the configured path, or of kfile.KusionDataFolder() if the path is empty.
+func NewStorage(backendConfig *v1.BackendConfig) (storages.Storage, error) {
+	if backendConfig == nil {
+		return newDefaultLocalStorage()
+	}
+
+	switch backendConfig.Type {
+	case v1.BackendTypeLocal:
+		localConfig := backendConfig.ToLocalBackend()
+		if localConfig.Path == "" {
+			return newDefaultLocalStorage()
+		}
+		return storages.NewLocalStorage(filepath.Join(localConfig.Path, defaultRelativeStoragePath))
+	case v1.BackendTypeMysql:
+		mysqlConfig := backendConfig.ToMysqlBackend()
+		if mysqlConfig.Password == "" {
+			mysqlConfig.Password = GetMysqlPasswordFromEnv()
+		}
+		if err := validation.ValidateMysqlConfig(mysqlConfig); err != nil {
+			return nil, err
+		}
+		return storages.NewMysqlStorage(mysqlConfig)
+	case v1.BackendTypeOss:
+		ossConfig := backendConfig.ToOssBackend()
+		accessKeyID, accessKeySecret := GetOssSensitiveDataFromEnv()
+		completeObjectStorageConfig(ossConfig.GenericBackendObjectStorageConfig, accessKeyID, accessKeySecret)
+		if err := validation.ValidateGenericObjectStorageConfig(ossConfig.GenericBackendObjectStorageConfig); err != nil {
+			return nil, err
+		}
+		return storages.NewOssStorage(ossConfig)
+	case v1.BackendTypeS3:
+		s3Config := backendConfig.ToS3Backend()
+		accessKeyID, accessKeySecret, region := GetS3SensitiveDataFromEnv()
+		completeObjectStorageConfig(s3Config.GenericBackendObjectStorageConfig, accessKeyID, accessKeySecret)
+		if s3Config.Region == "" {
+			s3Config.Region = region
+		}
+		if err := validation.ValidateGenericObjectStorageConfig(s3Config.GenericBackendObjectStorageConfig); err != nil {
+			return nil, err
+		}
+		return storages.NewS3Storage(s3Config)
+	case v1.BackendTypeHttp:
+		httpConfig := backendConfig.ToHttpBackend()
+		if err := validation.ValidateHttpConfig(httpConfig); err != nil {
+			return nil, err
+		}
+		return storages.NewHTTPStorage(httpConfig)
+	default:
+		return nil, fmt.Errorf("%w: %s", validation.ErrUnsupportedBackendType, backendConfig.Type)
+	}
+}
+
+// NewDefaultStorage news the workspace storage of the current backend in kusion config. If the current
+// backend is not set, the workspaces are stored under the directory "workspaces" of kfile.KusionDataFolder().
+func NewDefaultStorage() (storages.Storage, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get kusion config failed, %w", err)
+	}
+	name, backendConfig, err := config.GetCurrentBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := NewStorage(backendConfig)
+	if err != nil {
+		return nil, fmt.Errorf("new workspace storage of backend %s failed, %w", name, err)
+	}
+	return storage, nil
+}
+
+func newDefaultLocalStorage() (storages.Storage, error) {
+	kusionDataDir, err := kfile.KusionDataFolder()
+	if err != nil {
+		return nil, fmt.Errorf("get kusion data folder failed, %w", err)
+	}
+	return storages.NewLocalStorage(filepath.Join(kusionDataDir, defaultRelativeStoragePath))
+}
+
+// completeObjectStorageConfig sets the access key of the object storage config if not set.
+func completeObjectStorageConfig(config *v1.GenericBackendObjectStorageConfig, accessKeyID, accessKeySecret string) {
+	if config.AccessKeyID == "" {
+		config.AccessKeyID = accessKeyID
+	}
+	if config.AccessKeySecret == "" {
+		config.AccessKeySecret = accessKeySecret
+	}
+}
@@ -0,0 +1,114 @@
+package workspace
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/config"
+	"kusionstack.io/kusion/pkg/util/kfile"
+	"kusionstack.io/kusion/pkg/workspace/storages"
+)
+
+func TestNewStorage(t *testing.T) {
+	localPath := t.TempDir()
+	testcases := []struct {
+		name          string
+		success       bool
+		backendConfig *v1.BackendConfig
+		expectedPath  string
+	}{
+		{
+			name:          "default local storage",
+			success:       true,
+			backendConfig: nil,
+			expectedPath:  filepath.Join(testDataFolder(), defaultRelativeStoragePath),
+		},
+		{
+			name:    "local storage with path",
+			success: true,
+			backendConfig: &v1.BackendConfig{
+				Type:    v1.BackendTypeLocal,
+				Configs: map[string]any{v1.BackendLocalPath: localPath},
+			},
+			expectedPath: filepath.Join(localPath, defaultRelativeStoragePath),
+		},
+		{
+			name:    "http storage",
+			success: true,
+			backendConfig: &v1.BackendConfig{
+				Type:    v1.BackendTypeHttp,
+				Configs: map[string]any{v1.BackendHttpURLPrefix: "https://kusion.example.com"},
+			},
+		},
+		{
+			name:    "invalid http storage empty url prefix",
+			success: false,
+			backendConfig: &v1.BackendConfig{
+				Type: v1.BackendTypeHttp,
+			},
+		},
+		{
+			name:    "invalid mysql storage empty db name",
+			success: false,
+			backendConfig: &v1.BackendConfig{
+				Type:    v1.BackendTypeMysql,
+				Configs: map[string]any{v1.BackendMysqlUser: "kusion"},
+			},
+		},
+		{
+			name:    "invalid oss storage empty bucket",
+			success: false,
+			backendConfig: &v1.BackendConfig{
+				Type: v1.BackendTypeOss,
+			},
+		},
+		{
+			name:    "unsupported backend type",
+			success: false,
+			backendConfig: &v1.BackendConfig{
+				Type: "not-support",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockey.PatchConvey("mock kusion data folder", t, func() {
+				mockey.Mock(kfile.KusionDataFolder).Return(testDataFolder(), nil).Build()
+
+				storage, err := NewStorage(tc.backendConfig)
+				assert.Equal(t, tc.success, err == nil)
+				if tc.expectedPath != "" {
+					localStorage, ok := storage.(*storages.LocalStorage)
+					assert.True(t, ok)
+					assert.Equal(t, tc.expectedPath, localStorage.Path())
+				}
+			})
+		})
+	}
+}
+
+func TestNewDefaultStorage(t *testing.T) {
+	mockey.PatchConvey("current backend in kusion config", t, func() {
+		mockey.Mock(config.GetConfig).Return(&v1.Config{
+			Backends: &v1.BackendConfigs{
+				Current: "dev",
+				Backends: map[string]*v1.BackendConfig{
+					"dev": {
+						Type:    v1.BackendTypeHttp,
+						Configs: map[string]any{v1.BackendHttpURLPrefix: "https://kusion.example.com"},
+					},
+				},
+			},
+		}, nil).Build()
+
+		storage, err := NewDefaultStorage()
+		assert.NoError(t, err)
+		_, ok := storage.(*storages.HTTPStorage)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,204 @@
+package storages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	httpstate "kusionstack.io/kusion/pkg/engine/states/remote/http"
+)
+
+const (
+	workspacesURLFormat = "%s/apis/v1/workspaces"
+	workspaceURLFormat  = "%s/apis/v1/workspaces/%s"
+	contentTypeYAML     = "application/x-yaml"
+)
+
+var _ Storage = &HTTPStorage{}
+
+// HTTPStorage is an implementation of Storage which uses a http service as storage. The http service
+// should serve the following endpoints, where the workspace is transferred in yaml format:
+//
+//   - GET    /apis/v1/workspaces: returns the json array of the workspace names.
+//   - GET    /apis/v1/workspaces/{name}: returns the workspace with the revision as ETag header, or 404.
+//   - POST   /apis/v1/workspaces/{name}: creates the workspace, returns 409 if the workspace has existed.
+//   - PUT    /apis/v1/workspaces/{name}: updates the workspace, returns 404 if the workspace does not exist,
+//     and 412 if the If-Match header is set and differs from the current revision.
+//   - DELETE /apis/v1/workspaces/{name}: deletes the workspace.
+type HTTPStorage struct {
+	urlPrefix string
+	token     string
+	client    *http.Client
+}
+
+// NewHTTPStorage news http workspace storage.
+func NewHTTPStorage(config *v1.BackendHttpConfig) (*HTTPStorage, error) {
+	clientConfig := httpstate.NewDefaultClientConfig()
+	clientConfig.Token = config.Token
+	client, err := clientConfig.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPStorage{
+		urlPrefix: strings.TrimSuffix(config.URLPrefix, "/"),
+		token:     config.Token,
+		client:    client,
+	}, nil
+}
+
+func (s *HTTPStorage) Get(name string) (*v1.Workspace, string, error) {
+	if name == "" {
+		return nil, "", ErrEmptyWorkspaceName
+	}
+	res, content, err := s.do(http.MethodGet, s.workspaceURL(name), nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", ErrWorkspaceNotExist
+	default:
+		return nil, "", unexpectedStatusError("get workspace", res, content)
+	}
+
+	ws, err := unmarshalWorkspace(name, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("yaml unmarshal failed: %w", err)
+	}
+	return ws, res.Header.Get("ETag"), nil
+}
+
+func (s *HTTPStorage) GetNames() ([]string, error) {
+	res, content, err := s.do(http.MethodGet, fmt.Sprintf(workspacesURLFormat, s.urlPrefix), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatusError("list workspaces", res, content)
+	}
+	var names []string
+	if err = json.Unmarshal(content, &names); err != nil {
+		return nil, fmt.Errorf("json unmarshal workspace names failed: %w", err)
+	}
+	return names, nil
+}
+
+func (s *HTTPStorage) Exist(name string) (bool, error) {
+	_, _, err := s.Get(name)
+	if err == ErrWorkspaceNotExist {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *HTTPStorage) Create(ws *v1.Workspace) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+	res, body, err := s.do(http.MethodPost, s.workspaceURL(ws.Name), content, nil)
+	if err != nil {
+		return err
+	}
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusConflict:
+		return ErrWorkspaceAlreadyExist
+	default:
+		return unexpectedStatusError("create workspace", res, body)
+	}
+}
+
+func (s *HTTPStorage) Update(ws *v1.Workspace, revision string) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+	var headers map[string]string
+	if revision != "" {
+		headers = map[string]string{"If-Match": revision}
+	}
+	res, body, err := s.do(http.MethodPut, s.workspaceURL(ws.Name), content, headers)
+	if err != nil {
+		return err
+	}
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return ErrWorkspaceNotExist
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return ErrWorkspaceConflict
+	default:
+		return unexpectedStatusError("update workspace", res, body)
+	}
+}
+
+func (s *HTTPStorage) Delete(name string) error {
+	if name == "" {
+		return ErrEmptyWorkspaceName
+	}
+	res, body, err := s.do(http.MethodDelete, s.workspaceURL(name), nil, nil)
+	if err != nil {
+		return err
+	}
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return unexpectedStatusError("delete workspace", res, body)
+	}
+}
+
+func (s *HTTPStorage) workspaceURL(name string) string {
+	return fmt.Sprintf(workspaceURLFormat, s.urlPrefix, url.PathEscape(name))
+}
+
+// do sends the request and returns the response with the read body.
+func (s *HTTPStorage) do(method, url string, body []byte, headers map[string]string) (*http.Response, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentTypeYAML)
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := s.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, content, nil
+}
+
+func unexpectedStatusError(action string, res *http.Response, body []byte) error {
+	return fmt.Errorf("%s failed. StatusCode:%v, Status:%s, Body:%s", action, res.StatusCode, res.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,87 @@
+package storages
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+// newWorkspaceServer returns an in-memory http server which serves the workspace endpoints.
+func newWorkspaceServer(token string) *httptest.Server {
+	var mu sync.Mutex
+	workspaces := map[string][]byte{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/apis/v1/workspaces" {
+			names := []string{}
+			for name := range workspaces {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			_ = json.NewEncoder(w).Encode(names)
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/apis/v1/workspaces/")
+		content, exist := workspaces[name]
+		switch r.Method {
+		case http.MethodGet:
+			if !exist {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", contentRevision(content))
+			_, _ = w.Write(content)
+		case http.MethodPost:
+			if exist {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			workspaces[name], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPut:
+			if !exist {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if revision := r.Header.Get("If-Match"); revision != "" && revision != contentRevision(content) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			workspaces[name], _ = io.ReadAll(r.Body)
+		case http.MethodDelete:
+			delete(workspaces, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestHTTPStorage(t *testing.T) {
+	ts := newWorkspaceServer("kusion-token")
+	defer ts.Close()
+
+	s, err := NewHTTPStorage(&v1.BackendHttpConfig{URLPrefix: ts.URL + "/", Token: "kusion-token"})
+	assert.NoError(t, err)
+	testStorage(t, s)
+
+	s, err = NewHTTPStorage(&v1.BackendHttpConfig{URLPrefix: ts.URL, Token: "invalid"})
+	assert.NoError(t, err)
+	_, err = s.GetNames()
+	assert.Error(t, err)
+	_, _, err = s.Get("dev")
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package storages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+var (
+	ErrEmptyStoragePath = errors.New("empty storage path")
+	ErrUnexpectedDir    = errors.New("unexpected dir under storage path")
+	ErrFileNotYAML      = errors.New("not yaml file under storage path")
+)
+
+var _ Storage = &LocalStorage{}
+
+// LocalStorage is an implementation of Storage which uses local filesystem as storage, each workspace
+// is stored as a yaml file under the storage path.
+type LocalStorage struct {
+	// path is the directory to store the workspace configs, which should only include workspace
+	// configuration files.
+	path string
+
+	// mu serializes the check and write of workspace files in the same process.
+	mu sync.Mutex
+}
+
+// NewLocalStorage news local workspace storage. If the directory of the path has not been created, then
+// create the directory.
+func NewLocalStorage(path string) (*LocalStorage, error) {
+	if path == "" {
+		return nil, ErrEmptyStoragePath
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("create directory %s failed, %w", path, err)
+	}
+	return &LocalStorage{path: path}, nil
+}
+
+// Path returns the directory to store the workspace configs.
+func (s *LocalStorage) Path() string {
+	return s.path
+}
+
+// Validate is used to validate the files under the storage path are all workspace configs.
+func (s *LocalStorage) Validate() error {
+	if s.path == "" {
+		return ErrEmptyStoragePath
+	}
+
+	files, err := s.getWorkspaceFiles()
+	if err != nil {
+		return err
+	}
+	// under storage path, directories or files without suffix ".yaml" are not allowed.
+	for _, file := range files {
+		if file.IsDir() {
+			return fmt.Errorf("%w, dir path: %s", ErrUnexpectedDir, file.Name())
+		}
+		if !strings.HasSuffix(file.Name(), suffixYAML) {
+			return fmt.Errorf("%w, file path: %s", ErrFileNotYAML, file.Name())
+		}
+	}
+	return nil
+}
+
+func (s *LocalStorage) Get(name string) (*v1.Workspace, string, error) {
+	if name == "" {
+		return nil, "", ErrEmptyWorkspaceName
+	}
+	content, err := os.ReadFile(s.workspaceFilePath(name))
+	if os.IsNotExist(err) {
+		return nil, "", ErrWorkspaceNotExist
+	} else if err != nil {
+		return nil, "", fmt.Errorf("read workspace file failed: %w", err)
+	}
+
+	ws, err := unmarshalWorkspace(name, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("yaml unmarshal failed: %w", err)
+	}
+	return ws, contentRevision(content), nil
+}
+
+func (s *LocalStorage) GetNames() ([]string, error) {
+	files, err := s.getWorkspaceFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = strings.TrimSuffix(file.Name(), suffixYAML)
+	}
+	return names, nil
+}
+
+func (s *LocalStorage) Exist(name string) (bool, error) {
+	_, err := os.Stat(s.workspaceFilePath(name))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("stat workspace file failed: %w", err)
+	}
+	return true, nil
+}
+
+func (s *LocalStorage) Create(ws *v1.Workspace) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exist, err := s.Exist(ws.Name)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return ErrWorkspaceAlreadyExist
+	}
+	return s.writeWorkspaceFile(ws.Name, content)
+}
+
+func (s *LocalStorage) Update(ws *v1.Workspace, revision string) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	oldContent, err := os.ReadFile(s.workspaceFilePath(ws.Name))
+	if os.IsNotExist(err) {
+		return ErrWorkspaceNotExist
+	} else if err != nil {
+		return fmt.Errorf("read workspace file failed: %w", err)
+	}
+	if revision != "" && revision != contentRevision(oldContent) {
+		return ErrWorkspaceConflict
+	}
+	return s.writeWorkspaceFile(ws.Name, content)
+}
+
+func (s *LocalStorage) Delete(name string) error {
+	if name == "" {
+		return ErrEmptyWorkspaceName
+	}
+	err := os.Remove(s.workspaceFilePath(name))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove workspace file failed: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) workspaceFilePath(name string) string {
+	return filepath.Join(s.path, name+suffixYAML)
+}
+
+func (s *LocalStorage) getWorkspaceFiles() ([]os.DirEntry, error) {
+	files, err := os.ReadDir(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("read files under storage path %s failed: %w", s.path, err)
+	}
+	return files, nil
+}
+
+func (s *LocalStorage) writeWorkspaceFile(name string, content []byte) error {
+	if err := os.WriteFile(s.workspaceFilePath(name), content, 0o640); err != nil {
+		return fmt.Errorf("write workspace file failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package storages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(filepath.Join(t.TempDir(), "workspaces"))
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate())
+	testStorage(t, s)
+}
+
+func TestLocalStorage_Validate(t *testing.T) {
+	_, err := NewLocalStorage("")
+	assert.ErrorIs(t, err, ErrEmptyStoragePath)
+
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(s.Path(), "dev.json"), []byte("{}"), 0o640))
+	assert.ErrorIs(t, s.Validate(), ErrFileNotYAML)
+
+	s, err = NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, os.Mkdir(filepath.Join(s.Path(), "dev"), os.ModePerm))
+	assert.ErrorIs(t, s.Validate(), ErrUnexpectedDir)
+}
@@ -0,0 +1,176 @@
+package storages
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/didi/gendry/manager"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+// workspaceTableDDL creates the table to store workspaces, where revision is increased by one on each
+// update and is used to do optimistic concurrency control.
+const workspaceTableDDL = "CREATE TABLE IF NOT EXISTS `workspace` (" +
+	"`id` bigint NOT NULL AUTO_INCREMENT, " +
+	"`name` varchar(255) NOT NULL, " +
+	"`content` longtext NOT NULL, " +
+	"`revision` bigint NOT NULL DEFAULT 1, " +
+	"`create_time` datetime NOT NULL, " +
+	"`modified_time` datetime NOT NULL, " +
+	"PRIMARY KEY (`id`), " +
+	"UNIQUE KEY `uk_name` (`name`)" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8"
+
+var _ Storage = &MysqlStorage{}
+
+// MysqlStorage is an implementation of Storage which uses mysql as storage, each workspace is stored as
+// a row of table workspace.
+type MysqlStorage struct {
+	db *sql.DB
+}
+
+// NewMysqlStorage news mysql workspace storage, and creates the workspace table if not exists.
+func NewMysqlStorage(config *v1.BackendMysqlConfig) (*MysqlStorage, error) {
+	port := config.Port
+	if port == 0 {
+		port = v1.DefaultMysqlPort
+	}
+	db, err := manager.New(config.DBName, config.User, config.Password, config.Host).Set(
+		manager.SetCharset("utf8"),
+		manager.SetParseTime(true),
+		manager.SetInterpolateParams(true),
+		manager.SetLoc(url.QueryEscape("Asia/Shanghai"))).Port(port).Open(true)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = db.Exec(workspaceTableDDL); err != nil {
+		return nil, fmt.Errorf("create workspace table failed: %w", err)
+	}
+	return &MysqlStorage{db: db}, nil
+}
+
+func (s *MysqlStorage) Get(name string) (*v1.Workspace, string, error) {
+	if name == "" {
+		return nil, "", ErrEmptyWorkspaceName
+	}
+	var content string
+	var revision int64
+	err := s.db.QueryRow("SELECT content, revision FROM workspace WHERE name = ?", name).Scan(&content, &revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, "", ErrWorkspaceNotExist
+	} else if err != nil {
+		return nil, "", fmt.Errorf("query workspace failed: %w", err)
+	}
+
+	ws, err := unmarshalWorkspace(name, []byte(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("yaml unmarshal failed: %w", err)
+	}
+	return ws, strconv.FormatInt(revision, 10), nil
+}
+
+func (s *MysqlStorage) GetNames() ([]string, error) {
+	rows, err := s.db.Query("SELECT name FROM workspace ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("query workspace names failed: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan workspace name failed: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func (s *MysqlStorage) Exist(name string) (bool, error) {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM workspace WHERE name = ?", name).Scan(&count); err != nil {
+		return false, fmt.Errorf("query workspace failed: %w", err)
+	}
+	return count != 0, nil
+}
+
+func (s *MysqlStorage) Create(ws *v1.Workspace) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+	exist, err := s.Exist(ws.Name)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return ErrWorkspaceAlreadyExist
+	}
+
+	now := time.Now()
+	_, err = s.db.Exec("INSERT INTO workspace (name, content, revision, create_time, modified_time) VALUES (?, ?, 1, ?, ?)",
+		ws.Name, string(content), now, now)
+	if err != nil {
+		// the workspace may be created concurrently, which violates the unique key
+		if exist, _ = s.Exist(ws.Name); exist {
+			return ErrWorkspaceAlreadyExist
+		}
+		return fmt.Errorf("insert workspace failed: %w", err)
+	}
+	return nil
+}
+
+func (s *MysqlStorage) Update(ws *v1.Workspace, revision string) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+
+	var result sql.Result
+	if revision == "" {
+		result, err = s.db.Exec("UPDATE workspace SET content = ?, revision = revision + 1, modified_time = ? WHERE name = ?",
+			string(content), time.Now(), ws.Name)
+	} else {
+		var rev int64
+		if rev, err = strconv.ParseInt(revision, 10, 64); err != nil {
+			return ErrWorkspaceConflict
+		}
+		result, err = s.db.Exec("UPDATE workspace SET content = ?, revision = revision + 1, modified_time = ? WHERE name = ? AND revision = ?",
+			string(content), time.Now(), ws.Name, rev)
+	}
+	if err != nil {
+		return fmt.Errorf("update workspace failed: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update workspace failed: %w", err)
+	}
+	if affected != 0 {
+		return nil
+	}
+
+	exist, err := s.Exist(ws.Name)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return ErrWorkspaceNotExist
+	}
+	return ErrWorkspaceConflict
+}
+
+func (s *MysqlStorage) Delete(name string) error {
+	if name == "" {
+		return ErrEmptyWorkspaceName
+	}
+	if _, err := s.db.Exec("DELETE FROM workspace WHERE name = ?", name); err != nil {
+		return fmt.Errorf("delete workspace failed: %w", err)
+	}
+	return nil
+}
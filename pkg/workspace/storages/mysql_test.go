@@ -0,0 +1,33 @@
+package storages
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteWorkspaceTableDDL is the sqlite equivalent of workspaceTableDDL, the statements of MysqlStorage
+// are compatible with sqlite.
+const sqliteWorkspaceTableDDL = `CREATE TABLE workspace (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name VARCHAR(255) NOT NULL UNIQUE,
+	content TEXT NOT NULL,
+	revision INTEGER NOT NULL DEFAULT 1,
+	create_time DATETIME NOT NULL,
+	modified_time DATETIME NOT NULL
+)`
+
+func TestMysqlStorage(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	_, err = db.Exec(sqliteWorkspaceTableDDL)
+	assert.NoError(t, err)
+
+	s := &MysqlStorage{db: db}
+	testStorage(t, s)
+	assert.ErrorIs(t, s.Update(mockWorkspace("prod"), "invalid"), ErrWorkspaceConflict)
+}
@@ -0,0 +1,280 @@
+package storages
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+var errObjectNotExist = errors.New("object does not exist")
+
+var _ Storage = &ObjectStorage{}
+
+// objectClient is the minimal set of operations of an object storage service used by ObjectStorage.
+type objectClient interface {
+	// get returns the content of the object, or errObjectNotExist if the object does not exist.
+	get(key string) ([]byte, error)
+	put(key string, content []byte) error
+	delete(key string) error
+	// list returns the keys of all the objects with the prefix.
+	list(prefix string) ([]string, error)
+}
+
+// ObjectStorage is an implementation of Storage which uses object storage service such as OSS and S3 as
+// storage, each workspace is stored as a yaml object with the key "{prefix}/workspaces/{name}.yaml".
+//
+// Object storage services do not support conditional writes uniformly, so the revision check on update
+// is done by comparing the content right before writing, which narrows but does not close the window of
+// concurrent updates from different processes.
+type ObjectStorage struct {
+	client objectClient
+	prefix string
+
+	mu sync.Mutex
+}
+
+// NewOssStorage news oss workspace storage.
+func NewOssStorage(config *v1.BackendOssConfig) (*ObjectStorage, error) {
+	client, err := oss.New(config.Endpoint, config.AccessKeyID, config.AccessKeySecret)
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := client.Bucket(config.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectStorage{client: &ossClient{bucket: bucket}, prefix: config.Prefix}, nil
+}
+
+// NewS3Storage news s3 workspace storage.
+func NewS3Storage(config *v1.BackendS3Config) (*ObjectStorage, error) {
+	awsConfig := &aws.Config{
+		Credentials:      credentials.NewStaticCredentials(config.AccessKeyID, config.AccessKeySecret, ""),
+		Region:           aws.String(config.Region),
+		DisableSSL:       aws.Bool(true),
+		S3ForcePathStyle: aws.Bool(false),
+	}
+	if config.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.Endpoint)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectStorage{
+		client: &s3Client{client: s3.New(sess), bucket: config.Bucket},
+		prefix: config.Prefix,
+	}, nil
+}
+
+func (s *ObjectStorage) Get(name string) (*v1.Workspace, string, error) {
+	if name == "" {
+		return nil, "", ErrEmptyWorkspaceName
+	}
+	content, err := s.client.get(workspaceKey(s.prefix, name))
+	if errors.Is(err, errObjectNotExist) {
+		return nil, "", ErrWorkspaceNotExist
+	} else if err != nil {
+		return nil, "", fmt.Errorf("get workspace object failed: %w", err)
+	}
+
+	ws, err := unmarshalWorkspace(name, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("yaml unmarshal failed: %w", err)
+	}
+	return ws, contentRevision(content), nil
+}
+
+func (s *ObjectStorage) GetNames() ([]string, error) {
+	keys, err := s.client.list(workspacesKeyPrefix(s.prefix))
+	if err != nil {
+		return nil, fmt.Errorf("list workspace objects failed: %w", err)
+	}
+
+	var names []string
+	for _, key := range keys {
+		if name := workspaceNameFromKey(s.prefix, key); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (s *ObjectStorage) Exist(name string) (bool, error) {
+	_, _, err := s.Get(name)
+	if errors.Is(err, ErrWorkspaceNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ObjectStorage) Create(ws *v1.Workspace) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exist, err := s.Exist(ws.Name)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return ErrWorkspaceAlreadyExist
+	}
+	return s.put(ws.Name, content)
+}
+
+func (s *ObjectStorage) Update(ws *v1.Workspace, revision string) error {
+	content, err := marshalWorkspace(ws)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, currentRevision, err := s.Get(ws.Name)
+	if err != nil {
+		return err
+	}
+	if revision != "" && revision != currentRevision {
+		return ErrWorkspaceConflict
+	}
+	return s.put(ws.Name, content)
+}
+
+func (s *ObjectStorage) Delete(name string) error {
+	if name == "" {
+		return ErrEmptyWorkspaceName
+	}
+	if err := s.client.delete(workspaceKey(s.prefix, name)); err != nil {
+		return fmt.Errorf("delete workspace object failed: %w", err)
+	}
+	return nil
+}
+
+func (s *ObjectStorage) put(name string, content []byte) error {
+	if err := s.client.put(workspaceKey(s.prefix, name), content); err != nil {
+		return fmt.Errorf("put workspace object failed: %w", err)
+	}
+	return nil
+}
+
+// ossClient is the objectClient of OSS.
+type ossClient struct {
+	bucket *oss.Bucket
+}
+
+func (c *ossClient) get(key string) ([]byte, error) {
+	body, err := c.bucket.GetObject(key)
+	if err != nil {
+		var serviceErr oss.ServiceError
+		if errors.As(err, &serviceErr) && serviceErr.StatusCode == http.StatusNotFound {
+			return nil, errObjectNotExist
+		}
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+func (c *ossClient) put(key string, content []byte) error {
+	return c.bucket.PutObject(key, bytes.NewReader(content))
+}
+
+func (c *ossClient) delete(key string) error {
+	return c.bucket.DeleteObject(key)
+}
+
+func (c *ossClient) list(prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		options := []oss.Option{oss.Prefix(prefix)}
+		if token != "" {
+			options = append(options, oss.ContinuationToken(token))
+		}
+		result, err := c.bucket.ListObjectsV2(options...)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range result.Objects {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// s3Client is the objectClient of S3.
+type s3Client struct {
+	client *s3.S3
+	bucket string
+}
+
+func (c *s3Client) get(key string) ([]byte, error) {
+	out, err := c.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, errObjectNotExist
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+func (c *s3Client) put(key string, content []byte) error {
+	_, err := c.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(content),
+	})
+	return err
+}
+
+func (c *s3Client) delete(key string) error {
+	_, err := c.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (c *s3Client) list(prefix string) ([]string, error) {
+	var keys []string
+	err := c.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,44 @@
+package storages
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// memoryObjectClient is an in-memory objectClient used for testing.
+type memoryObjectClient map[string][]byte
+
+func (c memoryObjectClient) get(key string) ([]byte, error) {
+	content, ok := c[key]
+	if !ok {
+		return nil, errObjectNotExist
+	}
+	return content, nil
+}
+
+func (c memoryObjectClient) put(key string, content []byte) error {
+	c[key] = content
+	return nil
+}
+
+func (c memoryObjectClient) delete(key string) error {
+	delete(c, key)
+	return nil
+}
+
+func (c memoryObjectClient) list(prefix string) ([]string, error) {
+	var keys []string
+	for key := range c {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func TestObjectStorage(t *testing.T) {
+	client := memoryObjectClient{"kusion/other.yaml": []byte("")}
+	testStorage(t, &ObjectStorage{client: client, prefix: "kusion"})
+}
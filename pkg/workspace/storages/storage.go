@@ -0,0 +1,103 @@
+package storages
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+const (
+	// workspacesPrefix is the directory or key prefix to store the workspaces in the backend.
+	workspacesPrefix = "workspaces"
+	suffixYAML       = ".yaml"
+)
+
+var (
+	ErrEmptyWorkspace        = errors.New("empty workspace")
+	ErrEmptyWorkspaceName    = errors.New("empty workspace name")
+	ErrWorkspaceNotExist     = errors.New("workspace does not exist")
+	ErrWorkspaceAlreadyExist = errors.New("workspace has already existed")
+	ErrWorkspaceConflict     = errors.New("workspace has been modified by others, please retry")
+)
+
+// Storage is used to provide the storage service for multiple workspaces. The validation of the
+// workspace should be done before creating or updating.
+type Storage interface {
+	// Get returns the specified workspace and its revision. The revision is an opaque string which
+	// changes once the workspace is updated, and is used by Update to do optimistic concurrency control.
+	Get(name string) (*v1.Workspace, string, error)
+
+	// GetNames returns the names of all the workspaces.
+	GetNames() ([]string, error)
+
+	// Exist returns the specified workspace exists or not.
+	Exist(name string) (bool, error)
+
+	// Create creates a workspace, returns ErrWorkspaceAlreadyExist if the workspace has existed.
+	Create(ws *v1.Workspace) error
+
+	// Update updates the workspace, returns ErrWorkspaceNotExist if the workspace does not exist. If the
+	// revision is not empty and differs from the revision in the storage, returns ErrWorkspaceConflict.
+	Update(ws *v1.Workspace, revision string) error
+
+	// Delete deletes the workspace, and returns nil if the workspace does not exist.
+	Delete(name string) error
+}
+
+// marshalWorkspace returns the yaml content of the workspace.
+func marshalWorkspace(ws *v1.Workspace) ([]byte, error) {
+	if ws == nil {
+		return nil, ErrEmptyWorkspace
+	}
+	if ws.Name == "" {
+		return nil, ErrEmptyWorkspaceName
+	}
+	return yaml.Marshal(ws)
+}
+
+// unmarshalWorkspace returns the workspace with the name from the yaml content.
+func unmarshalWorkspace(name string, content []byte) (*v1.Workspace, error) {
+	ws := &v1.Workspace{}
+	if err := yaml.Unmarshal(content, ws); err != nil {
+		return nil, err
+	}
+	ws.Name = name
+	return ws, nil
+}
+
+// contentRevision returns the revision of the workspace stored as a whole file or object, which is
+// the sha256 of the content.
+func contentRevision(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// workspaceKey returns the key of the workspace in object storage, which is like
+// "{prefix}/workspaces/{name}.yaml".
+func workspaceKey(prefix, name string) string {
+	return workspacesKeyPrefix(prefix) + name + suffixYAML
+}
+
+// workspacesKeyPrefix returns the key prefix of all the workspaces in object storage.
+func workspacesKeyPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return workspacesPrefix + "/"
+	}
+	return prefix + "/" + workspacesPrefix + "/"
+}
+
+// workspaceNameFromKey returns the workspace name from the object key, returns empty string if the key is
+// not a workspace key.
+func workspaceNameFromKey(prefix, key string) string {
+	name := strings.TrimPrefix(key, workspacesKeyPrefix(prefix))
+	if name == key || strings.Contains(name, "/") || !strings.HasSuffix(name, suffixYAML) {
+		return ""
+	}
+	return strings.TrimSuffix(name, suffixYAML)
+}
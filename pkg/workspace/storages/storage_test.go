@@ -0,0 +1,115 @@
+package storages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+)
+
+func mockWorkspace(name string) *v1.Workspace {
+	return &v1.Workspace{
+		Name: name,
+		Modules: v1.ModuleConfigs{
+			"database": {
+				Default: v1.GenericConfig{
+					"type":    "aws",
+					"version": "5.7",
+				},
+			},
+		},
+		Runtimes: &v1.RuntimeConfigs{
+			Kubernetes: &v1.KubernetesConfig{
+				KubeConfig: "/etc/kubeconfig.yaml",
+			},
+		},
+	}
+}
+
+// testStorage checks the Storage implementation satisfies the contract of the interface.
+func testStorage(t *testing.T, s Storage) {
+	names, err := s.GetNames()
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	_, _, err = s.Get("dev")
+	assert.ErrorIs(t, err, ErrWorkspaceNotExist)
+	assert.ErrorIs(t, s.Update(mockWorkspace("dev"), ""), ErrWorkspaceNotExist)
+
+	assert.NoError(t, s.Create(mockWorkspace("dev")))
+	assert.NoError(t, s.Create(mockWorkspace("prod")))
+	assert.ErrorIs(t, s.Create(mockWorkspace("dev")), ErrWorkspaceAlreadyExist)
+	assert.ErrorIs(t, s.Create(nil), ErrEmptyWorkspace)
+
+	exist, err := s.Exist("dev")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	names, err = s.GetNames()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"dev", "prod"}, names)
+
+	ws, revision, err := s.Get("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, mockWorkspace("dev"), ws)
+	assert.NotEmpty(t, revision)
+
+	// update with the latest revision succeeds, while with the outdated revision conflicts
+	ws.Runtimes.Kubernetes.KubeConfig = "/etc/kubeconfig-new.yaml"
+	assert.NoError(t, s.Update(ws, revision))
+	newWs, newRevision, err := s.Get("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, ws, newWs)
+	assert.NotEqual(t, revision, newRevision)
+	assert.ErrorIs(t, s.Update(mockWorkspace("dev"), revision), ErrWorkspaceConflict)
+	assert.NoError(t, s.Update(mockWorkspace("dev"), ""))
+
+	assert.NoError(t, s.Delete("dev"))
+	assert.NoError(t, s.Delete("dev"))
+	exist, err = s.Exist("dev")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func TestWorkspaceNameFromKey(t *testing.T) {
+	testcases := []struct {
+		name         string
+		prefix       string
+		key          string
+		expectedName string
+	}{
+		{
+			name:         "key without prefix",
+			key:          "workspaces/dev.yaml",
+			expectedName: "dev",
+		},
+		{
+			name:         "key with prefix",
+			prefix:       "/kusion/",
+			key:          "kusion/workspaces/dev.yaml",
+			expectedName: "dev",
+		},
+		{
+			name:         "not yaml key",
+			key:          "workspaces/dev.json",
+			expectedName: "",
+		},
+		{
+			name:         "nested key",
+			key:          "workspaces/dev/dev.yaml",
+			expectedName: "",
+		},
+		{
+			name:         "other key",
+			prefix:       "kusion",
+			key:          "workspaces/dev.yaml",
+			expectedName: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, workspaceNameFromKey(tc.prefix, tc.key))
+		})
+	}
+}
@@ -19,6 +19,9 @@ func CompleteWorkspace(ws *v1.Workspace, name string) {
 	if ws.Backends != nil && GetBackendName(ws.Backends) == v1.DeprecatedBackendMysql {
 		CompleteMysqlConfig(ws.Backends.Mysql)
 	}
+	if ws.Backends != nil && GetBackendName(ws.Backends) == v1.DeprecatedBackendPostgres {
+		CompletePostgresConfig(ws.Backends.Postgres)
+	}
 }
 
 // GetProjectModuleConfigs returns the module configs of a specified project, whose key is the module name, should be called after ValidateModuleConfigs.
@@ -104,6 +107,27 @@ func GetKubernetesConfig(configs *v1.RuntimeConfigs) *v1.KubernetesConfig {
 	return configs.Kubernetes
 }
 
+// GetSecretStoreSpec returns the secret store spec of the workspace. If the Kubernetes provider doesn't specify
+// the kubeconfig, a copy of the spec is returned whose kubeconfig is the one of the Kubernetes runtime.
+func GetSecretStoreSpec(ws *v1.Workspace) *v1.SecretStoreSpec {
+	spec := ws.SecretStore
+	if spec == nil || spec.Provider == nil || spec.Provider.Kubernetes == nil || spec.Provider.Kubernetes.KubeConfig != "" {
+		return spec
+	}
+	kubeConfig := GetKubernetesConfig(ws.Runtimes)
+	if kubeConfig == nil || kubeConfig.KubeConfig == "" {
+		return spec
+	}
+
+	provider := *spec.Provider
+	kubernetes := *provider.Kubernetes
+	kubernetes.KubeConfig = kubeConfig.KubeConfig
+	provider.Kubernetes = &kubernetes
+	completed := *spec
+	completed.Provider = &provider
+	return &completed
+}
+
 // GetTerraformConfig returns terraform config from runtime config, should be called after
 // ValidateRuntimeConfigs.
 // If got empty terraform config, return nil.
@@ -146,6 +170,12 @@ func GetBackendName(configs *v1.DeprecatedBackendConfigs) string {
 	if configs.S3 != nil {
 		return v1.DeprecatedBackendS3
 	}
+	if configs.Postgres != nil {
+		return v1.DeprecatedBackendPostgres
+	}
+	if configs.Sqlite != nil {
+		return v1.DeprecatedBackendSqlite
+	}
 	return v1.DeprecatedBackendLocal
 }
 
@@ -154,6 +184,11 @@ func GetMysqlPasswordFromEnv() string {
 	return os.Getenv(v1.EnvBackendMysqlPassword)
 }
 
+// GetPostgresPasswordFromEnv returns postgres password set by environment variables.
+func GetPostgresPasswordFromEnv() string {
+	return os.Getenv(v1.EnvBackendPostgresPassword)
+}
+
 // GetOssSensitiveDataFromEnv returns oss accessKeyID, accessKeySecret set by environment variables.
 func GetOssSensitiveDataFromEnv() (string, string) {
 	return os.Getenv(v1.EnvOssAccessKeyID), os.Getenv(v1.EnvOssAccessKeySecret)
@@ -176,6 +211,14 @@ func CompleteMysqlConfig(config *v1.DeprecatedMysqlConfig) {
 	}
 }
 
+// CompletePostgresConfig sets default value of postgres config if not set.
+func CompletePostgresConfig(config *v1.DeprecatedPostgresConfig) {
+	if config.Port == nil {
+		port := v1.DefaultPostgresPort
+		config.Port = &port
+	}
+}
+
 // CompleteWholeMysqlConfig constructs the whole mysql config by environment variables if set.
 func CompleteWholeMysqlConfig(config *v1.DeprecatedMysqlConfig) {
 	password := GetMysqlPasswordFromEnv()
@@ -132,6 +132,49 @@ func Test_GetKubernetesConfig(t *testing.T) {
 	}
 }
 
+func Test_GetSecretStoreSpec(t *testing.T) {
+	kubernetesSecretStore := func(kubeConfig string) *v1.SecretStoreSpec {
+		return &v1.SecretStoreSpec{
+			Provider: &v1.ProviderSpec{
+				Kubernetes: &v1.KubernetesProvider{Namespace: "shared-secrets", KubeConfig: kubeConfig},
+			},
+		}
+	}
+	testcases := []struct {
+		name         string
+		ws           *v1.Workspace
+		expectedSpec *v1.SecretStoreSpec
+	}{
+		{
+			name:         "use kubeconfig of runtime",
+			ws:           &v1.Workspace{Runtimes: mockValidRuntimeConfigs(), SecretStore: kubernetesSecretStore("")},
+			expectedSpec: kubernetesSecretStore(mockValidKubernetesConfig().KubeConfig),
+		},
+		{
+			name:         "use kubeconfig of provider",
+			ws:           &v1.Workspace{Runtimes: mockValidRuntimeConfigs(), SecretStore: kubernetesSecretStore("/etc/kubeconfig")},
+			expectedSpec: kubernetesSecretStore("/etc/kubeconfig"),
+		},
+		{
+			name:         "no runtime config",
+			ws:           &v1.Workspace{SecretStore: kubernetesSecretStore("")},
+			expectedSpec: kubernetesSecretStore(""),
+		},
+		{
+			name:         "nil secret store",
+			ws:           &v1.Workspace{Runtimes: mockValidRuntimeConfigs()},
+			expectedSpec: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := GetSecretStoreSpec(tc.ws)
+			assert.Equal(t, tc.expectedSpec, spec)
+		})
+	}
+}
+
 func Test_GetTerraformConfig(t *testing.T) {
 	testcases := []struct {
 		name                    string
@@ -3,14 +3,16 @@ package workspace
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	v1 "kusionstack.io/kusion/pkg/apis/core/v1"
+	"kusionstack.io/kusion/pkg/workspace/storages"
 )
 
 var (
-	ErrEmptyWorkspaceName = errors.New("empty workspace name")
+	ErrEmptyWorkspaceName = storages.ErrEmptyWorkspaceName
 
 	ErrEmptyModuleName                      = errors.New("empty module name")
 	ErrEmptyModuleConfig                    = errors.New("empty module config")
@@ -37,6 +39,10 @@ var (
 	ErrEmptyMysqlUser       = errors.New("empty mysql db user")
 	ErrEmptyMysqlHost       = errors.New("empty mysql host")
 	ErrInvalidMysqlPort     = errors.New("mysql port must be between 1 and 65535")
+	ErrEmptyPostgresDBName  = errors.New("empty postgres db name")
+	ErrEmptyPostgresUser    = errors.New("empty postgres db user")
+	ErrEmptyPostgresHost    = errors.New("empty postgres host")
+	ErrInvalidPostgresPort  = errors.New("postgres port must be between 1 and 65535")
 	ErrEmptyBucket          = errors.New("empty bucket")
 	ErrEmptyAccessKeyID     = errors.New("empty access key id")
 	ErrEmptyAccessKeySecret = errors.New("empty access key secret")
@@ -47,18 +53,36 @@ var (
 	ErrMultiSecretStoreProviders = errors.New("may not specify more than 1 secret store provider")
 	ErrEmptyAWSRegion            = errors.New("region must be provided when using AWS Secrets Manager")
 	ErrEmptyVaultServer          = errors.New("server address must be provided when using Hashicorp Vault")
+	ErrMissingVaultAuthMethod    = errors.New("must specify an auth method of Hashicorp Vault")
+	ErrMultiVaultAuthMethods     = errors.New("may not specify more than 1 auth method of Hashicorp Vault")
+	ErrEmptyVaultAuthRole        = errors.New("role must be provided when using the Kubernetes auth method of Hashicorp Vault")
+	ErrVaultClientCertKeyPair    = errors.New("client cert and client key of Hashicorp Vault must be provided together")
 	ErrEmptyVaultURL             = errors.New("vault url must be provided when using Azure KeyVault")
 	ErrEmptyTenantID             = errors.New("azure tenant id must be provided when using Azure KeyVault")
 	ErrEmptyAlicloudRegion       = errors.New("region must be provided when using Alicloud Secrets Manager")
+	ErrEmptySecretFilePath       = errors.New("path must be provided when using the encrypted secret file")
+	ErrEmptySecretNamespace      = errors.New("namespace must be provided when using the Kubernetes secret store")
 	ErrMissingProviderType       = errors.New("must specify a provider type")
+	ErrInvalidRefreshInterval    = errors.New("invalid refresh interval of external secrets")
 )
 
 // ValidateWorkspace is used to validate the workspace get or set in the storage, and does not validate the
-// config which can get from environment variables, such as access key id in backend configs.
+// config which can get from environment variables, such as access key id in backend configs. For the workspace
+// which extends others, only the extends and the configs not merged are validated, for the others can be partial,
+// and the effective workspace is validated when creating or updating by the Operator.
 func ValidateWorkspace(ws *v1.Workspace) error {
 	if ws.Name == "" {
 		return ErrEmptyWorkspaceName
 	}
+	if len(ws.Extends) != 0 {
+		if err := validateExtends(ws); err != nil {
+			return err
+		}
+		if ws.Backends != nil {
+			return ValidateBackendConfigs(ws.Backends)
+		}
+		return nil
+	}
 	if ws.Modules != nil {
 		if err := ValidateModuleConfigs(ws.Modules); err != nil {
 			return err
@@ -249,6 +273,9 @@ func ValidateBackendConfigs(configs *v1.DeprecatedBackendConfigs) error {
 		}
 		return nil
 	}
+	if configs.Postgres != nil {
+		return ValidatePostgresConfig(configs.Postgres)
+	}
 	return nil
 }
 
@@ -269,7 +296,15 @@ func configureMoreThanOneBackend(configs *v1.DeprecatedBackendConfigs) bool {
 	if moreThanOneConfig {
 		return moreThanOneConfig
 	}
-	_, moreThanOneConfig = configCondition(configured, configs.S3 != nil)
+	configured, moreThanOneConfig = configCondition(configured, configs.S3 != nil)
+	if moreThanOneConfig {
+		return moreThanOneConfig
+	}
+	configured, moreThanOneConfig = configCondition(configured, configs.Postgres != nil)
+	if moreThanOneConfig {
+		return moreThanOneConfig
+	}
+	_, moreThanOneConfig = configCondition(configured, configs.Sqlite != nil)
 	return moreThanOneConfig
 }
 
@@ -290,6 +325,23 @@ func ValidateMysqlConfig(config *v1.DeprecatedMysqlConfig) error {
 	return nil
 }
 
+// ValidatePostgresConfig is used to validate postgresConfig is valid or not.
+func ValidatePostgresConfig(config *v1.DeprecatedPostgresConfig) error {
+	if config.DBName == "" {
+		return ErrEmptyPostgresDBName
+	}
+	if config.User == "" {
+		return ErrEmptyPostgresUser
+	}
+	if config.Host == "" {
+		return ErrEmptyPostgresHost
+	}
+	if config.Port != nil && (*config.Port < 1 || *config.Port > 65535) {
+		return ErrInvalidPostgresPort
+	}
+	return nil
+}
+
 // ValidateGenericObjectStorageConfig is used to validate ossConfig and s3Config is valid or not, where the
 // sensitive data items set as environment variables are not included.
 func ValidateGenericObjectStorageConfig(config *v1.GenericObjectStorageConfig) error {
@@ -372,11 +424,40 @@ func ValidateSecretStoreConfig(spec *v1.SecretStoreSpec) []error {
 			allErrs = append(allErrs, validateAlicloudSecretStore(spec.Provider.Alicloud)...)
 		}
 	}
+	if spec.Provider.File != nil {
+		if numProviders > 0 {
+			allErrs = append(allErrs, ErrMultiSecretStoreProviders)
+		} else {
+			numProviders++
+			allErrs = append(allErrs, validateFileSecretStore(spec.Provider.File)...)
+		}
+	}
+	if spec.Provider.Kubernetes != nil {
+		if numProviders > 0 {
+			allErrs = append(allErrs, ErrMultiSecretStoreProviders)
+		} else {
+			numProviders++
+			allErrs = append(allErrs, validateKubernetesSecretStore(spec.Provider.Kubernetes)...)
+		}
+	}
 
 	if numProviders == 0 {
 		allErrs = append(allErrs, ErrMissingProviderType)
 	}
+	if spec.ExternalSecrets != nil {
+		allErrs = append(allErrs, validateExternalSecretsConfig(spec.ExternalSecrets)...)
+	}
+
+	return allErrs
+}
 
+func validateExternalSecretsConfig(config *v1.ExternalSecretsConfig) []error {
+	var allErrs []error
+	if config.RefreshInterval != "" {
+		if _, err := time.ParseDuration(config.RefreshInterval); err != nil {
+			allErrs = append(allErrs, fmt.Errorf("%w: %v", ErrInvalidRefreshInterval, err))
+		}
+	}
 	return allErrs
 }
 
@@ -393,6 +474,32 @@ func validateHashiVaultSecretStore(vault *v1.VaultProvider) []error {
 	if len(vault.Server) == 0 {
 		allErrs = append(allErrs, ErrEmptyVaultServer)
 	}
+	if vault.Auth != nil {
+		allErrs = append(allErrs, validateHashiVaultAuth(vault.Auth)...)
+	}
+	if vault.TLS != nil && (vault.TLS.ClientCert == "") != (vault.TLS.ClientKey == "") {
+		allErrs = append(allErrs, ErrVaultClientCertKeyPair)
+	}
+	return allErrs
+}
+
+func validateHashiVaultAuth(auth *v1.VaultAuth) []error {
+	var allErrs []error
+	numMethods := 0
+	for _, configured := range []bool{auth.Token != nil, auth.AppRole != nil, auth.Kubernetes != nil, auth.JWT != nil} {
+		if configured {
+			numMethods++
+		}
+	}
+	switch {
+	case numMethods == 0:
+		allErrs = append(allErrs, ErrMissingVaultAuthMethod)
+	case numMethods > 1:
+		allErrs = append(allErrs, ErrMultiVaultAuthMethods)
+	}
+	if auth.Kubernetes != nil && len(auth.Kubernetes.Role) == 0 {
+		allErrs = append(allErrs, ErrEmptyVaultAuthRole)
+	}
 	return allErrs
 }
 
@@ -414,3 +521,19 @@ func validateAlicloudSecretStore(ac *v1.AlicloudProvider) []error {
 	}
 	return allErrs
 }
+
+func validateFileSecretStore(file *v1.FileProvider) []error {
+	var allErrs []error
+	if len(file.Path) == 0 {
+		allErrs = append(allErrs, ErrEmptySecretFilePath)
+	}
+	return allErrs
+}
+
+func validateKubernetesSecretStore(kubernetes *v1.KubernetesProvider) []error {
+	var allErrs []error
+	if len(kubernetes.Namespace) == 0 {
+		allErrs = append(allErrs, ErrEmptySecretNamespace)
+	}
+	return allErrs
+}
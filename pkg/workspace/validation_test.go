@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -243,6 +244,30 @@ func TestValidateWorkspace(t *testing.T) {
 			success:   false,
 			workspace: &v1.Workspace{},
 		},
+		{
+			name:    "valid partial workspace with extends",
+			success: true,
+			workspace: &v1.Workspace{
+				Name:    "dev",
+				Extends: []string{"base"},
+				Modules: v1.ModuleConfigs{"database": {Default: v1.GenericConfig{}}},
+			},
+		},
+		{
+			name:      "invalid workspace extends itself",
+			success:   false,
+			workspace: &v1.Workspace{Name: "dev", Extends: []string{"base", "dev"}},
+		},
+		{
+			name:      "invalid workspace repeated extends",
+			success:   false,
+			workspace: &v1.Workspace{Name: "dev", Extends: []string{"base", "base"}},
+		},
+		{
+			name:      "invalid workspace empty extends name",
+			success:   false,
+			workspace: &v1.Workspace{Name: "dev", Extends: []string{""}},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -481,6 +506,34 @@ func TestValidateBackendConfigs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid backend configs sqlite",
+			success: true,
+			backendConfigs: &v1.DeprecatedBackendConfigs{
+				Sqlite: &v1.DeprecatedSqliteConfig{},
+			},
+		},
+		{
+			name:    "invalid backend configs multiple backends postgres and sqlite",
+			success: false,
+			backendConfigs: &v1.DeprecatedBackendConfigs{
+				Postgres: &v1.DeprecatedPostgresConfig{
+					DBName: "kusion_db",
+					User:   "kusion",
+					Host:   "127.0.0.1",
+				},
+				Sqlite: &v1.DeprecatedSqliteConfig{},
+			},
+		},
+		{
+			name:    "invalid backend configs postgres",
+			success: false,
+			backendConfigs: &v1.DeprecatedBackendConfigs{
+				Postgres: &v1.DeprecatedPostgresConfig{
+					DBName: "kusion_db",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -550,6 +603,67 @@ func TestValidateMysqlConfig(t *testing.T) {
 	}
 }
 
+func TestValidatePostgresConfig(t *testing.T) {
+	invalidPort := 65536
+	testcases := []struct {
+		name           string
+		success        bool
+		postgresConfig *v1.DeprecatedPostgresConfig
+	}{
+		{
+			name:    "valid postgres config",
+			success: true,
+			postgresConfig: &v1.DeprecatedPostgresConfig{
+				DBName:  "kusion_db",
+				User:    "kusion",
+				Host:    "127.0.0.1",
+				SSLMode: "disable",
+			},
+		},
+		{
+			name:    "invalid postgres config empty dbName",
+			success: false,
+			postgresConfig: &v1.DeprecatedPostgresConfig{
+				User: "kusion",
+				Host: "127.0.0.1",
+			},
+		},
+		{
+			name:    "invalid postgres config empty user",
+			success: false,
+			postgresConfig: &v1.DeprecatedPostgresConfig{
+				DBName: "kusion_db",
+				Host:   "127.0.0.1",
+			},
+		},
+		{
+			name:    "invalid postgres config empty host",
+			success: false,
+			postgresConfig: &v1.DeprecatedPostgresConfig{
+				DBName: "kusion_db",
+				User:   "kusion",
+			},
+		},
+		{
+			name:    "invalid postgres config invalid port",
+			success: false,
+			postgresConfig: &v1.DeprecatedPostgresConfig{
+				DBName: "kusion_db",
+				User:   "kusion",
+				Host:   "127.0.0.1",
+				Port:   &invalidPort,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePostgresConfig(tc.postgresConfig)
+			assert.Equal(t, tc.success, err == nil)
+		})
+	}
+}
+
 func TestValidateValidateGenericObjectStorageConfig(t *testing.T) {
 	testcases := []struct {
 		name    string
@@ -724,6 +838,50 @@ func TestValidateHashiVaultSecretStore(t *testing.T) {
 			},
 			want: []error{ErrEmptyVaultServer},
 		},
+		{
+			name: "valid Hashi Vault provider spec with auth and tls",
+			args: args{
+				vault: &v1.VaultProvider{
+					Server:    "https://vault.example.com:8200",
+					Namespace: "ns1",
+					Auth: &v1.VaultAuth{
+						Kubernetes: &v1.VaultKubernetesAuth{Role: "kusion"},
+					},
+					TLS: &v1.VaultTLSConfig{
+						CACert:     "ca.pem",
+						ClientCert: "client.pem",
+						ClientKey:  "client-key.pem",
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid Hashi Vault auth and tls",
+			args: args{
+				vault: &v1.VaultProvider{
+					Server: "https://vault.example.com:8200",
+					Auth: &v1.VaultAuth{
+						AppRole:    &v1.VaultAppRoleAuth{},
+						Kubernetes: &v1.VaultKubernetesAuth{},
+					},
+					TLS: &v1.VaultTLSConfig{
+						ClientCert: "client.pem",
+					},
+				},
+			},
+			want: []error{ErrMultiVaultAuthMethods, ErrEmptyVaultAuthRole, ErrVaultClientCertKeyPair},
+		},
+		{
+			name: "empty Hashi Vault auth",
+			args: args{
+				vault: &v1.VaultProvider{
+					Server: "https://vault.example.com:8200",
+					Auth:   &v1.VaultAuth{},
+				},
+			},
+			want: []error{ErrMissingVaultAuthMethod},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -801,6 +959,72 @@ func TestValidateAlicloudSecretStore(t *testing.T) {
 	}
 }
 
+func TestValidateFileSecretStore(t *testing.T) {
+	type args struct {
+		file *v1.FileProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		want []error
+	}{
+		{
+			name: "valid file provider spec",
+			args: args{
+				file: &v1.FileProvider{
+					Path: "secrets.enc.yaml",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid file provider spec",
+			args: args{
+				file: &v1.FileProvider{},
+			},
+			want: []error{ErrEmptySecretFilePath},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, validateFileSecretStore(tt.args.file), "validateFileSecretStore(%v)", tt.args.file)
+		})
+	}
+}
+
+func TestValidateKubernetesSecretStore(t *testing.T) {
+	type args struct {
+		kubernetes *v1.KubernetesProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		want []error
+	}{
+		{
+			name: "valid kubernetes provider spec",
+			args: args{
+				kubernetes: &v1.KubernetesProvider{
+					Namespace: "shared-secrets",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid kubernetes provider spec",
+			args: args{
+				kubernetes: &v1.KubernetesProvider{},
+			},
+			want: []error{ErrEmptySecretNamespace},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, validateKubernetesSecretStore(tt.args.kubernetes), "validateKubernetesSecretStore(%v)", tt.args.kubernetes)
+		})
+	}
+}
+
 func TestValidateSecretStoreConfig(t *testing.T) {
 	type args struct {
 		spec *v1.SecretStoreSpec
@@ -855,6 +1079,22 @@ func TestValidateSecretStoreConfig(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "invalid refresh interval of external secrets",
+			args: args{
+				spec: &v1.SecretStoreSpec{
+					Provider: &v1.ProviderSpec{
+						AWS: &v1.AWSProvider{
+							Region: "us-east-1",
+						},
+					},
+					ExternalSecrets: &v1.ExternalSecretsConfig{
+						RefreshInterval: "1 hour",
+					},
+				},
+			},
+			want: []error{fmt.Errorf("%w: %v", ErrInvalidRefreshInterval, `time: unknown unit " hour" in duration "1 hour"`)},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {